@@ -0,0 +1,81 @@
+package minimax
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Aliasing tells how the engine guards against successor functions that
+// return pointers into memory they reuse, such as a buffer of states
+// overwritten by the next call. The engine keeps the states it is given in
+// its cache and search graph, so such states silently change under it.
+type Aliasing int
+
+const (
+	AliasTrust Aliasing = iota // Keep the states successors return (the default)
+	AliasCopy                  // Copy every successor state as soon as it is returned
+	AliasCheck                 // Copy them, and panic if a returned state changes afterwards
+)
+
+// WithAliasing sets how the engine guards against successor states that
+// alias reused memory. AliasCopy makes the engine own a copy of every
+// successor, made with the function of WithClone if given and by assignment
+// otherwise, at the cost of an allocation per state. AliasCheck does the
+// same and also remembers the states of the last call to successors: if the
+// next call changed any of them, it panics naming the state, to find the bug
+// in the game rather than work around it.
+func WithAliasing(mode Aliasing) Option {
+	return func(o *options) {
+		o.aliasing = mode
+	}
+}
+
+// aliasGuard remembers the states successors last returned, and their values
+// then, to tell whether they changed since
+type aliasGuard[T comparable] struct {
+	mu       sync.Mutex
+	parent   T    // State whose successors were returned
+	returned []*T // States as returned
+	values   []T  // Values of the states when returned
+}
+
+// own returns copies of the successor states succ of state, checking first
+// that the states returned before did not change, if asked to
+func (cf *config[T]) own(state *T, succ []*T) []*T {
+	if g := cf.aliasGuard; g != nil {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		for i, p := range g.returned {
+			if *p != g.values[i] {
+				panic(fmt.Sprintf("minimax: successor %d of state %s changed after being returned, to %s; successors must not reuse the memory of the states they return",
+					i, cf.describe(&g.parent), cf.describe(p)))
+			}
+		}
+		g.parent = *state
+		g.returned = append(g.returned[:0], succ...)
+		g.values = g.values[:0]
+		for _, p := range succ {
+			g.values = append(g.values, *p)
+		}
+	}
+
+	owned := make([]*T, len(succ))
+	for i, p := range succ {
+		if cf.clone != nil {
+			owned[i] = cf.clone(p)
+		} else {
+			c := *p
+			owned[i] = &c
+		}
+	}
+	return owned
+}
+
+// describe writes state with the function of WithFormat if given, and with
+// %v otherwise
+func (cf *config[T]) describe(state *T) string {
+	if cf.format != nil {
+		return cf.format(state)
+	}
+	return fmt.Sprintf("%v", *state)
+}
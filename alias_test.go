@@ -0,0 +1,52 @@
+package minimax
+
+import (
+	"strings"
+	"testing"
+)
+
+// reusedSuccessors returns the successors of Nim in a buffer it overwrites on
+// every call, the bug WithAliasing guards against
+func reusedSuccessors() func(*nimState) []*nimState {
+	var buf [3]nimState
+	return func(s *nimState) []*nimState {
+		var succ []*nimState
+		for take := 1; take <= min(3, s.stones); take++ {
+			buf[take-1] = nimState{stones: s.stones - take, aiTurn: !s.aiTurn}
+			succ = append(succ, &buf[take-1])
+		}
+		return succ
+	}
+}
+
+// TestAliasCopy tests that copied successors give the moves of a correct game.
+func TestAliasCopy(t *testing.T) {
+	root := nimState{stones: 10, aiTurn: true}
+	want := Make(&root, nimTerminal, nimUtility, nimSuccessors, true)
+	mm := Make(&root, nimTerminal, nimUtility, reusedSuccessors(), true, WithAliasing(AliasCopy))
+
+	for stones := 1; stones <= 10; stones++ {
+		state := nimState{stones: stones, aiTurn: true}
+		if got, best := mm.Solve(state), want.Solve(state); *got != *best {
+			t.Errorf("%d stones: expected %v, got %v", stones, *best, *got)
+		}
+	}
+}
+
+// TestAliasCheck tests that a successor changed after being returned is reported.
+func TestAliasCheck(t *testing.T) {
+	defer func() {
+		if p, _ := recover().(string); !strings.Contains(p, "changed after being returned") {
+			t.Errorf("Expected a panic naming the changed successor, got %q", p)
+		}
+	}()
+	Make(&nimState{stones: 10, aiTurn: true}, nimTerminal, nimUtility, reusedSuccessors(), true, WithAliasing(AliasCheck))
+}
+
+// TestAliasCheckCorrect tests that correct games pass the check.
+func TestAliasCheckCorrect(t *testing.T) {
+	mm := Make(&nimState{stones: 10, aiTurn: true}, nimTerminal, nimUtility, nimSuccessors, true, WithAliasing(AliasCheck))
+	if move := mm.Solve(nimState{stones: 10, aiTurn: true}); move == nil || move.stones != 8 {
+		t.Errorf("Expected to leave 8 stones, got %v", move)
+	}
+}
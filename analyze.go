@@ -0,0 +1,110 @@
+package minimax
+
+import (
+	"maps"
+	"time"
+)
+
+// Analyze studies state in the background until told to stop, as analysis
+// GUIs expect. It searches one ply deeper at a time, trying the best moves of
+// the previous iteration first, and reports through the WithProgress callback
+// as it goes: periodically within an iteration, and with the principal
+// variation in PV whenever an iteration completes. Reports count the nodes
+// and time of the whole analysis.
+//
+// Analysis goes on until Stop is called, an iteration solves state without
+// reaching its depth limit, or the iteration of MaxDepth completes if one was
+// given to Make; the other limits are ignored. A final report with Done set
+// then repeats the deepest completed iteration, whose best move is returned by
+// Wait. Like SolveAsync, the Minimax must not be used again until the
+// analysis is over; it then holds the results of that iteration.
+func (m Minimax[T]) Analyze(state T) *Search[T] {
+	h := &Search[T]{done: make(chan struct{})}
+
+	go func() {
+		defer close(h.done)
+		m.analyze(h, state)
+	}()
+
+	return h
+}
+
+// analyze deepens the search of state on behalf of the handle h until it is stopped
+func (m Minimax[T]) analyze(h *Search[T], state T) {
+	if m.config.isTerminal(&state) {
+		return
+	}
+
+	start := time.Now()
+	report := func(p Progress[T]) {
+		if m.config.progress != nil {
+			p.Elapsed = time.Since(start)
+			if secs := p.Elapsed.Seconds(); secs > 0 {
+				p.NPS = int(float64(p.Nodes) / secs)
+			}
+			m.config.progress(p)
+		}
+	}
+
+	var best *T
+	var pv []*T
+	var last Result
+	var nodes int
+	var cache map[T]*T
+	for depth := 1; m.config.limits.MaxDepth == 0 || depth <= m.config.limits.MaxDepth; depth++ {
+		cf := m.config
+		cf.limits = Limits{MaxDepth: depth}
+		cf.autoProbes = 0
+		cf.hints = cache
+		before := nodes
+		cf.progress = func(p Progress[T]) {
+			if !p.Done { // Completed iterations are reported with their PV below
+				p.Nodes += before
+				report(p)
+			}
+		}
+		it := build(&state, cf, h)
+		res := it.Result()
+		nodes += res.Nodes
+		move := it.moveMap[state]
+
+		if res.Stopped == StopCanceled {
+			// Stopped, its partial result only helps if nothing else was found
+			if best == nil && move != nil {
+				best, last, cache = move, res, it.moveMap
+				pv = []*T{move}
+			}
+			break
+		}
+		if move == nil {
+			break
+		}
+		best, last, cache = move, res, it.moveMap
+		pv = principal(cache, state, depth)
+		report(Progress[T]{Depth: res.Depth, BestMove: best, Score: res.Value, Nodes: nodes, PV: pv})
+		if res.Stopped == StopNone {
+			break // Solved, deeper iterations would find the same
+		}
+	}
+
+	if cache != nil {
+		maps.Copy(m.moveMap, cache)
+	}
+	last.Nodes = nodes
+	last.Elapsed = time.Since(start)
+	*m.result = last
+	if best != nil {
+		m.cache.remember(state, last.Value)
+		h.setBest(best, last.Value)
+		report(Progress[T]{Depth: last.Depth, BestMove: best, Score: last.Value, Nodes: nodes, PV: pv, Done: true})
+	}
+}
+
+// principal follows the best moves in moves from state, for up to plies moves
+func principal[T comparable](moves map[T]*T, state T, plies int) []*T {
+	var pv []*T
+	for move := moves[state]; move != nil && len(pv) < plies; move = moves[*move] {
+		pv = append(pv, move)
+	}
+	return pv
+}
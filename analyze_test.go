@@ -0,0 +1,96 @@
+package minimax
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestAnalyze tests that analysis deepens until stopped, reporting every
+// completed iteration with a principal variation as long as its depth.
+func TestAnalyze(t *testing.T) {
+	var mu sync.Mutex
+	var reports []Progress[pathState]
+	deep := make(chan struct{})
+	progress := WithProgress(time.Millisecond, func(p Progress[pathState]) {
+		mu.Lock()
+		defer mu.Unlock()
+		reports = append(reports, p)
+		if len(p.PV) == 5 {
+			close(deep)
+		}
+	})
+	state := pathState{depth: pathDepth}
+	mm := Make(&state, pathTerminal, pathUtility, pathSuccessors, true, progress)
+	reports = nil // Drop the report of the search run by Make
+
+	search := mm.Analyze(pathState{})
+	select {
+	case <-deep:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Analysis did not reach depth 5")
+	}
+	search.Stop()
+	move := search.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	final := reports[len(reports)-1]
+	if !final.Done || move == nil || final.BestMove != move {
+		t.Fatalf("Expected a final report of the best move %v, got %+v", move, final)
+	}
+	iterations, nodes := 0, 0
+	for _, p := range reports[:len(reports)-1] {
+		if p.Done {
+			t.Fatalf("Expected a single final report, got %+v", p)
+		}
+		if p.Nodes < nodes {
+			t.Errorf("Expected node counts to grow across iterations, got %d after %d", p.Nodes, nodes)
+		}
+		nodes = p.Nodes
+		if p.PV != nil {
+			iterations++
+			if len(p.PV) != iterations || p.PV[0] != p.BestMove {
+				t.Errorf("Expected iteration %d to start its PV with its best move, got %+v", iterations, p)
+			}
+			for i, s := range p.PV {
+				if s.depth != i+1 {
+					t.Errorf("Expected a line of successive moves, got %v", p.PV)
+				}
+			}
+		}
+	}
+	if iterations < 5 || len(final.PV) < 5 {
+		t.Errorf("Expected at least 5 iterations, got %d and a final PV of %d moves", iterations, len(final.PV))
+	}
+	if res := mm.Result(); res.Nodes != final.Nodes {
+		t.Errorf("Expected the result to count the nodes of all iterations, got %d instead of %d", res.Nodes, final.Nodes)
+	}
+}
+
+// TestAnalyzeEnds tests that analysis ends by itself once the state is solved
+// or the depth limit is reached.
+func TestAnalyzeEnds(t *testing.T) {
+	state := nimState{stones: 7, aiTurn: true}
+	var final Progress[nimState]
+	progress := WithProgress(time.Hour, func(p Progress[nimState]) { final = p })
+	mm := Make(&state, nimTerminal, nimUtility, nimSuccessors, true, progress)
+
+	move := mm.Analyze(state).Wait()
+	if move == nil || move.stones != 4 || !final.Done {
+		t.Fatalf("Expected to leave 4 stones, got %v and %+v", move, final)
+	}
+	if last := final.PV[len(final.PV)-1]; !nimTerminal(last) {
+		t.Errorf("Expected the PV to end the game, got %v", final.PV)
+	}
+	if res := mm.Result(); res.Stopped != StopNone {
+		t.Errorf("Expected a solved state, got %+v", res)
+	}
+
+	pathStart := pathState{depth: pathDepth}
+	path := Make(&pathStart, pathTerminal, pathUtility, pathSuccessors, true, WithLimits(Limits{MaxDepth: 3}))
+	path.Analyze(pathState{}).Wait()
+	if res := path.Result(); res.Stopped != StopDepth || res.Depth != 3 {
+		t.Errorf("Expected analysis to stop at depth 3, got %+v", res)
+	}
+}
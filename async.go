@@ -0,0 +1,133 @@
+package minimax
+
+import (
+	"maps"
+	"sync"
+	"sync/atomic"
+)
+
+// Search is a handle on a search running in the background, see SolveAsync
+type Search[T comparable] struct {
+	stop atomic.Bool   // Set to ask the search to stop
+	done chan struct{} // Closed when the search is over
+
+	mu    sync.Mutex
+	best  *T                 // Best move so far, final once done is closed
+	score int                // Value of best for the AI
+	moves func(T) (*T, bool) // Looks moves up in the running search, nil between searches
+
+	pump *pumper // Hands control back to the owner of a pumped search, nil otherwise
+}
+
+// SolveAsync starts looking for the best move from state in a new goroutine
+// and returns immediately. The Minimax must not be used again until the
+// search is over, since the search updates its cache and result.
+func (m Minimax[T]) SolveAsync(state T) *Search[T] {
+	h := &Search[T]{done: make(chan struct{})}
+
+	go func() {
+		defer close(h.done)
+		m.solveInto(h, state)
+	}()
+
+	return h
+}
+
+// solveInto finds the best move from state on behalf of the handle h
+func (m Minimax[T]) solveInto(h *Search[T], state T) {
+	if m.config.isTerminal(&state) {
+		return
+	}
+	// Moves cached on the way to another state have no value of their own, so
+	// only states searched from are answered from the cache
+	move := m.moveMap[state]
+	value, known := m.cache.value(state)
+	m.cache.count(move != nil && known)
+	if move != nil && known {
+		h.setBest(move, value)
+		return
+	}
+
+	newMM := build(&state, m.config, h)
+	maps.Copy(m.moveMap, newMM.moveMap)
+	*m.result = *newMM.result
+	m.cache.remember(state, newMM.result.Value)
+	if move := m.moveMap[state]; move != nil {
+		h.setBest(move, newMM.result.Value)
+	}
+}
+
+// Stop asks the search to finish as soon as possible, keeping the best move
+// found so far. It does not wait for the search to end.
+func (h *Search[T]) Stop() {
+	h.stop.Store(true)
+}
+
+// Wait blocks until the search is over and returns the best move, which is
+// nil if the state is terminal or the search stopped before evaluating any move
+func (h *Search[T]) Wait() *T {
+	<-h.done
+	move, _ := h.Best()
+	return move
+}
+
+// Done returns a channel that is closed when the search is over
+func (h *Search[T]) Done() <-chan struct{} {
+	return h.done
+}
+
+// Best returns the best move found so far and its value for the AI.
+// It can be called at any time, from any goroutine.
+func (h *Search[T]) Best() (*T, int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.best, h.score
+}
+
+// Lookup returns the best move the running search has found so far from
+// state, for states whose search is over, such as from an observer pausing
+// the search. It reports false once the search is over, when the cache of the
+// Minimax answers instead, or before it starts.
+func (h *Search[T]) Lookup(state T) (*T, bool) {
+	h.mu.Lock()
+	moves := h.moves
+	h.mu.Unlock()
+	if moves == nil {
+		return nil, false
+	}
+	return moves(state)
+}
+
+// watch makes Lookup answer from the moves of s, or not at all if s is nil
+func (h *Search[T]) watch(s *search[T]) {
+	if h == nil {
+		return
+	}
+	var moves func(T) (*T, bool)
+	if s != nil {
+		moves = func(state T) (*T, bool) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			move, ok := s.mp[state]
+			return move, ok
+		}
+	}
+	h.mu.Lock()
+	h.moves = moves
+	h.mu.Unlock()
+}
+
+// setBest records a new best move
+func (h *Search[T]) setBest(move *T, score int) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	h.best, h.score = move, score
+	h.mu.Unlock()
+}
+
+// stopping reports whether the caller asked the search to stop
+func (h *Search[T]) stopping() bool {
+	return h != nil && h.stop.Load()
+}
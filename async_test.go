@@ -0,0 +1,104 @@
+package minimax
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSolveAsync tests that an asynchronous search finds the same move as Solve.
+func TestSolveAsync(t *testing.T) {
+	state := nimState{stones: 6, aiTurn: true}
+	mm := Make(&state, nimTerminal, nimUtility, nimSuccessors, true)
+
+	// Not in the cache, so a new search is needed
+	next := nimState{stones: 7, aiTurn: true}
+	move := mm.SolveAsync(next).Wait()
+	if move == nil || move.stones != 4 {
+		t.Errorf("Expected to leave 4 stones, got %v", move)
+	}
+	if cached := mm.Solve(next); cached != move {
+		t.Errorf("Expected the async result to be cached, got %v", cached)
+	}
+}
+
+// TestSolveAsyncCachedValue tests that an asynchronous search from a state
+// cached by the search of another reports the value of that state.
+func TestSolveAsyncCachedValue(t *testing.T) {
+	root := nimState{stones: 10, aiTurn: true}
+	mm := Make(&root, nimTerminal, nimUtility, nimSuccessors, true)
+
+	state := nimState{stones: 6, aiTurn: true}
+	if _, ok := mm.Lookup(state); !ok {
+		t.Fatalf("Expected %v to be cached by the search from %v", state, root)
+	}
+	want := Make(&state, nimTerminal, nimUtility, nimSuccessors, true).Result().Value
+
+	search := mm.SolveAsync(state)
+	search.Wait()
+	if _, got := search.Best(); got != want {
+		t.Errorf("Expected the value %d of %v, got %d", want, state, got)
+	}
+
+	// Searched from now, so answered from the cache
+	again := mm.SolveAsync(state)
+	again.Wait()
+	if _, got := again.Best(); got != want {
+		t.Errorf("Expected the cached value %d, got %d", want, got)
+	}
+	if hits, _ := mm.CacheStats(); hits != 1 {
+		t.Errorf("Expected 1 cache hit, got %d", hits)
+	}
+}
+
+// TestSolveAsyncStop tests that Stop ends a long search early.
+func TestSolveAsyncStop(t *testing.T) {
+	state := pathState{depth: pathDepth}
+	mm := Make(&state, pathTerminal, pathUtility, pathSuccessors, true)
+
+	search := mm.SolveAsync(pathState{})
+	time.Sleep(20 * time.Millisecond)
+	search.Stop()
+
+	select {
+	case <-search.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Search did not stop")
+	}
+	if got := mm.Result().Stopped; got != StopCanceled {
+		t.Errorf("Expected StopCanceled, got %v", got)
+	}
+}
+
+// TestSearchLookup tests looking moves up in a running search.
+func TestSearchLookup(t *testing.T) {
+	handle := make(chan *Search[nimState], 1)
+	var h *Search[nimState]
+	var found, root bool
+	observe := func(ev Event, s *nimState) {
+		if h == nil {
+			h = <-handle
+		}
+		if ev.Kind == EventDone {
+			_, found = h.Lookup(nimState{stones: 3, aiTurn: true})
+			_, root = h.Lookup(nimState{stones: 7, aiTurn: true})
+		}
+	}
+
+	// Made from a terminal state, whose events are skipped, so nothing is cached
+	end := nimState{aiTurn: true}
+	mm := Make(&end, nimTerminal, nimUtility, nimSuccessors, true, WithObserver(func(ev Event, s *nimState) {
+		if *s != end {
+			observe(ev, s)
+		}
+	}))
+	search := mm.SolveAsync(nimState{stones: 7, aiTurn: true})
+	handle <- search
+	search.Wait()
+
+	if !found || !root {
+		t.Errorf("Expected the moves searched to be found before the search ended, got %v and %v", found, root)
+	}
+	if _, ok := search.Lookup(nimState{stones: 7, aiTurn: true}); ok {
+		t.Error("Expected the cache to answer once the search is over")
+	}
+}
@@ -0,0 +1,59 @@
+package minimax
+
+import (
+	"math/rand"
+	"slices"
+)
+
+// autoDepthCap is the deepest depth WithAutoDepth considers without a MaxDepth
+const autoDepthCap = 64
+
+// WithAutoDepth picks the depth limit of every search from its node and time
+// budget: before searching, the tree below the state is estimated with
+// EstimateTree using probes random walks (100 if not positive), and MaxDepth
+// is set to the deepest depth whose full tree is expected to fit within
+// MaxNodes and MaxTime, or 1 if none does. No depth limit is set if the whole
+// game fits. The chosen depth is reported in Result.AutoDepth.
+//
+// The full tree is what alpha-beta visits at worst, so the choice errs on
+// the safe side, and MaxNodes and MaxTime remain hard limits. A MaxDepth
+// passed with WithLimits caps the choice. Without a node or time budget, and
+// in the iterations of SolveClock, which deepens by itself, nothing is chosen.
+func WithAutoDepth(probes int) Option {
+	if probes <= 0 {
+		probes = 100
+	}
+	return func(o *options) {
+		o.autoProbes = probes
+	}
+}
+
+// autoDepth returns the depth limit to search state with, 0 for none
+func (cf *config[T]) autoDepth(state *T) int {
+	if cf.autoProbes == 0 || cf.limits.MaxNodes == 0 && cf.limits.MaxTime == 0 {
+		return 0
+	}
+	deepest := cf.limits.MaxDepth
+	if deepest == 0 {
+		deepest = autoDepthCap
+	}
+	successors := func(s *T) []*T {
+		succ, release := cf.generate(s)
+		defer release()
+		return slices.Clone(succ)
+	}
+	estimates := estimateTree(*state, cf.isTerminal, successors, deepest, cf.autoProbes, rand.New(rand.NewSource(1)))
+
+	depth := 1
+	for i, e := range estimates {
+		if cf.limits.MaxNodes > 0 && e.Nodes > float64(cf.limits.MaxNodes) ||
+			cf.limits.MaxTime > 0 && e.Time > cf.limits.MaxTime {
+			break
+		}
+		if i > 0 && e.Nodes == estimates[i-1].Nodes && cf.limits.MaxDepth == 0 {
+			return 0 // No probe got this deep, the whole game fits
+		}
+		depth = e.Depth
+	}
+	return depth
+}
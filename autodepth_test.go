@@ -0,0 +1,27 @@
+package minimax
+
+import "testing"
+
+// TestAutoDepth tests that the deepest depth fitting the node budget is chosen and reported.
+func TestAutoDepth(t *testing.T) {
+	state := pathState{}
+	h := WithHeuristic(func(s *pathState) int { return int(s.path % 5) })
+	mm := Make(&state, pathTerminal, pathUtility, pathSuccessors, true, h,
+		WithCutoff(CutoffHeuristic), WithLimits(Limits{MaxNodes: 200}), WithAutoDepth(0))
+	res := mm.Result()
+	if res.AutoDepth != 4 || res.Depth != 4 || res.Stopped != StopDepth || res.Nodes > 121 {
+		t.Errorf("Expected a complete search to depth 4, got %+v", res)
+	}
+
+	capped := Make(&state, pathTerminal, pathUtility, pathSuccessors, true, h,
+		WithCutoff(CutoffHeuristic), WithLimits(Limits{MaxNodes: 200, MaxDepth: 2}), WithAutoDepth(0))
+	if res := capped.Result(); res.AutoDepth != 2 {
+		t.Errorf("Expected MaxDepth to cap the choice at 2, got %d", res.AutoDepth)
+	}
+
+	nim := nimState{stones: 5, aiTurn: true}
+	whole := Make(&nim, nimTerminal, nimUtility, nimSuccessors, true, WithLimits(Limits{MaxNodes: 1000}), WithAutoDepth(10))
+	if res := whole.Result(); res.AutoDepth != 0 || res.Unknown || res.Stopped != StopNone {
+		t.Errorf("Expected the whole game to be solved, got %+v", res)
+	}
+}
@@ -0,0 +1,53 @@
+package minimax
+
+import (
+	"cmp"
+	"slices"
+)
+
+// WithBeam turns the search into a beam search: every node keeps only its
+// width best children (at least 1) and the others are never searched. With
+// WithHeuristic, children are ranked by their heuristic value for the player
+// to move; otherwise the first width children are kept after move ordering,
+// such as WithHistory or a transposition table move, has run. The kept
+// children stay in that order.
+//
+// Beam search makes games with branching factors in the hundreds playable, at
+// the price of missing moves ranked poorly. Its values are therefore
+// approximate, and count as unknown unless they are proven wins for the
+// player who chose them.
+func WithBeam(width int) Option {
+	return func(o *options) {
+		o.beam = max(width, 1)
+	}
+}
+
+// narrow drops all but the best width children of n
+func (s *search[T]) narrow(n *node[T], width int) {
+	if len(n.children) <= width {
+		return
+	}
+	n.narrowed = true
+	s.truncated.Store(true)
+	if s.heuristic == nil {
+		n.children = n.children[:width]
+		return
+	}
+
+	ranked := slices.Clone(n.children)
+	evals := make(map[*node[T]]int, len(ranked))
+	for _, child := range ranked {
+		evals[child] = s.heuristic(child.elem)
+	}
+	slices.SortStableFunc(ranked, func(a, b *node[T]) int {
+		if n.isMax {
+			return cmp.Compare(evals[b], evals[a])
+		}
+		return cmp.Compare(evals[a], evals[b])
+	})
+	keep := make(map[*node[T]]bool, width)
+	for _, child := range ranked[:width] {
+		keep[child] = true
+	}
+	n.children = slices.DeleteFunc(n.children, func(c *node[T]) bool { return !keep[c] })
+}
@@ -0,0 +1,29 @@
+package minimax
+
+import "testing"
+
+// TestBeam tests that a beam as wide as the tree changes nothing, and a narrow one follows the heuristic.
+func TestBeam(t *testing.T) {
+	state := pathState{depth: pathDepth}
+	limit := WithLimits(Limits{MaxDepth: 6})
+	h := WithHeuristic(func(s *pathState) int { return 1000 * (int(s.path%7) - 3) })
+	build := func(opts ...Option) (*pathState, Result) {
+		mm := Make(&state, pathTerminal, pathUtility, pathSuccessors, true, append([]Option{limit, h}, opts...)...)
+		move := mm.Solve(pathState{})
+		return move, mm.Result()
+	}
+
+	_, plain := build()
+	_, wide := build(WithBeam(3))
+	if wide.Nodes != plain.Nodes || wide.Value != plain.Value {
+		t.Errorf("Expected a full beam to change nothing, got %+v instead of %+v", wide, plain)
+	}
+
+	move, narrow := build(WithBeam(1))
+	if narrow.Nodes != 7 || !narrow.Unknown {
+		t.Errorf("Expected a single line of 7 nodes, got %+v", narrow)
+	}
+	if move == nil || move.path != 2 {
+		t.Errorf("Expected the child with the best heuristic value, got %v", move)
+	}
+}
@@ -0,0 +1,47 @@
+package minimax
+
+// WithSuccessorsInto replaces the successors function passed to Make (which may
+// then be nil) with one that appends the successors of s to buf and returns the
+// extended slice, like the standard append. The engine hands out buffers it
+// reuses once the children are created, which saves allocating a slice per
+// interior node. Only the slice is reused: the states it points to still
+// belong to the engine.
+func WithSuccessorsInto[T comparable](successors func(s *T, buf []*T) []*T) Option {
+	return func(o *options) {
+		o.successorsInto = successors
+	}
+}
+
+// generate returns the successors of state, and a function to call once they
+// have been copied, which recycles their slice if it came from a buffer
+func (cf *config[T]) generate(state *T) ([]*T, func()) {
+	if cf.successorCache != nil {
+		return cf.cachedSuccessors(state), func() {}
+	}
+	return cf.generateFresh(state)
+}
+
+// generateFresh is generate without the successor cache
+func (cf *config[T]) generateFresh(state *T) ([]*T, func()) {
+	succ, release := cf.generateRaw(state)
+	if cf.aliasing == AliasTrust {
+		return succ, release
+	}
+	defer release()
+	return cf.own(state, succ), func() {}
+}
+
+// generateRaw is generateFresh, keeping the states successors return
+func (cf *config[T]) generateRaw(state *T) ([]*T, func()) {
+	if cf.successorsInto == nil {
+		return cf.successors(state), func() {}
+	}
+
+	buf := cf.buffers.Get().(*[]*T)
+	succ := cf.successorsInto(state, (*buf)[:0])
+	return succ, func() {
+		clear(succ)
+		*buf = succ[:0]
+		cf.buffers.Put(buf)
+	}
+}
@@ -0,0 +1,36 @@
+package minimax
+
+import "testing"
+
+// TestSuccessorsInto tests that buffer-reusing successors give the same result with fewer allocations.
+func TestSuccessorsInto(t *testing.T) {
+	into := func(s *pathState, buf []*pathState) []*pathState {
+		if pathTerminal(s) {
+			return buf
+		}
+		for i := range 3 {
+			buf = append(buf, &pathState{path: s.path*3 + uint64(i), depth: s.depth + 1})
+		}
+		return buf
+	}
+
+	state := pathState{depth: pathDepth - 7}
+	plain := Make(&state, pathTerminal, pathUtility, pathSuccessors, true)
+	reused := Make(&state, pathTerminal, pathUtility, nil, true, WithSuccessorsInto(into))
+
+	if plain.Result().Value != reused.Result().Value || *plain.Solve(state) != *reused.Solve(state) {
+		t.Errorf("Expected the same outcome, got %+v and %+v", plain.Result(), reused.Result())
+	}
+
+	if raceEnabled {
+		return
+	}
+	allocs := func(opts ...Option) float64 {
+		return testing.AllocsPerRun(5, func() {
+			Make(&state, pathTerminal, pathUtility, pathSuccessors, true, opts...)
+		})
+	}
+	if with, without := allocs(WithSuccessorsInto(into)), allocs(); with >= without {
+		t.Errorf("Expected fewer allocations with buffers, got %v vs %v", with, without)
+	}
+}
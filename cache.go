@@ -0,0 +1,112 @@
+package minimax
+
+import "math"
+
+// moveCache holds the bookkeeping of the best moves a Minimax remembers
+type moveCache[T comparable] struct {
+	hits   int        // Solve calls answered from the cache
+	misses int        // Solve calls that had to search
+	pinned map[T]bool // States whose moves ClearCache keeps
+	values map[T]int  // Values of the states searches started from, for SolveAsync
+}
+
+// remember records the value found by a search from state
+func (c *moveCache[T]) remember(state T, value int) {
+	if c != nil {
+		c.values[state] = value
+	}
+}
+
+// value returns the value found by the last search from state
+func (c *moveCache[T]) value(state T) (int, bool) {
+	if c == nil {
+		return 0, false
+	}
+	v, ok := c.values[state]
+	return v, ok
+}
+
+// count records whether a Solve call was answered from the cache
+func (c *moveCache[T]) count(hit bool) {
+	switch {
+	case c == nil:
+	case hit:
+		c.hits++
+	default:
+		c.misses++
+	}
+}
+
+// CacheLen returns the number of states whose best move is cached
+func (m Minimax[T]) CacheLen() int {
+	return len(m.moveMap)
+}
+
+// CacheStats returns how many calls to Solve and SolveAsync found their state
+// in the cache and how many had to search
+func (m Minimax[T]) CacheStats() (hits, misses int) {
+	if m.cache == nil {
+		return 0, 0
+	}
+	return m.cache.hits, m.cache.misses
+}
+
+// ClearCache forgets the best moves cached for every state but the pinned
+// ones, and resets the counters of CacheStats. Long-running services call it
+// to bound the memory the cache takes, typically between games.
+func (m Minimax[T]) ClearCache() {
+	for state := range m.moveMap {
+		if !m.cache.pinned[state] {
+			delete(m.moveMap, state)
+			delete(m.cache.values, state)
+		}
+	}
+	m.cache.hits, m.cache.misses = 0, 0
+}
+
+// Pin keeps the move cached for state, or to be cached for it, through
+// ClearCache, such as the moves of an opening book
+func (m Minimax[T]) Pin(state T) {
+	m.cache.pinned[state] = true
+}
+
+// Unpin lets ClearCache forget the move of state again
+func (m Minimax[T]) Unpin(state T) {
+	delete(m.cache.pinned, state)
+}
+
+// Merge copies the best moves cached by other into m's cache, such as those
+// of searches of different openings run in parallel or on other machines.
+// Where both caches hold a move for the same state, the move of the search
+// that looked deeper is kept: one without a depth limit beats any with one,
+// and a deeper limit a shallower one, m's moves winning ties. Pinned states
+// keep their moves. It returns the number of moves copied.
+func (m Minimax[T]) Merge(other Minimax[T]) int {
+	deeper := other.horizon() > m.horizon()
+	copied := 0
+	for state, move := range other.moveMap {
+		if _, ok := m.moveMap[state]; ok && (!deeper || m.cache != nil && m.cache.pinned[state]) {
+			continue
+		}
+		m.moveMap[state] = move
+		if v, ok := other.cache.value(state); ok {
+			m.cache.remember(state, v)
+		} else if m.cache != nil {
+			delete(m.cache.values, state)
+		}
+		copied++
+	}
+	return copied
+}
+
+// horizon returns the depth limit of the searches of m, MaxInt if unlimited
+func (m Minimax[T]) horizon() int {
+	switch {
+	case m.result.AutoDepth > 0:
+		return m.result.AutoDepth
+	case m.config.limits.MaxDepth > 0:
+		return m.config.limits.MaxDepth
+	default:
+		return math.MaxInt
+	}
+}
@@ -0,0 +1,73 @@
+package minimax
+
+import "testing"
+
+// TestCache tests the counters, clearing and pinning of the best-move cache.
+func TestCache(t *testing.T) {
+	root := nimState{stones: 10, aiTurn: true}
+	mm := Make(&root, nimTerminal, nimUtility, nimSuccessors, true)
+	if mm.CacheLen() == 0 {
+		t.Fatal("Expected the search to fill the cache")
+	}
+
+	mm.Solve(root)
+	mm.Solve(nimState{stones: 20, aiTurn: true})
+	mm.SolveAsync(root).Wait()
+	if hits, misses := mm.CacheStats(); hits != 2 || misses != 1 {
+		t.Errorf("Expected 2 hits and 1 miss, got %d and %d", hits, misses)
+	}
+
+	mm.Pin(root)
+	mm.Pin(nimState{stones: 30, aiTurn: true}) // Not cached yet
+	mm.ClearCache()
+	if hits, misses := mm.CacheStats(); mm.CacheLen() != 1 || hits != 0 || misses != 0 {
+		t.Errorf("Expected only the pinned move and no counts, got %d moves, %d hits and %d misses", mm.CacheLen(), hits, misses)
+	}
+	if _, ok := mm.Lookup(root); !ok {
+		t.Error("Expected the pinned move to be kept")
+	}
+
+	mm.Solve(nimState{stones: 30, aiTurn: true})
+	mm.Unpin(root)
+	mm.ClearCache()
+	if _, ok := mm.Lookup(nimState{stones: 30, aiTurn: true}); !ok || mm.CacheLen() != 1 {
+		t.Errorf("Expected only the move pinned before it was cached, got %d moves", mm.CacheLen())
+	}
+}
+
+// TestMerge tests that merged caches keep the moves of the deeper search, and pinned moves.
+func TestMerge(t *testing.T) {
+	root := nimState{stones: 10, aiTurn: true}
+	shallow := Make(&root, nimTerminal, nimUtility, nimSuccessors, true, WithLimits(Limits{MaxDepth: 1}))
+	deep := Make(&root, nimTerminal, nimUtility, nimSuccessors, true)
+	other := Make(&nimState{stones: 20, aiTurn: true}, nimTerminal, nimUtility, nimSuccessors, true)
+	want, _ := deep.Lookup(root)
+
+	// The shallow search's move from the root loses, the deep one's wins
+	if got, _ := shallow.Lookup(root); *got == *want {
+		t.Fatalf("Expected the shallow search to pick another move than %v", *want)
+	}
+	if n := deep.Merge(shallow); n != 0 {
+		t.Errorf("Expected nothing to be copied into the deeper cache, got %d", n)
+	}
+	pinned := Make(&root, nimTerminal, nimUtility, nimSuccessors, true, WithLimits(Limits{MaxDepth: 1}))
+	pinned.Pin(root)
+	kept, _ := pinned.Lookup(root)
+
+	if n := shallow.Merge(deep); n == 0 {
+		t.Error("Expected moves to be copied into the shallower cache")
+	}
+	if got, _ := shallow.Lookup(root); *got != *want {
+		t.Errorf("Expected the deeper move %v, got %v", *want, *got)
+	}
+	pinned.Merge(deep)
+	if got, _ := pinned.Lookup(root); got != kept {
+		t.Errorf("Expected the pinned move %v to be kept, got %v", *kept, *got)
+	}
+
+	size := deep.CacheLen()
+	deep.Merge(other)
+	if _, ok := deep.Lookup(nimState{stones: 20, aiTurn: true}); !ok || deep.CacheLen() <= size {
+		t.Error("Expected the moves of another region to be added")
+	}
+}
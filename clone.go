@@ -0,0 +1,32 @@
+package minimax
+
+// WithClone gives the engine a way to make an independent copy of a state.
+// It is needed when states refer to memory that can be shared or reused, such
+// as pointers to arrays: a comparable state only compares those pointers, so
+// without copies the cache would silently alias the caller's states.
+//
+// When set, the engine clones the initial state passed to Make, and every
+// state it keeps beyond a search: the keys and moves of the cache and the best
+// moves handed to asynchronous searches.
+func WithClone[T comparable](clone func(*T) *T) Option {
+	return func(o *options) {
+		o.clone = clone
+	}
+}
+
+// copyOf returns an independent copy of state if a clone function was given,
+// and state itself otherwise, or the shared copy of state if states are interned
+func (cf *config[T]) copyOf(state *T) *T {
+	if cf.interner != nil {
+		return cf.interner.intern(state, cf.cloneOf, cf.clone != nil)
+	}
+	return cf.cloneOf(state)
+}
+
+// cloneOf is copyOf without interning
+func (cf *config[T]) cloneOf(state *T) *T {
+	if cf.clone == nil {
+		return state
+	}
+	return cf.clone(state)
+}
@@ -0,0 +1,47 @@
+package minimax
+
+import "testing"
+
+// boardState refers to its board through a pointer, so copies share the board
+// unless cloned.
+type boardState struct {
+	board  *[3]int
+	aiTurn bool
+}
+
+func cloneBoard(s *boardState) *boardState {
+	board := *s.board
+	return &boardState{board: &board, aiTurn: s.aiTurn}
+}
+
+// TestCloneInitialState tests that the engine keeps its own copy of the initial state.
+func TestCloneInitialState(t *testing.T) {
+	terminal := func(s *boardState) bool { return s.board[0] != 0 }
+	utility := func(s *boardState) int { return s.board[0] }
+	successors := func(s *boardState) []*boardState {
+		win := cloneBoard(s)
+		win.board[0] = 1
+		win.aiTurn = !s.aiTurn
+		return []*boardState{win}
+	}
+
+	state := boardState{board: &[3]int{}, aiTurn: true}
+	var cloned int
+	mm := Make(&state, terminal, utility, successors, true,
+		WithClone(func(s *boardState) *boardState {
+			cloned++
+			return cloneBoard(s)
+		}))
+
+	if cloned == 0 {
+		t.Fatal("Expected the clone function to be used")
+	}
+
+	// Mutating the caller's board must not change the engine's states
+	state.board[1] = 7
+	for key, move := range mm.moveMap {
+		if key.board == state.board || move.board == state.board {
+			t.Error("Expected the cache not to alias the caller's board")
+		}
+	}
+}
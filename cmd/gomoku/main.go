@@ -0,0 +1,54 @@
+// Command gomoku plays five in a row against the engine in the terminal.
+// Moves are typed as cells, such as h8.
+//
+//	gomoku [-size 15] [-time 2s] [-second]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/abtsousa/minimax-go"
+	"github.com/abtsousa/minimax-go/games/gomoku"
+	"github.com/abtsousa/minimax-go/gridgame"
+	"github.com/abtsousa/minimax-go/tui"
+)
+
+func main() {
+	size := flag.Int("size", 15, "board size")
+	think := flag.Duration("time", 2*time.Second, "engine thinking time per move")
+	second := flag.Bool("second", false, "move second")
+	flag.Parse()
+
+	ai := gridgame.Second
+	if *second {
+		ai = gridgame.First
+	}
+	g := gomoku.New(*size, ai)
+	g.Width = 8
+	h := tui.Harness[gomoku.State]{
+		Game:   g.Game(),
+		Render: func(s *gomoku.State) string { return s.Board.FormatLabeled(".XO") },
+		MoveName: func(from, to *gomoku.State) string {
+			return from.Board.CellName(from.Board.Placed(to.Board))
+		},
+		Parse: func(s *gomoku.State, input string) (*gomoku.State, error) {
+			i, ok := s.Board.ParseCell(input)
+			if !ok || s.Board.At(i) != gridgame.Empty {
+				return nil, fmt.Errorf("illegal move %q", input)
+			}
+			return &gomoku.State{Board: s.Board.Set(i, s.Turn), Turn: s.Turn.Opponent()}, nil
+		},
+		Options: []minimax.Option{
+			minimax.WithLimits(minimax.Limits{MaxDepth: 4, MaxTime: *think}),
+			minimax.WithHeuristic(g.Evaluate),
+		},
+	}
+	fmt.Printf("You play %c. Type help for commands.\n", ".XO"[ai.Opponent()])
+	start := g.Start()
+	if _, err := h.Run(minimax.Position[gomoku.State]{State: start, IsMax: ai == gridgame.First}, os.Stdin, os.Stdout); err != nil {
+		fmt.Println()
+	}
+}
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+const help = `commands:
+  load <position>     load a position, e.g. load %s
+  start [depth]       start a stepped search of the loaded position
+  step [n]            run the search for n events (default 1)
+  next <kind>         run until an event of the given kind (enter, expand, cutoff, exit, done)
+  continue            run the search to the end
+  where               show the current event and node
+  expand [path...]    list the children of a node given by child indices from the root,
+                      or of the current node, whether the search reached them or not
+  cache [position]    show the best move found for a position, or for the current node,
+                      so far if the search is running
+  result              show the result of the finished search
+  help                show this help
+  quit                leave the debugger`
+
+// game is a game the debugger can load positions of
+type game[T comparable] struct {
+	minimax.Game[T] // ToMove and Format must be set
+
+	parse   func(string) (minimax.Position[T], error) // Reads a position as load takes it
+	syntax  string                                    // Example of what parse reads, for help
+	players [2]string                                 // Names of the AI and of its opponent
+}
+
+// step is an event of the search together with the state of its node
+type step[T comparable] struct {
+	ev    minimax.Event
+	state T
+}
+
+// debugger runs a search in the background, pausing it after every event
+type debugger[T comparable] struct {
+	out  io.Writer
+	game game[T]
+
+	root    minimax.Position[T]
+	loaded  bool
+	current *step[T]
+
+	events chan step[T]        // Events of the running search
+	resume chan struct{}       // Lets the paused search continue
+	cancel chan struct{}       // Closed to let an abandoned search run freely
+	search *minimax.Search[T]  // Running search, nil if none is
+	mm     *minimax.Minimax[T] // Minimax of the running or last finished search
+	done   bool                // Whether the search of mm is over
+}
+
+func newDebugger[T comparable](out io.Writer, g game[T]) *debugger[T] {
+	return &debugger[T]{out: out, game: g}
+}
+
+// run reads commands from in until it ends or quit is entered
+func (d *debugger[T]) run(in io.Reader, prompt bool) {
+	sc := bufio.NewScanner(in)
+	for {
+		if prompt {
+			fmt.Fprint(d.out, "(minimax) ")
+		}
+		if !sc.Scan() {
+			return
+		}
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "quit" || fields[0] == "q" {
+			return
+		}
+		if err := d.exec(fields[0], fields[1:]); err != nil {
+			fmt.Fprintln(d.out, "error:", err)
+		}
+	}
+}
+
+// exec runs a single command
+func (d *debugger[T]) exec(cmd string, args []string) error {
+	switch cmd {
+	case "help", "h":
+		fmt.Fprintf(d.out, help+"\n", d.game.syntax)
+	case "load":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: load <position>")
+		}
+		pos, err := d.game.parse(args[0])
+		if err != nil {
+			return err
+		}
+		d.close()
+		d.root, d.loaded, d.current, d.mm = pos, true, nil, nil
+		fmt.Fprintf(d.out, "loaded %s, %s to move\n", d.format(pos.State), d.side(pos.State))
+	case "start":
+		depth := 0
+		if len(args) > 0 {
+			n, err := strconv.Atoi(args[0])
+			if err != nil {
+				return err
+			}
+			depth = n
+		}
+		return d.start(depth)
+	case "step", "s":
+		n := 1
+		if len(args) > 0 {
+			v, err := strconv.Atoi(args[0])
+			if err != nil {
+				return err
+			}
+			n = v
+		}
+		for range n {
+			if !d.advance(true) {
+				break
+			}
+		}
+	case "next", "n":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: next <kind>")
+		}
+		if d.search == nil {
+			return fmt.Errorf("no search running")
+		}
+		for {
+			if !d.advance(false) {
+				d.result() // Over before an event of that kind
+				break
+			}
+			if d.current.ev.Kind == minimax.EventKind(args[0]) {
+				d.show()
+				break
+			}
+		}
+	case "continue", "c":
+		for d.advance(false) {
+		}
+		d.result()
+	case "where", "w":
+		if d.current == nil {
+			return fmt.Errorf("no search step to show")
+		}
+		d.show()
+	case "expand", "e":
+		return d.expand(args)
+	case "cache":
+		return d.cache(args)
+	case "result":
+		d.result()
+	default:
+		return fmt.Errorf("unknown command %q, type help", cmd)
+	}
+	return nil
+}
+
+// start begins a new search of the loaded position, paused before its first event
+func (d *debugger[T]) start(depth int) error {
+	if !d.loaded {
+		return fmt.Errorf("no position loaded")
+	}
+	d.close()
+
+	events, resume, cancel := make(chan step[T]), make(chan struct{}), make(chan struct{})
+	armed := false // Events of the search making the Minimax are not shown
+	observe := func(ev minimax.Event, s *T) {
+		if !armed {
+			return
+		}
+		select {
+		case events <- step[T]{ev, *s}:
+		case <-cancel:
+			return
+		}
+		select {
+		case <-resume:
+		case <-cancel:
+		}
+	}
+
+	opts := []minimax.Option{minimax.WithObserver(observe)}
+	if depth > 0 {
+		opts = append(opts, minimax.WithLimits(minimax.Limits{MaxDepth: depth}))
+	}
+	// The Minimax is made from the end of a game, which needs no search, so
+	// that the search of the root runs with SolveAsync, whose handle looks up
+	// the moves found so far
+	end := d.root.State
+	for !d.game.IsTerminal(&end) {
+		end = *d.game.Successors(&end)[0]
+	}
+	mm := d.game.Make(&end, d.root.IsMax, opts...)
+	armed = true
+
+	d.events, d.resume, d.cancel = events, resume, cancel
+	d.search, d.mm, d.done, d.current = mm.SolveAsync(d.root.State), &mm, false, nil
+	fmt.Fprintln(d.out, "search started")
+	return nil
+}
+
+// advance lets the search run to its next event, printing it if verbose.
+// It returns false once the search is over.
+func (d *debugger[T]) advance(verbose bool) bool {
+	if d.search == nil {
+		if verbose {
+			fmt.Fprintln(d.out, "no search running")
+		}
+		return false
+	}
+	if d.current != nil {
+		d.resume <- struct{}{}
+	}
+
+	select {
+	case st := <-d.events:
+		d.current = &st
+		if verbose {
+			d.show()
+		}
+		return true
+	case <-d.search.Done():
+		d.finish()
+		if verbose {
+			d.result()
+		}
+		return false
+	}
+}
+
+// finish records the end of the running search
+func (d *debugger[T]) finish() {
+	d.events, d.resume, d.search, d.done = nil, nil, nil, true
+	close(d.cancel)
+}
+
+// close abandons the running search, if any, letting it finish unobserved
+func (d *debugger[T]) close() {
+	if d.search != nil {
+		d.search.Stop()
+		close(d.cancel)
+		d.events, d.resume, d.search, d.mm = nil, nil, nil, nil
+	}
+}
+
+// show prints the current event and its node
+func (d *debugger[T]) show() {
+	st := d.current
+	fmt.Fprintf(d.out, "%-6s path=%v window=[%d,%d] value=%d", st.ev.Kind, st.ev.Path,
+		st.ev.Alpha, st.ev.Beta, st.ev.Value)
+	switch st.ev.Kind {
+	case minimax.EventExpand:
+		fmt.Fprintf(d.out, " children=%d", st.ev.Children)
+	case minimax.EventCutoff:
+		fmt.Fprintf(d.out, " after child %d", st.ev.Child)
+	}
+	fmt.Fprintf(d.out, "  %s (%s to move)\n", st.ev.State, d.side(st.state))
+}
+
+// expand lists the children of the node at the given path, or of the current node
+func (d *debugger[T]) expand(args []string) error {
+	if !d.loaded {
+		return fmt.Errorf("no position loaded")
+	}
+
+	s := d.root.State
+	if len(args) == 0 && d.current != nil {
+		s = d.current.state
+	}
+	for _, a := range args {
+		i, err := strconv.Atoi(a)
+		if err != nil {
+			return err
+		}
+		var succ []*T
+		if !d.game.IsTerminal(&s) {
+			succ = d.game.Successors(&s)
+		}
+		if i < 0 || i >= len(succ) {
+			return fmt.Errorf("%s has no child %d", d.format(s), i)
+		}
+		s = *succ[i]
+	}
+
+	fmt.Fprintf(d.out, "%s (%s to move)\n", d.format(s), d.side(s))
+	if d.game.IsTerminal(&s) {
+		return nil
+	}
+	for i, c := range d.game.Successors(&s) {
+		note := ""
+		if d.game.IsTerminal(c) {
+			note = fmt.Sprintf("  terminal, utility %d", d.game.Utility(c))
+		}
+		fmt.Fprintf(d.out, "  %d: %s%s\n", i, d.format(*c), note)
+	}
+	return nil
+}
+
+// cache shows the best move found for a position by the running search, or
+// cached by the last finished one
+func (d *debugger[T]) cache(args []string) error {
+	if d.mm == nil {
+		return fmt.Errorf("no search started")
+	}
+
+	var s T
+	switch {
+	case len(args) > 0:
+		pos, err := d.game.parse(args[0])
+		if err != nil {
+			return err
+		}
+		s = pos.State
+	case d.current != nil:
+		s = d.current.state
+	default:
+		s = d.root.State
+	}
+
+	lookup, found := d.mm.Lookup, "best move"
+	if d.search != nil {
+		lookup, found = d.search.Lookup, "best move so far"
+	}
+	move, ok := lookup(s)
+	if !ok || move == nil {
+		fmt.Fprintf(d.out, "%s: not searched yet\n", d.format(s))
+		return nil
+	}
+	fmt.Fprintf(d.out, "%s: %s %s\n", d.format(s), found, d.format(*move))
+	return nil
+}
+
+// result prints the outcome of the last finished search
+func (d *debugger[T]) result() {
+	if d.mm == nil || !d.done {
+		fmt.Fprintln(d.out, "no finished search")
+		return
+	}
+
+	res := d.mm.Result()
+	fmt.Fprintf(d.out, "done: value=%d nodes=%d depth=%d stopped=%v\n", res.Value, res.Nodes, res.Depth, res.Stopped)
+	if move, ok := d.mm.Lookup(d.root.State); ok && move != nil {
+		fmt.Fprintf(d.out, "best move: %s\n", d.format(*move))
+	}
+}
+
+// format writes a state of the game
+func (d *debugger[T]) format(s T) string {
+	return d.game.Format(&s)
+}
+
+// side returns the name of the player to move in s
+func (d *debugger[T]) side(s T) string {
+	if d.game.ToMove(&s) {
+		return d.game.players[0]
+	}
+	return d.game.players[1]
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestDebuggerSession tests a scripted debugging session.
+func TestDebuggerSession(t *testing.T) {
+	script := `load xx./oo./...
+start
+step 2
+where
+expand
+next cutoff
+continue
+cache
+expand 9
+quit
+`
+	var out strings.Builder
+	d := newDebugger(&out, tictactoe)
+	d.run(strings.NewReader(script), false)
+	d.close()
+
+	got := out.String()
+	for _, want := range []string{
+		"loaded xx./oo./..., x to move",
+		"enter  path=[] window=[-30000,30000]",
+		"expand path=[] window=[-30000,30000] value=0 children=5",
+		"  0: xxx/oo./...  terminal, utility 1",
+		"cutoff path=[1 0 0]",
+		"done: value=29999",
+		"xx./oo./...: best move xxx/oo./...",
+		"error: xx./oo./... has no child 9",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+// TestDebuggerLive tests cache queries while the search runs, and the result
+// printed when next reaches the end of the search, on another game.
+func TestDebuggerLive(t *testing.T) {
+	script := `load 5
+start
+cache
+next exit
+next exit
+cache 1
+next done
+next exit
+cache
+result
+`
+	var out strings.Builder
+	d := newDebugger(&out, nim)
+	d.run(strings.NewReader(script), false)
+	d.close()
+
+	got := out.String()
+	for _, want := range []string{
+		"loaded 5, ai to move",
+		"5: not searched yet",
+		"exit   path=[0 0 0 0]",
+		"1: best move so far 0",
+		"done   path=[]",
+		"done: value=29997",
+		"best move: 4",
+		"5: best move 4",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Count(got, "done: value=") != 2 {
+		t.Errorf("Expected the result after next reached the end and on request, got:\n%s", got)
+	}
+}
+
+// TestParseBoard tests board parsing and the side to move.
+func TestParseBoard(t *testing.T) {
+	tests := []struct {
+		in      string
+		xToMove bool
+		err     bool
+	}{
+		{".........", true, false},
+		{"x../.../...", false, false},
+		{"xo-/x../___", false, false},
+		{"xx./.../...", false, true},
+		{"xo", false, true},
+		{"xoz......", false, true},
+	}
+
+	for _, tt := range tests {
+		b, err := parseBoard(tt.in)
+		if (err != nil) != tt.err {
+			t.Errorf("parseBoard(%q) error = %v, want error %v", tt.in, err, tt.err)
+			continue
+		}
+		if err == nil && b.xToMove != tt.xToMove {
+			t.Errorf("parseBoard(%q) xToMove = %v, want %v", tt.in, b.xToMove, tt.xToMove)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Command minimax-debug is an interactive debugger for the search. It loads a
+// position of one of the games it knows, steps through the search one event
+// at a time, shows the alpha-beta window of every node, expands branches the
+// search skipped and queries the best moves found, while the search runs or
+// once it is over. Type help for a list of commands.
+//
+// Usage:
+//
+//	minimax-debug [-game tictactoe|nim]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// session is a debugger for one of the games
+type session interface {
+	run(in io.Reader, prompt bool)
+	close()
+}
+
+// games are the games the debugger knows, by name
+var games = map[string]func(io.Writer) session{
+	"tictactoe": func(out io.Writer) session { return newDebugger(out, tictactoe) },
+	"nim":       func(out io.Writer) session { return newDebugger(out, nim) },
+}
+
+func main() {
+	name := flag.String("game", "tictactoe", "game to debug: "+strings.Join(gameNames(), ", "))
+	flag.Parse()
+
+	open, ok := games[*name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown game %q, known games: %s\n", *name, strings.Join(gameNames(), ", "))
+		os.Exit(2)
+	}
+	d := open(os.Stdout)
+	d.run(os.Stdin, true)
+	d.close()
+}
+
+// gameNames returns the names of the known games, sorted
+func gameNames() []string {
+	var names []string
+	for name := range games {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
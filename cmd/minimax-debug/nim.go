@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+// pile is a position of Nim: players take 1 to 3 stones in turn, and whoever
+// takes the last one wins
+type pile struct {
+	stones int
+	aiTurn bool
+}
+
+// nim is Nim with a single pile
+var nim = game[pile]{
+	Game: minimax.Game[pile]{
+		IsTerminal: func(p *pile) bool { return p.stones == 0 },
+		Utility: func(p *pile) int {
+			if p.aiTurn {
+				return -1 // The opponent took the last stone
+			}
+			return 1
+		},
+		Successors: func(p *pile) []*pile {
+			var succ []*pile
+			for take := 1; take <= min(3, p.stones); take++ {
+				succ = append(succ, &pile{p.stones - take, !p.aiTurn})
+			}
+			return succ
+		},
+		ToMove: func(p *pile) bool { return p.aiTurn },
+		Format: func(p *pile) string { return strconv.Itoa(p.stones) },
+	},
+	parse:   parsePile,
+	syntax:  "7 or 7/opponent, the stones left and who moves (the AI by default)",
+	players: [2]string{"ai", "opponent"},
+}
+
+// parsePile reads a number of stones, optionally followed by a slash and the
+// player to move
+func parsePile(s string) (minimax.Position[pile], error) {
+	count, who, _ := strings.Cut(s, "/")
+	stones, err := strconv.Atoi(count)
+	if err != nil || stones < 0 {
+		return minimax.Position[pile]{}, fmt.Errorf("invalid number of stones %q", count)
+	}
+	var aiTurn bool
+	switch who {
+	case "", "ai":
+		aiTurn = true
+	case "opponent":
+	default:
+		return minimax.Position[pile]{}, fmt.Errorf("unknown player %q", who)
+	}
+	return minimax.Position[pile]{State: pile{stones, aiTurn}, IsMax: aiTurn}, nil
+}
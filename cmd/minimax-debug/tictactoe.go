@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+// tictactoe is tic-tac-toe with the AI playing x
+var tictactoe = game[board]{
+	Game: minimax.Game[board]{
+		IsTerminal: isTerminal,
+		Utility:    utility,
+		Successors: successors,
+		ToMove:     func(b *board) bool { return b.xToMove },
+		Format:     (*board).String,
+	},
+	parse: func(s string) (minimax.Position[board], error) {
+		b, err := parseBoard(s)
+		return minimax.Position[board]{State: b, IsMax: b.xToMove}, err
+	},
+	syntax:  "x.o/.x./... (x moves first)",
+	players: [2]string{"x", "o"},
+}
+
+// board is a tic-tac-toe position. The AI plays x.
+type board struct {
+	cells   [9]byte // 'x', 'o' or '.'
+	xToMove bool
+}
+
+var lines = [8][3]int{
+	{0, 1, 2}, {3, 4, 5}, {6, 7, 8},
+	{0, 3, 6}, {1, 4, 7}, {2, 5, 8},
+	{0, 4, 8}, {2, 4, 6},
+}
+
+// parseBoard reads nine cells made of x, o and dots, row by row. x moves
+// first, so the side to move follows from the number of pieces.
+func parseBoard(s string) (board, error) {
+	s = strings.ToLower(strings.ReplaceAll(s, "/", ""))
+	if len(s) != 9 {
+		return board{}, fmt.Errorf("board %q must have 9 cells", s)
+	}
+
+	var b board
+	xs, os := 0, 0
+	for i := range 9 {
+		switch c := s[i]; c {
+		case 'x':
+			xs++
+		case 'o':
+			os++
+		case '.', '-', '_':
+			s = s[:i] + "." + s[i+1:]
+		default:
+			return board{}, fmt.Errorf("invalid cell %q", c)
+		}
+		b.cells[i] = s[i]
+	}
+	if xs != os && xs != os+1 {
+		return board{}, fmt.Errorf("board has %d x and %d o", xs, os)
+	}
+	b.xToMove = xs == os
+	return b, nil
+}
+
+func (b board) winner() byte {
+	for _, l := range lines {
+		if c := b.cells[l[0]]; c != '.' && c == b.cells[l[1]] && c == b.cells[l[2]] {
+			return c
+		}
+	}
+	return 0
+}
+
+func (b board) full() bool {
+	for _, c := range b.cells {
+		if c == '.' {
+			return false
+		}
+	}
+	return true
+}
+
+func (b board) String() string {
+	return fmt.Sprintf("%s/%s/%s", b.cells[0:3], b.cells[3:6], b.cells[6:9])
+}
+
+func isTerminal(b *board) bool {
+	return b.winner() != 0 || b.full()
+}
+
+func utility(b *board) int {
+	switch b.winner() {
+	case 'x':
+		return 1
+	case 'o':
+		return -1
+	}
+	return 0
+}
+
+func successors(b *board) []*board {
+	if isTerminal(b) {
+		return nil
+	}
+
+	piece := byte('o')
+	if b.xToMove {
+		piece = 'x'
+	}
+	var succ []*board
+	for i, c := range b.cells {
+		if c == '.' {
+			next := *b
+			next.cells[i] = piece
+			next.xToMove = !b.xToMove
+			succ = append(succ, &next)
+		}
+	}
+	return succ
+}
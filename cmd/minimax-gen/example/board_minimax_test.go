@@ -0,0 +1,64 @@
+package example
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+// positions are the states searched by the tests and benchmarks
+var positions = []Board{
+	{XToMove: true},
+	{Cells: [9]byte{'X', 0, 0, 0, 'O', 0, 0, 0, 0}, XToMove: true},
+	{Cells: [9]byte{'X', 'X', 0, 'O', 'O', 0, 0, 0, 0}, XToMove: false},
+	{Cells: [9]byte{'X', 'O', 'X', 'X', 'O', 'O', 'O', 'X', 'X'}, XToMove: false},
+}
+
+// TestSameAsMake tests that the generated search finds the same values, moves
+// and node counts as Make, whatever the limits.
+func TestSameAsMake(t *testing.T) {
+	for _, limits := range []minimax.Limits{{}, {MaxDepth: 2}, {MaxDepth: 4}, {MaxNodes: 500}} {
+		for _, b := range positions {
+			for _, isMax := range []bool{true, false} {
+				want := minimax.Make(&b, isTerminal, utility, successors, isMax,
+					minimax.WithLimits(limits), minimax.WithHeuristic(eval))
+				got := MakeBoardMinimax(&b, isMax, limits)
+
+				wr, gr := want.Result(), got.Result()
+				wr.Elapsed, gr.Elapsed = 0, 0
+				if !reflect.DeepEqual(gr, wr) {
+					t.Errorf("%v from %v: expected result %+v, got %+v", limits, b, wr, gr)
+				}
+				wm, gm := want.Solve(b), got.Solve(b)
+				if (wm == nil) != (gm == nil) || wm != nil && *wm != *gm {
+					t.Errorf("%v from %v: expected move %v, got %v", limits, b, wm, gm)
+				}
+			}
+		}
+	}
+}
+
+// TestTimeLimit tests that the time limit stops the generated search.
+func TestTimeLimit(t *testing.T) {
+	mm := MakeBoardMinimax(&Board{XToMove: true}, true, minimax.Limits{MaxTime: time.Nanosecond})
+	if got := mm.Result().Stopped; got != minimax.StopTime {
+		t.Errorf("Expected the search to stop on time, got %v", got)
+	}
+	if _, ok := mm.Lookup(Board{XToMove: true}); !ok {
+		t.Error("Expected the best move so far to be cached")
+	}
+}
+
+func BenchmarkMake(b *testing.B) {
+	for range b.N {
+		minimax.Make(&positions[0], isTerminal, utility, successors, true)
+	}
+}
+
+func BenchmarkGenerated(b *testing.B) {
+	for range b.N {
+		MakeBoardMinimax(&positions[0], true, minimax.Limits{})
+	}
+}
@@ -0,0 +1,86 @@
+// Package example is a tic-tac-toe game with a search generated by
+// minimax-gen, to test the generator and compare its speed with Make.
+package example
+
+//go:generate go run .. -type Board -terminal isTerminal -utility utility -successors successors -heuristic eval
+
+// Board is a tic-tac-toe position. The AI plays X.
+type Board struct {
+	Cells   [9]byte // 'X', 'O' or 0 for empty
+	XToMove bool
+}
+
+// lines are the rows, columns and diagonals of the board
+var lines = [8][3]int{
+	{0, 1, 2}, {3, 4, 5}, {6, 7, 8},
+	{0, 3, 6}, {1, 4, 7}, {2, 5, 8},
+	{0, 4, 8}, {2, 4, 6},
+}
+
+// winner returns the player with three in a row, or 0
+func (b *Board) winner() byte {
+	for _, l := range lines {
+		if c := b.Cells[l[0]]; c != 0 && c == b.Cells[l[1]] && c == b.Cells[l[2]] {
+			return c
+		}
+	}
+	return 0
+}
+
+func isTerminal(b *Board) bool {
+	if b.winner() != 0 {
+		return true
+	}
+	for _, c := range b.Cells {
+		if c == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func utility(b *Board) int {
+	switch b.winner() {
+	case 'X':
+		return 1
+	case 'O':
+		return -1
+	default:
+		return 0
+	}
+}
+
+func successors(b *Board) []*Board {
+	player := byte('O')
+	if b.XToMove {
+		player = 'X'
+	}
+	var succ []*Board
+	for i, c := range b.Cells {
+		if c == 0 {
+			next := &Board{Cells: b.Cells, XToMove: !b.XToMove}
+			next.Cells[i] = player
+			succ = append(succ, next)
+		}
+	}
+	return succ
+}
+
+// eval counts the lines still open to X minus those still open to O
+func eval(b *Board) int {
+	v := 0
+	for _, l := range lines {
+		var x, o bool
+		for _, i := range l {
+			x = x || b.Cells[i] == 'X'
+			o = o || b.Cells[i] == 'O'
+		}
+		switch {
+		case !o:
+			v++
+		case !x:
+			v--
+		}
+	}
+	return v
+}
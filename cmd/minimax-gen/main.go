@@ -0,0 +1,409 @@
+// Command minimax-gen generates a minimax search specialized for one game.
+// Make calls the game's functions through function values at every node,
+// which the compiler cannot inline; the generated search calls them
+// directly, for users who need the most speed from a single thread. Run it
+// from the package defining the game, usually through go:generate:
+//
+//	//go:generate go run github.com/abtsousa/minimax-go/cmd/minimax-gen -type Board -terminal isTerminal -utility utility -successors successors -heuristic eval
+//
+// This writes board_minimax.go, with a BoardMinimax type whose Solve, Lookup
+// and Result methods work as those of minimax.Minimax[Board], built by
+// MakeBoardMinimax(&state, isMax, limits). It finds the same moves and
+// values as minimax.Make with the same functions, WithHeuristic if
+// -heuristic is given, and WithLimits; the other options are not supported.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// spec describes the search to generate
+type spec struct {
+	Package    string // Package of the game
+	Type       string // State type
+	Name       string // Prefix of the generated identifiers; Type if empty
+	Terminal   string // func(*Type) bool
+	Utility    string // func(*Type) int
+	Successors string // func(*Type) []*Type
+	Heuristic  string // func(*Type) int, may be empty
+	Args       string // Command line, recorded in the header
+}
+
+func main() {
+	var sp spec
+	flag.StringVar(&sp.Type, "type", "", "state `type` of the game (required)")
+	flag.StringVar(&sp.Name, "name", "", "`prefix` of the generated identifiers (default the type name)")
+	flag.StringVar(&sp.Terminal, "terminal", "", "`function` reporting whether a state is terminal (required)")
+	flag.StringVar(&sp.Utility, "utility", "", "`function` returning the utility of a terminal state (required)")
+	flag.StringVar(&sp.Successors, "successors", "", "`function` returning the successors of a state (required)")
+	flag.StringVar(&sp.Heuristic, "heuristic", "", "`function` scoring states at the depth limit")
+	dir := flag.String("dir", ".", "`directory` of the game's package")
+	out := flag.String("o", "", "output `file` (default <type>_minimax.go in the package directory)")
+	flag.Parse()
+	sp.Args = strings.Join(os.Args[1:], " ")
+
+	if err := run(sp, *dir, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "minimax-gen:", err)
+		os.Exit(1)
+	}
+}
+
+// run checks sp against the package in dir and writes the search to out
+func run(sp spec, dir, out string) error {
+	if sp.Type == "" || sp.Terminal == "" || sp.Utility == "" || sp.Successors == "" {
+		return errors.New("-type, -terminal, -utility and -successors are required")
+	}
+	if out == "" {
+		out = filepath.Join(dir, strings.ToLower(sp.Type)+"_minimax.go")
+	}
+
+	pkg, decls, err := scan(dir, filepath.Base(out))
+	if err != nil {
+		return err
+	}
+	sp.Package = pkg
+	for _, name := range []string{sp.Type, sp.Terminal, sp.Utility, sp.Successors, sp.Heuristic} {
+		if name != "" && !decls[name] {
+			return fmt.Errorf("%s is not declared in package %s", name, pkg)
+		}
+	}
+
+	src, err := generate(sp)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(out, src, 0o644)
+}
+
+// scan returns the name of the package in dir and its top-level
+// declarations, skipping tests and the file skip, which is regenerated
+func scan(dir, skip string) (string, map[string]bool, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", nil, err
+	}
+	pkg, decls := "", make(map[string]bool)
+	fset := token.NewFileSet()
+	for _, path := range files {
+		if base := filepath.Base(path); base == skip || strings.HasSuffix(base, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return "", nil, err
+		}
+		pkg = f.Name.Name
+		for _, d := range f.Decls {
+			switch d := d.(type) {
+			case *ast.FuncDecl:
+				if d.Recv == nil {
+					decls[d.Name.Name] = true
+				}
+			case *ast.GenDecl:
+				for _, s := range d.Specs {
+					switch s := s.(type) {
+					case *ast.TypeSpec:
+						decls[s.Name.Name] = true
+					case *ast.ValueSpec:
+						for _, n := range s.Names {
+							decls[n.Name] = true
+						}
+					}
+				}
+			}
+		}
+	}
+	if pkg == "" {
+		return "", nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	return pkg, decls, nil
+}
+
+// generate returns the formatted source of the search described by sp
+func generate(sp spec) ([]byte, error) {
+	if sp.Name == "" {
+		sp.Name = sp.Type
+	}
+	data := struct {
+		spec
+		Minimax, Make, Prefix string
+	}{
+		spec:    sp,
+		Minimax: sp.Name + "Minimax",
+		Make:    "make" + upperFirst(sp.Name) + "Minimax",
+		Prefix:  lowerFirst(sp.Name),
+	}
+	if ast.IsExported(sp.Name) {
+		data.Make = "Make" + sp.Name + "Minimax"
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// upperFirst returns s with its first letter in upper case
+func upperFirst(s string) string {
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// lowerFirst returns s with its first letter in lower case
+func lowerFirst(s string) string {
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+var tmpl = template.Must(template.New("search").Parse(`// Code generated by minimax-gen {{.Args}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"time"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+// {{.Minimax}} is a minimax search specialized for {{.Type}}, which calls
+// {{.Terminal}}, {{.Utility}} and {{.Successors}} directly. It finds the same
+// moves and values as minimax.Make with these functions{{if .Heuristic}},
+// WithHeuristic({{.Heuristic}}){{end}} and WithLimits.
+type {{.Minimax}} struct {
+	moveMap map[{{.Type}}]*{{.Type}} // Cache
+	isMax   bool
+	limits  minimax.Limits
+	result  *minimax.Result // Outcome of the most recent search
+}
+
+// {{.Make}} searches from state, with isMax true if it is the AI's turn
+func {{.Make}}(state *{{.Type}}, isMax bool, limits minimax.Limits) {{.Minimax}} {
+	m := {{.Minimax}}{
+		moveMap: make(map[{{.Type}}]*{{.Type}}),
+		isMax:   isMax,
+		limits:  limits,
+		result:  new(minimax.Result),
+	}
+	m.search(state)
+	return m
+}
+
+// Solve returns the best possible move for the given state.
+// It returns nil for terminal states, and also when a node or time limit
+// stopped the search before any move from the state was evaluated.
+func (m {{.Minimax}}) Solve(state {{.Type}}) *{{.Type}} {
+	if {{.Terminal}}(&state) {
+		return nil
+	}
+	if move := m.moveMap[state]; move != nil {
+		return move
+	}
+	m.search(&state)
+	return m.moveMap[state]
+}
+
+// Lookup returns the best move cached for state without searching
+func (m {{.Minimax}}) Lookup(state {{.Type}}) (*{{.Type}}, bool) {
+	move, ok := m.moveMap[state]
+	return move, ok
+}
+
+// Result reports the outcome of the most recent search, including which limit (if any) ended it
+func (m {{.Minimax}}) Result() minimax.Result {
+	return *m.result
+}
+
+// search runs a search from state, adding the best moves found to the cache
+func (m {{.Minimax}}) search(state *{{.Type}}) {
+	s := &{{.Prefix}}Search{
+		limits: m.limits,
+		start:  time.Now(),
+		moves:  m.moveMap,
+		table:  make(map[{{.Prefix}}Key]*{{.Prefix}}Node),
+	}
+	if m.limits.MaxTime > 0 {
+		s.deadline = s.start.Add(m.limits.MaxTime)
+	}
+	val, unknown := s.minimax(state, 0, m.isMax, -minimax.WinScore, minimax.WinScore)
+
+	stopped := s.stopped
+	if stopped == minimax.StopNone && s.truncated {
+		stopped = minimax.StopDepth
+	}
+	if stopped != minimax.StopNone && stopped != minimax.StopDepth {
+		// A search cut short is never exact, and without a finished move
+		// the root holds no value at all
+		unknown = true
+		if _, ok := s.moves[*state]; !ok {
+			val = 0
+		}
+	}
+	*m.result = minimax.Result{
+		Value:   val,
+		Nodes:   s.nodes,
+		Depth:   s.maxDepth,
+		Elapsed: time.Since(s.start),
+		Stopped: stopped,
+		Unknown: unknown,
+	}
+}
+
+// {{.Prefix}}Key identifies a node in the search graph
+type {{.Prefix}}Key struct {
+	state {{.Type}}
+	depth int
+}
+
+// {{.Prefix}}Node is a node in the search graph, shared between transpositions
+type {{.Prefix}}Node struct {
+	val      int
+	unknown  bool // Whether val depends on branches cut off by a limit
+	searched bool // Whether val holds the result of a completed search
+	lo, hi   int  // Window val was searched with
+	expanded bool
+	children []*{{.Type}}
+}
+
+// reusable reports whether the value from the last search of n can stand for
+// a search within the window [alpha, beta]
+func (n *{{.Prefix}}Node) reusable(alpha, beta int) bool {
+	switch {
+	case !n.searched:
+		return false
+	case n.val <= n.lo: // Upper bound
+		return n.val <= alpha
+	case n.val >= n.hi: // Lower bound
+		return n.val >= beta
+	default: // Exact
+		return true
+	}
+}
+
+// {{.Prefix}}Search holds the state of a single search
+type {{.Prefix}}Search struct {
+	limits          minimax.Limits
+	start, deadline time.Time
+	nodes, maxDepth int
+	stopped         minimax.StopReason
+	truncated       bool // Whether the depth limit cut off any branch
+	moves           map[{{.Type}}]*{{.Type}}
+	table           map[{{.Prefix}}Key]*{{.Prefix}}Node
+}
+
+// minimax searches state, reached at depth, within the window [alpha, beta]
+// and returns its value and whether it depends on a limit
+func (s *{{.Prefix}}Search) minimax(state *{{.Type}}, depth int, isMax bool, alpha, beta int) (int, bool) {
+	key := {{.Prefix}}Key{*state, depth}
+	n := s.table[key]
+	if n == nil {
+		n = &{{.Prefix}}Node{}
+		s.table[key] = n
+	}
+	if n.reusable(alpha, beta) || !s.visit(depth) {
+		return n.val, n.unknown
+	}
+	n.val, n.unknown = s.evaluate(n, state, depth, isMax, alpha, beta)
+	n.searched = s.stopped == minimax.StopNone
+	n.lo, n.hi = alpha, beta
+	return n.val, n.unknown
+}
+
+// visit counts a node, returning false if a hard limit stops the search before it
+func (s *{{.Prefix}}Search) visit(depth int) bool {
+	if s.stopped != minimax.StopNone {
+		return false
+	}
+	s.nodes++
+	if s.limits.MaxNodes > 0 && s.nodes > s.limits.MaxNodes {
+		s.nodes--
+		s.stopped = minimax.StopNodes
+		return false
+	}
+	if s.nodes%minimax.CheckInterval == 0 && !s.deadline.IsZero() && time.Now().After(s.deadline) {
+		s.stopped = minimax.StopTime
+		return false
+	}
+	s.maxDepth = max(s.maxDepth, depth)
+	return true
+}
+
+// evaluate computes the value of n
+func (s *{{.Prefix}}Search) evaluate(n *{{.Prefix}}Node, state *{{.Type}}, depth int, isMax bool, alpha, beta int) (int, bool) {
+	if {{.Terminal}}(state) {
+		switch u := {{.Utility}}(state); {
+		case u > 0:
+			return minimax.WinScore - depth, false
+		case u < 0:
+			return depth - minimax.WinScore, false
+		default:
+			return 0, false
+		}
+	}
+	if s.limits.MaxDepth > 0 && depth >= s.limits.MaxDepth {
+		s.truncated = true
+{{- if .Heuristic}}
+		return max(-minimax.MaxHeuristic, min(minimax.MaxHeuristic, {{.Heuristic}}(state))), true
+{{- else}}
+		return 0, true
+{{- end}}
+	}
+
+	if !n.expanded {
+		n.children = {{.Successors}}(state)
+		n.expanded = true
+	}
+	if len(n.children) == 0 {
+		return {{.Utility}}(state), false
+	}
+
+	val := minimax.WinScore
+	if isMax {
+		val = -minimax.WinScore
+	}
+	var best *{{.Type}}
+	var bestUnknown, anyUnknown bool
+	for _, child := range n.children {
+		cv, cu := s.minimax(child, depth+1, !isMax, alpha, beta)
+		if s.stopped != minimax.StopNone {
+			break // Limit reached, child value is incomplete
+		}
+		anyUnknown = anyUnknown || cu
+		if isMax && cv > val || !isMax && cv < val {
+			val, best, bestUnknown = cv, child, cu
+		}
+		if isMax {
+			alpha = max(alpha, val)
+		} else {
+			beta = min(beta, val)
+		}
+		if beta <= alpha {
+			break
+		}
+	}
+
+	// Keep partial results only at the root, where they are the best move so far
+	if best != nil && (s.stopped == minimax.StopNone || depth == 0) {
+		s.moves[*state] = best
+	}
+	// A proven win for the player to move is certain whatever the other children hide
+	if isMax {
+		return val, bestUnknown || anyUnknown && val <= minimax.MaxHeuristic
+	}
+	return val, bestUnknown || anyUnknown && val >= -minimax.MaxHeuristic
+}
+`))
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGenerated tests that the checked-in example is what the generator
+// writes now, so that changes to the template are tested by the example.
+func TestGenerated(t *testing.T) {
+	want, err := os.ReadFile(filepath.Join("example", "board_minimax.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(t.TempDir(), "board_minimax.go")
+	sp := spec{
+		Type: "Board", Terminal: "isTerminal", Utility: "utility", Successors: "successors", Heuristic: "eval",
+		Args: "-type Board -terminal isTerminal -utility utility -successors successors -heuristic eval",
+	}
+	if err := run(sp, "example", out); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(out); !bytes.Equal(got, want) {
+		t.Error("Expected example/board_minimax.go to be up to date, run go generate ./...")
+	}
+}
+
+// TestNames tests the names of the generated identifiers.
+func TestNames(t *testing.T) {
+	for _, tc := range []struct {
+		typ, name string
+		want      []string
+	}{
+		{"Board", "", []string{"type BoardMinimax struct", "func MakeBoardMinimax(", "type boardSearch struct"}},
+		{"board", "", []string{"type boardMinimax struct", "func makeBoardMinimax(", "type boardSearch struct"}},
+		{"Board", "Fast", []string{"type FastMinimax struct", "func MakeFastMinimax(state *Board", "type fastNode struct"}},
+	} {
+		src, err := generate(spec{Package: "p", Type: tc.typ, Name: tc.name, Terminal: "t", Utility: "u", Successors: "s"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, w := range tc.want {
+			if !strings.Contains(string(src), w) {
+				t.Errorf("Expected the code for %s named %q to contain %q", tc.typ, tc.name, w)
+			}
+		}
+	}
+}
+
+// TestErrors tests that missing flags and undeclared names are reported.
+func TestErrors(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "x.go")
+	for _, tc := range []struct {
+		sp   spec
+		want string
+	}{
+		{spec{Type: "Board"}, "are required"},
+		{spec{Type: "Board", Terminal: "isTerminal", Utility: "utility", Successors: "moves"}, "moves is not declared in package example"},
+		{spec{Type: "Bord", Terminal: "isTerminal", Utility: "utility", Successors: "successors"}, "Bord is not declared"},
+	} {
+		if err := run(tc.sp, "example", out); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("Expected an error containing %q, got %v", tc.want, err)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Command othello plays Othello against the engine in the terminal. Moves are
+// typed as cells, such as d3, or pass when there is none.
+//
+//	othello [-size 8] [-time 2s] [-white]
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/abtsousa/minimax-go"
+	"github.com/abtsousa/minimax-go/games/othello"
+	"github.com/abtsousa/minimax-go/gridgame"
+	"github.com/abtsousa/minimax-go/tui"
+)
+
+func main() {
+	size := flag.Int("size", 8, "board size, even")
+	think := flag.Duration("time", 2*time.Second, "engine thinking time per move")
+	white := flag.Bool("white", false, "play White, moving second")
+	flag.Parse()
+
+	human, ai := othello.Black, othello.White
+	if *white {
+		human, ai = ai, human
+	}
+	h := tui.Harness[othello.State]{
+		Game:     othello.Game(ai),
+		Render:   func(s *othello.State) string { return s.Board.FormatLabeled(".XO") },
+		MoveName: moveName,
+		Parse:    parse,
+		Options: []minimax.Option{
+			minimax.WithLimits(minimax.Limits{MaxDepth: 6, MaxTime: *think}),
+			minimax.WithHeuristic(othello.Evaluate(ai)),
+		},
+	}
+	fmt.Printf("You play %s (%c). Type help for commands.\n", name(human), ".XO"[human])
+	start := othello.Start(*size)
+	if _, err := h.Run(minimax.Position[othello.State]{State: start, IsMax: ai == othello.Black}, os.Stdin, os.Stdout); err != nil {
+		fmt.Println()
+	}
+}
+
+// name returns the name of a player
+func name(p gridgame.Piece) string {
+	if p == othello.Black {
+		return "Black"
+	}
+	return "White"
+}
+
+// moveName names the cell a move placed a disc on
+func moveName(from, to *othello.State) string {
+	if i := from.Board.Placed(to.Board); i >= 0 {
+		return from.Board.CellName(i)
+	}
+	return "pass"
+}
+
+// parse plays the move typed by the human
+func parse(s *othello.State, input string) (*othello.State, error) {
+	moves := othello.Moves(s.Board, s.Turn)
+	if input == "pass" {
+		if len(moves) > 0 {
+			return nil, errors.New("you can only pass without moves")
+		}
+		return &othello.State{Board: s.Board, Turn: s.Turn.Opponent()}, nil
+	}
+	i, ok := s.Board.ParseCell(input)
+	if !ok || !slices.Contains(moves, i) {
+		return nil, fmt.Errorf("illegal move %q, type moves for a list", input)
+	}
+	next := othello.Play(*s, i)
+	return &next, nil
+}
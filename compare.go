@@ -0,0 +1,100 @@
+package minimax
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// Outcome is what a single engine configuration decided for a position
+type Outcome[T comparable] struct {
+	Move    *T            // Chosen move, nil if none
+	Value   int           // Value of the position for the AI
+	Nodes   int           // Nodes visited
+	Elapsed time.Duration // Time spent searching
+}
+
+// Comparison holds the outcomes of two configurations on the same position
+type Comparison[T comparable] struct {
+	Position Position[T]
+	A, B     Outcome[T]
+}
+
+// SameMove reports whether both configurations chose the same move
+func (c Comparison[T]) SameMove() bool {
+	if c.A.Move == nil || c.B.Move == nil {
+		return c.A.Move == c.B.Move
+	}
+	return *c.A.Move == *c.B.Move
+}
+
+// Agree reports whether both configurations chose the same move with the same value
+func (c Comparison[T]) Agree() bool {
+	return c.SameMove() && c.A.Value == c.B.Value
+}
+
+// Compare searches every position with configurations a and b and returns
+// their outcomes side by side, in the order of positions
+func Compare[T comparable](g Game[T], positions []Position[T], a, b []Option) []Comparison[T] {
+	comparisons := make([]Comparison[T], 0, len(positions))
+	for _, pos := range positions {
+		comparisons = append(comparisons, Comparison[T]{
+			Position: pos,
+			A:        outcome(g, pos, a),
+			B:        outcome(g, pos, b),
+		})
+	}
+	return comparisons
+}
+
+// outcome searches a single position with the given options
+func outcome[T comparable](g Game[T], pos Position[T], opts []Option) Outcome[T] {
+	state := pos.State
+	mm := g.Make(&state, pos.IsMax, opts...)
+	res := mm.Result()
+	return Outcome[T]{
+		Move:    mm.Solve(state),
+		Value:   res.Value,
+		Nodes:   res.Nodes,
+		Elapsed: res.Elapsed,
+	}
+}
+
+// WriteComparisons writes a table of comparisons to w, marking the positions
+// where the configurations disagree, followed by a summary line
+func WriteComparisons[T comparable](w io.Writer, comparisons []Comparison[T]) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tmove A\tmove B\tvalue A\tvalue B\tnodes A\tnodes B\ttime A\ttime B\t")
+
+	var disagree, nodesA, nodesB int
+	var timeA, timeB time.Duration
+	for i, c := range comparisons {
+		mark := ""
+		if !c.Agree() {
+			mark = "<< differs"
+			disagree++
+		}
+		nodesA, nodesB = nodesA+c.A.Nodes, nodesB+c.B.Nodes
+		timeA, timeB = timeA+c.A.Elapsed, timeB+c.B.Elapsed
+
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%d\t%d\t%d\t%d\t%v\t%v\t%s\n", i,
+			formatMove(c.A.Move), formatMove(c.B.Move), c.A.Value, c.B.Value,
+			c.A.Nodes, c.B.Nodes, c.A.Elapsed, c.B.Elapsed, mark)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "%d positions, %d disagreements, nodes %d vs %d, time %v vs %v\n",
+		len(comparisons), disagree, nodesA, nodesB, timeA, timeB)
+	return err
+}
+
+// formatMove renders a move for reports
+func formatMove[T comparable](move *T) string {
+	if move == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%v", *move)
+}
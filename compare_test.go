@@ -0,0 +1,34 @@
+package minimax
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCompare tests that Compare spots positions where a depth limit changes the chosen move.
+func TestCompare(t *testing.T) {
+	game := Game[nimState]{IsTerminal: nimTerminal, Utility: nimUtility, Successors: nimSuccessors}
+	positions := []Position[nimState]{
+		{State: nimState{stones: 1, aiTurn: true}, IsMax: true},
+		{State: nimState{stones: 7, aiTurn: true}, IsMax: true},
+	}
+
+	comparisons := Compare(game, positions, nil, []Option{WithLimits(Limits{MaxDepth: 1})})
+	if len(comparisons) != 2 {
+		t.Fatalf("Expected 2 comparisons, got %d", len(comparisons))
+	}
+	if !comparisons[0].Agree() {
+		t.Errorf("Expected agreement on a forced win, got %+v", comparisons[0])
+	}
+	if comparisons[1].SameMove() {
+		t.Errorf("Expected a shallow search to miss the winning move, got %+v", comparisons[1])
+	}
+
+	var sb strings.Builder
+	if err := WriteComparisons(&sb, comparisons); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(sb.String(), "1 disagreements") {
+		t.Errorf("Expected the report to count one disagreement, got:\n%s", sb.String())
+	}
+}
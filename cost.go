@@ -0,0 +1,49 @@
+package minimax
+
+// Combiner folds the cost accumulated on the way to a state into the value
+// of the state for the AI, both on the scale of Result.Value
+type Combiner func(value, cost int) int
+
+// SubtractCost is the default Combiner: each unit of cost is worth a unit of value
+func SubtractCost(value, cost int) int {
+	return value - cost
+}
+
+// WithCosts supports games whose moves have a cost, such as resources spent
+// or time consumed, for optimization puzzles as much as for games won or lost.
+// cost returns the cost of the move from one state to another for the AI,
+// negative if the move benefits the AI, such as a cost borne by the opponent.
+// The costs of the moves leading from the searched state to a leaf of the
+// search are added up and folded into the leaf's value with combine, or
+// SubtractCost if nil, before values are backed up, so the search prefers the
+// cheapest way to the best outcome.
+//
+// The value of a state then depends on the cost of the path to it, so states
+// are only shared between paths of equal cost, and WithTable, which stores
+// values by state, cannot be used. Costs should stay small next to the
+// values of proven wins and losses, which Decided counts as plies otherwise.
+func WithCosts[T comparable](cost func(from, to *T) int, combine Combiner) Option {
+	if combine == nil {
+		combine = SubtractCost
+	}
+	return func(o *options) {
+		o.cost = cost
+		o.combine = combine
+	}
+}
+
+// moveCost returns the cost of the move from one state to another, 0 without WithCosts
+func (cf *config[T]) moveCost(from, to *T) int {
+	if cf.cost == nil {
+		return 0
+	}
+	return cf.cost(from, to)
+}
+
+// charge folds the cost accumulated on the way to n into its value v
+func (s *search[T]) charge(n *node[T], v int) int {
+	if s.cost == nil {
+		return v
+	}
+	return max(-score, min(score, s.combine(v, n.cost)))
+}
@@ -0,0 +1,104 @@
+package minimax
+
+import "testing"
+
+// routeState is a puzzle with a short expensive route and a long cheap one
+// to the goal: start -> a, or start -> b1 -> b2 -> goal
+type routeState struct {
+	pos    string
+	aiTurn bool
+}
+
+var routes = map[string][]string{"start": {"a", "b1"}, "b1": {"b2"}, "b2": {"goal"}}
+
+// routeCosts are the costs of reaching each position
+var routeCosts = map[string]int{"a": 10, "b1": 1, "b2": 1, "goal": 1}
+
+func routeTerminal(s *routeState) bool {
+	return len(routes[s.pos]) == 0
+}
+
+func routeUtility(*routeState) int {
+	return 1
+}
+
+func routeSuccessors(s *routeState) []*routeState {
+	var succ []*routeState
+	for _, pos := range routes[s.pos] {
+		succ = append(succ, &routeState{pos: pos, aiTurn: !s.aiTurn})
+	}
+	return succ
+}
+
+func routeCost(_, to *routeState) int {
+	return routeCosts[to.pos]
+}
+
+// TestCosts tests that costs steer the search toward the cheapest route to the goal.
+func TestCosts(t *testing.T) {
+	state := routeState{pos: "start", aiTurn: true}
+	plain := Make(&state, routeTerminal, routeUtility, routeSuccessors, true)
+	if move := plain.Solve(state); move.pos != "a" || plain.Result().Value != score-1 {
+		t.Errorf("Expected the shortest route without costs, got %v worth %d", move, plain.Result().Value)
+	}
+
+	costly := Make(&state, routeTerminal, routeUtility, routeSuccessors, true, WithCosts(routeCost, nil))
+	if move := costly.Solve(state); move.pos != "b1" || costly.Result().Value != score-3-3 {
+		t.Errorf("Expected the cheapest route, got %v worth %d", move, costly.Result().Value)
+	}
+
+	// A combiner that ignores costs keeps the short route
+	ignore := func(v, _ int) int { return v }
+	ignored := Make(&state, routeTerminal, routeUtility, routeSuccessors, true, WithCosts(routeCost, ignore))
+	if move := ignored.Solve(state); move.pos != "a" || ignored.Result().Value != score-1 {
+		t.Errorf("Expected the short route, got %v worth %d", move, ignored.Result().Value)
+	}
+}
+
+// TestCostsTranspositions tests that states reached at different costs are told apart.
+func TestCostsTranspositions(t *testing.T) {
+	// Taking more stones costs the AI more, so the same state is reached
+	// at the same depth with different costs
+	takes := func(from, to *nimState) int {
+		if from.aiTurn {
+			return from.stones - to.stones
+		}
+		return 0
+	}
+	var brute func(s *nimState, depth, cost int) int
+	brute = func(s *nimState, depth, cost int) int {
+		if nimTerminal(s) {
+			return score*nimUtility(s) - depth*nimUtility(s) - cost
+		}
+		best := -score
+		if !s.aiTurn {
+			best = score
+		}
+		for _, next := range nimSuccessors(s) {
+			v := brute(next, depth+1, cost+takes(s, next))
+			if s.aiTurn && v > best || !s.aiTurn && v < best {
+				best = v
+			}
+		}
+		return best
+	}
+
+	for stones := 1; stones <= 12; stones++ {
+		state := nimState{stones: stones, aiTurn: true}
+		mm := Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithCosts(takes, nil))
+		if want := brute(&state, 0, 0); mm.Result().Value != want {
+			t.Errorf("%d stones: expected %d, got %d", stones, want, mm.Result().Value)
+		}
+	}
+}
+
+// TestCostsTable tests that costs are refused along with a transposition table.
+func TestCostsTable(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic")
+		}
+	}()
+	state := nimState{stones: 3, aiTurn: true}
+	Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithCosts(func(_, _ *nimState) int { return 1 }, nil), WithTable(NewTable(1<<10), nimHash))
+}
@@ -0,0 +1,86 @@
+package minimax
+
+import "sync"
+
+// Countermoves implements the countermove heuristic: for every move, it
+// remembers the reply that most recently caused a cutoff after it, and
+// searches that reply early whenever the move is played again. Replies are
+// often good whatever position they are played in, such as taking back a
+// piece that was just taken.
+//
+// Moves are identified by a function of the states before and after them,
+// which should give the same identity to the same move played in different
+// positions (for instance the source and target cells). A Countermoves can be
+// kept between searches, and shared by parallel ones.
+type Countermoves[T, M comparable] struct {
+	move func(from, to *T) M
+
+	mu      sync.Mutex
+	replies map[M]M
+}
+
+// counters is how the search uses a Countermoves, whatever its move type
+type counters[T comparable] interface {
+	order(n *node[T])
+	learn(n, child *node[T])
+}
+
+// NewCountermoves returns an empty table identifying moves with move
+func NewCountermoves[T, M comparable](move func(from, to *T) M) *Countermoves[T, M] {
+	return &Countermoves[T, M]{move: move, replies: make(map[M]M)}
+}
+
+// WithCountermoves orders children by the countermove heuristic, updating c
+// while searching. The remembered reply to the move leading to a node is
+// searched first, unless a best move from a previous iteration or IID takes
+// precedence, in which case it comes second.
+func WithCountermoves[T, M comparable](c *Countermoves[T, M]) Option {
+	return func(o *options) {
+		o.countermoves = c
+	}
+}
+
+// Reply returns the countermove remembered for move
+func (c *Countermoves[T, M]) Reply(move M) (M, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	reply, ok := c.replies[move]
+	return reply, ok
+}
+
+// Len returns the number of moves with a remembered reply
+func (c *Countermoves[T, M]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.replies)
+}
+
+// order moves the countermove to the move leading to n to the front of its children
+func (c *Countermoves[T, M]) order(n *node[T]) {
+	if n.from == nil || len(n.children) < 2 {
+		return
+	}
+	reply, ok := c.Reply(c.move(n.from.elem, n.elem))
+	if !ok {
+		return
+	}
+
+	for i, child := range n.children {
+		if c.move(n.elem, child.elem) == reply {
+			copy(n.children[1:i+1], n.children[:i])
+			n.children[0] = child
+			return
+		}
+	}
+}
+
+// learn records that child caused a cutoff at n
+func (c *Countermoves[T, M]) learn(n, child *node[T]) {
+	if n.from == nil {
+		return
+	}
+	move, reply := c.move(n.from.elem, n.elem), c.move(n.elem, child.elem)
+	c.mu.Lock()
+	c.replies[move] = reply
+	c.mu.Unlock()
+}
@@ -0,0 +1,38 @@
+package minimax
+
+import "testing"
+
+// pathMove identifies a move of the path game by the branch it takes
+func pathMove(from, to *pathState) int {
+	return int(to.path % 3)
+}
+
+// TestCountermoves tests that replies causing cutoffs are learned and searched first.
+func TestCountermoves(t *testing.T) {
+	state := pathState{depth: pathDepth - 8}
+	plain := Make(&state, pathTerminal, pathUtility, pathSuccessors, true)
+
+	c := NewCountermoves(pathMove)
+	mm := Make(&state, pathTerminal, pathUtility, pathSuccessors, true, WithCountermoves(c))
+	if mm.Result().Value != plain.Result().Value {
+		t.Errorf("Expected value %d, got %d", plain.Result().Value, mm.Result().Value)
+	}
+	if c.Len() == 0 {
+		t.Fatal("Expected countermoves to be learned")
+	}
+
+	// Replying 2 to move 1 caused a cutoff
+	parent := &node[pathState]{elem: &pathState{}}
+	n := &node[pathState]{elem: pathSuccessors(parent.elem)[1], from: parent, depth: 1}
+	for _, succ := range pathSuccessors(n.elem) {
+		n.children = append(n.children, &node[pathState]{elem: succ, from: n, depth: 2})
+	}
+	c.learn(n, n.children[2])
+	if reply, ok := c.Reply(1); !ok || reply != 2 {
+		t.Errorf("Expected reply 2 to move 1, got %d", reply)
+	}
+	c.order(n)
+	if pathMove(n.elem, n.children[0].elem) != 2 {
+		t.Errorf("Expected the countermove first, got %v", n.children)
+	}
+}
@@ -0,0 +1,80 @@
+package minimax
+
+// MaxHeuristic bounds heuristic values. They are clamped to
+// [-MaxHeuristic, MaxHeuristic], so they always rank below proven wins and
+// above proven losses.
+const MaxHeuristic = 10000
+
+// Decided reports whether v, on the scale of Result.Value, is a proven win or
+// loss for the AI, and if so in how many plies from the searched state the
+// game ends
+func Decided(v int) (plies int, ok bool) {
+	if v >= -MaxHeuristic && v <= MaxHeuristic {
+		return 0, false
+	}
+	return score - abs(v), true
+}
+
+// Cutoff tells how states cut off by the depth limit are scored, since their
+// true outcome is unknown. Whatever the policy, Result.Unknown reports whether
+// the value of the searched state depends on such a guess.
+type Cutoff int
+
+const (
+	CutoffDraw        Cutoff = iota // Score as a draw (the default)
+	CutoffHeuristic                 // Score with the function given to WithHeuristic
+	CutoffPessimistic               // Score as the worst outcome for the AI short of a proven loss
+)
+
+// WithCutoff selects how states cut off by the depth limit are scored
+func WithCutoff(c Cutoff) Option {
+	return func(o *options) {
+		o.cutoff = c
+	}
+}
+
+// WithHeuristic scores states cut off by the depth limit with h, which should
+// estimate the value of a state for the AI (higher is better). It implies
+// WithCutoff(CutoffHeuristic), and replaces any earlier WithStages or
+// WithEvaluator.
+func WithHeuristic[T comparable](h func(*T) int) Option {
+	return func(o *options) {
+		o.heuristic = h
+		o.stages, o.noisy = nil, nil
+		o.cutoff = CutoffHeuristic
+	}
+}
+
+// cutoffValue scores a node cut off by the depth limit
+func (s *search[T]) cutoffValue(n *node[T]) int {
+	switch s.cutoff {
+	case CutoffHeuristic:
+		var v int
+		if s.quiescence > 0 {
+			v = s.quiesce(n.elem, n.isMax, n.alpha, n.beta, n.depth, s.quiescence)
+		} else if s.stages != nil {
+			v = max(-MaxHeuristic, min(MaxHeuristic, s.staged(n)))
+		} else {
+			v = max(-MaxHeuristic, min(MaxHeuristic, s.heuristic(n.elem)))
+		}
+		s.observeStatic(n, v)
+		return v
+	case CutoffPessimistic:
+		return -MaxHeuristic
+	default:
+		return 0
+	}
+}
+
+// unknownValue tells whether the value of n depends on branches cut off by a
+// limit, given whether its best child's value and any child's value do. A
+// proven win for the player to move is certain whatever the other children hide.
+func unknownValue[T comparable](n *node[T], bestUnknown, anyUnknown bool) bool {
+	if bestUnknown {
+		return true
+	}
+	if n.isMax {
+		return anyUnknown && n.val <= MaxHeuristic
+	}
+	return anyUnknown && n.val >= -MaxHeuristic
+}
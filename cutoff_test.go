@@ -0,0 +1,87 @@
+package minimax
+
+import "testing"
+
+// nimHeuristic knows the winning strategy of Nim, so a shallow search with it plays perfectly.
+func nimHeuristic(s *nimState) int {
+	if (s.stones%4 == 0) != s.aiTurn {
+		return 100 // The player to move is losing, and it's the opponent
+	}
+	return -100
+}
+
+// TestValueScale tests the values of proven wins and losses.
+func TestValueScale(t *testing.T) {
+	tests := []struct {
+		stones, value, plies int
+	}{
+		{6, 30000 - 3, 3},  // Take 2, then the last stones
+		{10, 30000 - 5, 5}, // Take 2, then leave multiples of 4
+		{8, 4 - 30000, 4},  // Lost, the opponent leaving multiples of 4
+	}
+	for _, tt := range tests {
+		state := nimState{stones: tt.stones, aiTurn: true}
+		v := Make(&state, nimTerminal, nimUtility, nimSuccessors, true).Result().Value
+		if plies, ok := Decided(v); v != tt.value || !ok || plies != tt.plies {
+			t.Errorf("%d stones: expected %d, decided in %d plies, got %d", tt.stones, tt.value, tt.plies, v)
+		}
+	}
+}
+
+// TestCutoffPolicies tests how states cut off by the depth limit are scored.
+func TestCutoffPolicies(t *testing.T) {
+	state := nimState{stones: 7, aiTurn: true}
+	limit := WithLimits(Limits{MaxDepth: 1})
+
+	tests := []struct {
+		name      string
+		opts      []Option
+		wantValue int
+		wantMove  int // Stones left by the best move, 0 to skip the check
+	}{
+		{name: "draw", opts: []Option{limit}, wantValue: 0},
+		{name: "pessimistic", opts: []Option{limit, WithCutoff(CutoffPessimistic)}, wantValue: -MaxHeuristic},
+		{name: "heuristic", opts: []Option{limit, WithHeuristic(nimHeuristic)}, wantValue: 100, wantMove: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mm := Make(&state, nimTerminal, nimUtility, nimSuccessors, true, tt.opts...)
+			res := mm.Result()
+			if res.Value != tt.wantValue {
+				t.Errorf("Expected value %d, got %d", tt.wantValue, res.Value)
+			}
+			if !res.Unknown {
+				t.Error("Expected the value to be marked unknown")
+			}
+			if move := mm.Solve(state); tt.wantMove != 0 && (move == nil || move.stones != tt.wantMove) {
+				t.Errorf("Expected to leave %d stones, got %v", tt.wantMove, move)
+			}
+		})
+	}
+}
+
+// TestCutoffKnownWin tests that a proven win is not marked unknown even if other branches were cut off.
+func TestCutoffKnownWin(t *testing.T) {
+	state := nimState{stones: 3, aiTurn: true}
+	mm := Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithLimits(Limits{MaxDepth: 1}))
+
+	res := mm.Result()
+	if res.Unknown {
+		t.Error("Expected a proven win to be known")
+	}
+	if res.Value <= MaxHeuristic {
+		t.Errorf("Expected a winning value, got %d", res.Value)
+	}
+}
+
+// TestCutoffHeuristicRequired tests that CutoffHeuristic without a heuristic is rejected.
+func TestCutoffHeuristicRequired(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected Make to panic")
+		}
+	}()
+	state := nimState{stones: 3, aiTurn: true}
+	Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithCutoff(CutoffHeuristic))
+}
@@ -0,0 +1,36 @@
+package minimax
+
+import "sync/atomic"
+
+// WithRepetitions makes the search safe for games whose states can repeat.
+// A state that already occurs on the path from the root is not searched again
+// but scored as value, from the AI's point of view (0 to call it a draw).
+//
+// Values that depend on such a repetition are only valid for the path they
+// were found on (the graph-history-interaction problem), so those nodes are
+// never shared with other paths reaching the same state: they are searched
+// again instead. Without this option, a game with cycles and no depth limit
+// recurses forever.
+func WithRepetitions(value int) Option {
+	return func(o *options) {
+		o.cycles = true
+		o.repetition = value
+	}
+}
+
+// ancestor is a frame of the path from the root to the node being searched
+type ancestor[T comparable] struct {
+	state     *T
+	up        *ancestor[T]
+	dependent atomic.Bool // Whether the value of this frame's node depends on the path
+}
+
+// repeats reports whether state occurs on the path ending at a
+func (a *ancestor[T]) repeats(state *T) bool {
+	for ; a != nil; a = a.up {
+		if *a.state == *state {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package minimax
+
+import "testing"
+
+// raceState is a token on squares 0 to 4 that players move one square either
+// way. Whoever reaches square 4 wins, so nobody wants to step onto square 3
+// and play can go back and forth forever.
+type raceState struct {
+	pos    int
+	aiTurn bool
+}
+
+func raceTerminal(s *raceState) bool {
+	return s.pos == 4
+}
+
+func raceUtility(s *raceState) int {
+	if s.pos != 4 {
+		return 0
+	}
+	if s.aiTurn {
+		return -1
+	}
+	return 1
+}
+
+func raceSuccessors(s *raceState) []*raceState {
+	succ := []*raceState{{pos: s.pos + 1, aiTurn: !s.aiTurn}}
+	if s.pos > 0 {
+		succ = append(succ, &raceState{pos: s.pos - 1, aiTurn: !s.aiTurn})
+	}
+	return succ
+}
+
+// TestRepetitions tests that games with cycles terminate and score repetitions as draws.
+func TestRepetitions(t *testing.T) {
+	tests := []struct {
+		name     string
+		pos      int
+		wantPos  int
+		wantSign int
+	}{
+		{name: "win in one", pos: 3, wantPos: 4, wantSign: 1},
+		{name: "avoid stepping next to the goal", pos: 2, wantPos: 1, wantSign: 0},
+		{name: "forced onto the board", pos: 0, wantPos: 1, wantSign: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := raceState{pos: tt.pos, aiTurn: true}
+			mm := Make(&state, raceTerminal, raceUtility, raceSuccessors, true, WithRepetitions(0))
+
+			move := mm.Solve(state)
+			if move == nil || move.pos != tt.wantPos {
+				t.Errorf("Expected to move to %d, got %v", tt.wantPos, move)
+			}
+			if v := mm.Result().Value; (v > 0) != (tt.wantSign > 0) || (v == 0) != (tt.wantSign == 0) {
+				t.Errorf("Expected a value of sign %d, got %d", tt.wantSign, v)
+			}
+		})
+	}
+}
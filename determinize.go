@@ -0,0 +1,112 @@
+package minimax
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+)
+
+// Aggregation tells how Determinized combines the searches of its samples
+type Aggregation int
+
+const (
+	AggregateVote    Aggregation = iota // Play the move chosen best by the most samples
+	AggregateAverage                    // Play the move with the best value on average over the samples
+)
+
+// Determinized plays games of imperfect information, such as card games,
+// with the perfect-information search: it samples states consistent with
+// what the AI knows (its information set I), searches each one as if every
+// card were face up, and combines the recommended moves. Since the successor
+// states differ between samples, moves are compared by an identity M, such
+// as the card played.
+type Determinized[I any, T, M comparable] struct {
+	Game        Game[T]                      // Rules of the game with all information revealed
+	Sample      func(info I, r *rand.Rand) T // Returns a state consistent with info
+	Move        func(from, to *T) M          // Identifies a move independently of hidden information
+	Samples     int                          // States searched; 20 if not positive
+	Aggregation Aggregation                  // How the samples' results are combined
+	Options     []Option                     // Options for each search
+	Rand        *rand.Rand                   // Source for Sample, seeded with 1 if nil
+}
+
+// MoveStats summarises what the samples think of a move
+type MoveStats[M comparable] struct {
+	Move    M
+	Votes   int     // Samples whose search chose the move
+	Samples int     // Samples where the move was legal
+	Value   float64 // Average value of the move for the AI over those samples, with AggregateAverage
+}
+
+// Solve returns the move to play in the information set info, with isMax true
+// if it is the AI's turn, and the statistics of every move seen, best first.
+// It returns false if no sample had a legal move.
+func (d Determinized[I, T, M]) Solve(info I, isMax bool) (M, []MoveStats[M], bool) {
+	samples := d.Samples
+	if samples <= 0 {
+		samples = 20
+	}
+	r := d.Rand
+	if r == nil {
+		r = rand.New(rand.NewSource(1))
+	}
+
+	var order []M // Moves in the order first seen, to break ties deterministically
+	stats := make(map[M]*MoveStats[M])
+	add := func(m M) *MoveStats[M] {
+		st := stats[m]
+		if st == nil {
+			st = &MoveStats[M]{Move: m}
+			stats[m] = st
+			order = append(order, m)
+		}
+		return st
+	}
+
+	for range samples {
+		state := d.Sample(info, r)
+		if d.Game.IsTerminal(&state) {
+			continue
+		}
+		mm := d.Game.Make(&state, isMax, d.Options...)
+
+		if d.Aggregation == AggregateAverage {
+			for _, ms := range mm.ScoreMoves(state) {
+				st := add(d.Move(&state, ms.Move))
+				st.Samples++
+				st.Value += float64(ms.Value)
+			}
+		} else {
+			for _, succ := range d.Game.Successors(&state) {
+				add(d.Move(&state, succ)).Samples++
+			}
+		}
+		if best := mm.Solve(state); best != nil {
+			add(d.Move(&state, best)).Votes++
+		}
+	}
+
+	result := make([]MoveStats[M], len(order))
+	for i, m := range order {
+		st := stats[m]
+		if d.Aggregation == AggregateAverage && st.Samples > 0 {
+			st.Value /= float64(st.Samples)
+		}
+		result[i] = *st
+	}
+	if len(result) == 0 {
+		var zero M
+		return zero, nil, false
+	}
+
+	slices.SortStableFunc(result, func(a, b MoveStats[M]) int {
+		if d.Aggregation == AggregateAverage {
+			if isMax {
+				return cmp.Compare(b.Value, a.Value)
+			}
+			return cmp.Compare(a.Value, b.Value)
+		}
+		return cmp.Compare(b.Votes, a.Votes)
+	})
+	return result[0].Move, result, true
+}
@@ -0,0 +1,59 @@
+package minimax
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// boxState hides a prize in one of three boxes, and the AI picks one
+type boxState struct {
+	prize int
+	pick  int // -1 until the AI picks
+}
+
+// TestDeterminized tests that votes and averages follow the sampled hidden information.
+func TestDeterminized(t *testing.T) {
+	game := Game[boxState]{
+		IsTerminal: func(s *boxState) bool { return s.pick >= 0 },
+		Utility: func(s *boxState) int {
+			if s.pick == s.prize {
+				return 1
+			}
+			return -1
+		},
+		Successors: func(s *boxState) []*boxState {
+			var succ []*boxState
+			for box := range 3 {
+				succ = append(succ, &boxState{prize: s.prize, pick: box})
+			}
+			return succ
+		},
+	}
+
+	// The AI believes the prize is in box 1 most of the time, and never in box 2
+	sample := func(belief float64, r *rand.Rand) boxState {
+		if r.Float64() < belief {
+			return boxState{prize: 1, pick: -1}
+		}
+		return boxState{prize: 0, pick: -1}
+	}
+	for _, agg := range []Aggregation{AggregateVote, AggregateAverage} {
+		d := Determinized[float64, boxState, int]{
+			Game:        game,
+			Sample:      sample,
+			Move:        func(from, to *boxState) int { return to.pick },
+			Samples:     50,
+			Aggregation: agg,
+		}
+		move, stats, ok := d.Solve(0.8, true)
+		if !ok || move != 1 {
+			t.Errorf("aggregation %d: expected box 1, got %d (%+v)", agg, move, stats)
+		}
+		if len(stats) != 3 || stats[0].Samples != 50 || stats[0].Votes < 30 {
+			t.Errorf("aggregation %d: unexpected statistics %+v", agg, stats)
+		}
+		if agg == AggregateAverage && stats[2].Value != -score+1 {
+			t.Errorf("Expected box 2 to always lose, got %+v", stats[2])
+		}
+	}
+}
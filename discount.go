@@ -0,0 +1,40 @@
+package minimax
+
+import "math"
+
+// WithDiscount discounts values by gamma per ply, as in reinforcement
+// learning, instead of taking a point off wins and losses per ply: a value v
+// found d plies below the searched state is worth v·gamma^d. Both the
+// heuristic values of states cut off by the depth limit and the values of
+// proven wins and losses are discounted, so the search prefers quicker wins
+// and slower losses, and also good positions sooner than later.
+//
+// Proven wins and losses keep ranking beyond MaxHeuristic, their part beyond
+// it being discounted, so Decided still tells them apart, but the plies it
+// reports no longer count moves. A gamma outside (0, 1) disables the
+// discount. WithTable, which stores values independently of depth, cannot
+// be used along with it.
+func WithDiscount(gamma float64) Option {
+	return func(o *options) {
+		o.gamma = 0
+		if gamma > 0 && gamma < 1 {
+			o.gamma = gamma
+		}
+	}
+}
+
+// win returns the value of a proven win for the AI at depth
+func (s *search[T]) win(depth int) int {
+	if s.gamma == 0 {
+		return score - depth
+	}
+	return MaxHeuristic + 1 + int(math.Round(float64(score-MaxHeuristic-1)*math.Pow(s.gamma, float64(depth))))
+}
+
+// discount discounts the heuristic value v of n by the plies below the root
+func (s *search[T]) discount(n *node[T], v int) int {
+	if s.gamma == 0 {
+		return v
+	}
+	return int(math.Round(float64(v) * math.Pow(s.gamma, float64(n.depth))))
+}
@@ -0,0 +1,48 @@
+package minimax
+
+import (
+	"math"
+	"testing"
+)
+
+// TestDiscountWins tests that discounted wins still rank beyond heuristics and prefer the quickest.
+func TestDiscountWins(t *testing.T) {
+	for stones := 5; stones <= 11; stones += 2 {
+		state := nimState{stones: stones, aiTurn: true}
+		plain := Make(&state, nimTerminal, nimUtility, nimSuccessors, true)
+		discounted := Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithDiscount(0.9))
+
+		plies, _ := Decided(plain.Result().Value)
+		want := MaxHeuristic + 1 + int(math.Round(float64(score-MaxHeuristic-1)*math.Pow(0.9, float64(plies))))
+		if got := discounted.Result().Value; got != want {
+			t.Errorf("%d stones: expected a win worth %d, got %d", stones, want, got)
+		}
+		if *discounted.Solve(state) != *plain.Solve(state) {
+			t.Errorf("%d stones: expected the same move as without discount", stones)
+		}
+	}
+}
+
+// TestDiscountHeuristic tests that heuristic values are discounted by their depth.
+func TestDiscountHeuristic(t *testing.T) {
+	state := pathState{}
+	h := WithHeuristic(func(*pathState) int { return 100 })
+	for gamma, want := range map[float64]int{0.5: 13, 0.9: 73, 1: 100, 0: 100} {
+		mm := Make(&state, pathTerminal, pathUtility, pathSuccessors, true,
+			WithLimits(Limits{MaxDepth: 3}), h, WithDiscount(gamma))
+		if got := mm.Result().Value; got != want {
+			t.Errorf("Gamma %v: expected %d, got %d", gamma, want, got)
+		}
+	}
+}
+
+// TestDiscountTable tests that a discount is refused along with a transposition table.
+func TestDiscountTable(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic")
+		}
+	}()
+	state := nimState{stones: 3, aiTurn: true}
+	Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithDiscount(0.9), WithTable(NewTable(1<<10), nimHash))
+}
@@ -0,0 +1,36 @@
+package minimax
+
+// WithEndgame switches to exact solving for states where isEndgame is true:
+// such states are never cut off by MaxDepth, so the depth-limited heuristic
+// search of the midgame turns into an exhaustive search of the rest of the
+// game below them, and endgames are played perfectly. A root state in the
+// endgame is solved outright. EndgameWithin builds isEndgame from a count of
+// the moves left.
+//
+// isEndgame should only hold when the rest of the game is small enough to
+// solve, and stay true for the states that follow; MaxNodes and MaxTime
+// still stop searches that turn out too large. Razoring, beams and widening
+// leave endgame states alone, since they would make their values inexact.
+func WithEndgame[T comparable](isEndgame func(*T) bool) Option {
+	return func(o *options) {
+		o.endgame = isEndgame
+	}
+}
+
+// EndgameWithin returns an endgame test for WithEndgame that holds once at
+// most moves moves are left, as counted by remaining
+func EndgameWithin[T comparable](remaining func(*T) int, moves int) func(*T) bool {
+	return func(s *T) bool {
+		return remaining(s) <= moves
+	}
+}
+
+// exact reports whether n is in the endgame, to be solved exactly
+func (s *search[T]) exact(n *node[T]) bool {
+	return s.endgame != nil && s.endgame(n.elem)
+}
+
+// cutOff reports whether n is cut off by the depth limit
+func (s *search[T]) cutOff(n *node[T]) bool {
+	return s.limits.MaxDepth > 0 && n.depth >= s.limits.MaxDepth && !s.exact(n)
+}
@@ -0,0 +1,29 @@
+package minimax
+
+import "testing"
+
+// TestEndgame tests that endgame states are solved exactly despite the depth limit.
+func TestEndgame(t *testing.T) {
+	stones := func(s *nimState) int { return s.stones }
+	limit := WithLimits(Limits{MaxDepth: 4})
+	exact := Make(&nimState{stones: 21, aiTurn: true}, nimTerminal, nimUtility, nimSuccessors, true).Result()
+
+	for _, tc := range []struct {
+		stones, within int
+		unknown        bool
+	}{
+		{21, 12, true},  // The frontier is still in the midgame
+		{21, 18, false}, // The frontier is in the endgame
+		{8, 8, false},   // The root is in the endgame
+	} {
+		state := nimState{stones: tc.stones, aiTurn: true}
+		res := Make(&state, nimTerminal, nimUtility, nimSuccessors, true, limit,
+			WithEndgame(EndgameWithin(stones, tc.within))).Result()
+		if res.Unknown != tc.unknown {
+			t.Errorf("%d stones, endgame within %d: expected unknown %v, got %+v", tc.stones, tc.within, tc.unknown, res)
+		}
+		if tc.stones == 21 && !tc.unknown && res.Value != exact.Value {
+			t.Errorf("Expected the exact value %d, got %d", exact.Value, res.Value)
+		}
+	}
+}
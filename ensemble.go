@@ -0,0 +1,173 @@
+package minimax
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+)
+
+// Combine tells how an Ensemble combines the opinions of its members
+type Combine int
+
+const (
+	CombineVote  Combine = iota // Play the move picked as best by the largest weight of members (the default)
+	CombineScore                // Play the move with the best weighted mean value over the members
+)
+
+// Member is an engine taking part in an Ensemble. By default it is a minimax
+// search with Options; Score replaces it with any other engine, such as a
+// Monte Carlo search or a hand-written policy.
+type Member[T comparable] struct {
+	Name    string
+	Weight  float64  // Say of the member in the decision; 1 if zero
+	Options []Option // Options of the member's minimax search
+
+	// Scores the moves from state, on the scale of Result.Value, with isMax
+	// true if it is the AI's turn. May be nil for a minimax search. Moves it
+	// leaves out get no score from the member, and moves it did not score
+	// Complete are left out like those of searches a limit stopped.
+	Score func(state T, isMax bool) []MoveScore[T]
+}
+
+// Ensemble is a meta-engine: it asks several engines for their opinion of the
+// same position and combines their recommendations, so that each covers for
+// the blind spots of the others. Members search concurrently, so the game's
+// functions must be safe for concurrent use, as for WithParallel.
+type Ensemble[T comparable] struct {
+	Game    Game[T]
+	Members []Member[T]
+	Combine Combine
+}
+
+// Tally is what the members of an Ensemble think of a move
+type Tally[T comparable] struct {
+	Move   *T
+	Votes  float64  // Total weight of the members that picked the move as best
+	Score  float64  // Weighted mean value of the move for the AI, over the members that scored it
+	Voters []string // Names of the members that picked the move as best
+	weight float64  // Total weight of the members that scored the move
+}
+
+// Solve returns the move the members agree on from state, with isMax true
+// if it is the AI's turn, and the tally of every move the members scored,
+// best first. With CombineVote, minimax members score only their best move,
+// and ties between votes are broken by score. With CombineScore, they score
+// every move with ScoreMoves, so the members' values must share a scale, and
+// ties between scores are broken by votes. Moves whose search a node or time
+// limit stopped are left out, along with the votes of the members that found
+// them, unless no move was searched completely. It returns nil for terminal
+// states or if no member found a move.
+func (e Ensemble[T]) Solve(state T, isMax bool) (*T, []Tally[T]) {
+	if e.Game.IsTerminal(&state) {
+		return nil, nil
+	}
+
+	// Options are checked before searching, so that their panics reach the caller
+	searches := make([]Minimax[T], len(e.Members))
+	for i, m := range e.Members {
+		if m.Score == nil {
+			searches[i] = Minimax[T]{
+				moveMap: make(map[T]*T),
+				config:  newConfig(e.Game.IsTerminal, e.Game.Utility, e.Game.Successors, isMax, m.Options),
+				result:  new(Result),
+			}
+		}
+	}
+
+	opinions := make([][]MoveScore[T], len(e.Members))
+	var wg sync.WaitGroup
+	for i, m := range e.Members {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if m.Score != nil {
+				opinions[i] = m.Score(state, isMax)
+			} else {
+				opinions[i] = e.opinion(searches[i], state)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Moves a limit cut short are only counted if no member completed any
+	complete := func(o []MoveScore[T]) bool { return slices.ContainsFunc(o, MoveScore[T].Complete) }
+	if slices.ContainsFunc(opinions, complete) {
+		for i := range opinions {
+			opinions[i] = slices.DeleteFunc(opinions[i], func(ms MoveScore[T]) bool { return !ms.Complete() })
+		}
+	}
+
+	var tallies []*Tally[T]
+	index := make(map[T]*Tally[T])
+	for i, m := range e.Members {
+		weight := m.Weight
+		if weight == 0 {
+			weight = 1
+		}
+		top := favorite(opinions[i], isMax)
+		for j, ms := range opinions[i] {
+			t := index[*ms.Move]
+			if t == nil {
+				t = &Tally[T]{Move: ms.Move}
+				index[*ms.Move] = t
+				tallies = append(tallies, t)
+			}
+			t.Score += weight * float64(ms.Value)
+			t.weight += weight
+			if j == top {
+				t.Votes += weight
+				t.Voters = append(t.Voters, m.Name)
+			}
+		}
+	}
+	if len(tallies) == 0 {
+		return nil, nil
+	}
+
+	result := make([]Tally[T], len(tallies))
+	for i, t := range tallies {
+		if t.weight != 0 {
+			t.Score /= t.weight
+		}
+		result[i] = *t
+	}
+	side := 1.0
+	if !isMax {
+		side = -1
+	}
+	slices.SortStableFunc(result, func(a, b Tally[T]) int {
+		votes, score := cmp.Compare(b.Votes, a.Votes), cmp.Compare(side*b.Score, side*a.Score)
+		if e.Combine == CombineScore {
+			votes, score = score, votes
+		}
+		if votes != 0 {
+			return votes
+		}
+		return score
+	})
+	return result[0].Move, result
+}
+
+// opinion returns the moves from state the search mm scored
+func (e Ensemble[T]) opinion(mm Minimax[T], state T) []MoveScore[T] {
+	if e.Combine == CombineScore {
+		return mm.ScoreMoves(state)
+	}
+	move := mm.Solve(state)
+	if move == nil {
+		return nil
+	}
+	res := mm.Result()
+	return []MoveScore[T]{{Move: move, Value: res.Value, Unknown: res.Unknown, Nodes: res.Nodes, Stopped: res.Stopped}}
+}
+
+// favorite returns the index of the best of scores for the side to move, the first of equals
+func favorite[T comparable](scores []MoveScore[T], isMax bool) int {
+	b := 0
+	for i, ms := range scores {
+		if isMax && ms.Value > scores[b].Value || !isMax && ms.Value < scores[b].Value {
+			b = i
+		}
+	}
+	return b
+}
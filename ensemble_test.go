@@ -0,0 +1,95 @@
+package minimax
+
+import (
+	"slices"
+	"testing"
+)
+
+var nimGame = Game[nimState]{IsTerminal: nimTerminal, Utility: nimUtility, Successors: nimSuccessors}
+
+// TestEnsembleVote tests that votes are weighted and ties broken by score.
+func TestEnsembleVote(t *testing.T) {
+	shallow := []Option{WithLimits(Limits{MaxDepth: 1})}
+	e := Ensemble[nimState]{Game: nimGame, Members: []Member[nimState]{
+		{Name: "deep"},
+		{Name: "shallow", Options: shallow},
+		{Name: "shallow too", Options: shallow},
+	}}
+	state := nimState{stones: 7, aiTurn: true}
+
+	// Depth 1 sees no difference between moves and takes the first
+	move, tallies := e.Solve(state, true)
+	if move.stones != 6 || len(tallies) != 2 || tallies[0].Votes != 2 || !slices.Equal(tallies[0].Voters, []string{"shallow", "shallow too"}) {
+		t.Errorf("Expected the shallow members to outvote the deep one, got %v and %+v", move, tallies)
+	}
+
+	e.Members[0].Weight = 3
+	if move, _ := e.Solve(state, true); move.stones != 4 {
+		t.Errorf("Expected the heavier deep member to win, got %v", move)
+	}
+
+	// A tie is broken by the deep member's proven win
+	e.Members[0].Weight = 2
+	if move, tallies := e.Solve(state, true); move.stones != 4 || tallies[0].Votes != tallies[1].Votes {
+		t.Errorf("Expected a tie broken by score, got %v and %+v", move, tallies)
+	}
+
+	if move, _ := e.Solve(nimState{}, true); move != nil {
+		t.Errorf("Expected no move from a terminal state, got %v", move)
+	}
+}
+
+// TestEnsembleScore tests aggregating the values of every move, including
+// those of members that are not minimax searches.
+func TestEnsembleScore(t *testing.T) {
+	fond := func(stones int) func(nimState, bool) []MoveScore[nimState] {
+		return func(s nimState, isMax bool) []MoveScore[nimState] {
+			return []MoveScore[nimState]{{Move: &nimState{stones: stones, aiTurn: !s.aiTurn}, Value: 1 - score}}
+		}
+	}
+	e := Ensemble[nimState]{Game: nimGame, Combine: CombineScore, Members: []Member[nimState]{
+		{Name: "deep"},
+		{Name: "shallow", Options: []Option{WithLimits(Limits{MaxDepth: 1})}},
+		{Name: "fond of 5", Score: fond(5)},
+	}}
+
+	// The opponent wants the lowest value: leaving 4 stones is a proven win for it
+	state := nimState{stones: 7}
+	move, tallies := e.Solve(state, false)
+	if move.stones != 4 || len(tallies) != 3 {
+		t.Fatalf("Expected to leave 4 stones, got %v and %+v", move, tallies)
+	}
+	for _, tl := range tallies {
+		if tl.Move.stones == 5 && abs(int(tl.Score)) > 1 {
+			t.Errorf("Expected the custom member to cancel the deep one out on 5 stones, got %+v", tl)
+		}
+		if tl.Move.stones == 4 && (tl.Votes != 1 || tl.Voters[0] != "deep") {
+			t.Errorf("Expected the deep member alone to vote for 4 stones, got %+v", tl)
+		}
+	}
+
+	// Weighting the custom member up makes it prevail
+	e.Members[2].Weight = 100
+	if move, _ := e.Solve(state, false); move.stones != 5 {
+		t.Errorf("Expected the heavy custom member to win, got %v", move)
+	}
+}
+
+// TestEnsembleLimits tests that members cut short by a limit do not swing the decision.
+func TestEnsembleLimits(t *testing.T) {
+	for _, combine := range []Combine{CombineVote, CombineScore} {
+		e := Ensemble[nimState]{Game: nimGame, Combine: combine, Members: []Member[nimState]{
+			{Name: "shallow", Options: []Option{WithLimits(Limits{MaxDepth: 2})}},
+			{Name: "starved", Weight: 100, Options: []Option{WithLimits(Limits{MaxDepth: 30, MaxNodes: 3})}},
+		}}
+		move, tallies := e.Solve(nimState{stones: 40, aiTurn: true}, true)
+		if move == nil {
+			t.Fatalf("Combine %d: expected a move", combine)
+		}
+		for _, tl := range tallies {
+			if slices.Contains(tl.Voters, "starved") || abs(int(tl.Score)) > MaxHeuristic {
+				t.Errorf("Combine %d: expected the starved member to be left out, got %+v", combine, tl)
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package minimax
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Estimate predicts the size of a search to a given depth
+type Estimate struct {
+	Depth    int           // Depth limit of the search
+	Nodes    float64       // Nodes of the full tree down to Depth, which bounds what alpha-beta visits from above
+	MinNodes float64       // Nodes of the minimal tree alpha-beta visits with perfect move ordering
+	Time     time.Duration // Time to generate Nodes nodes at the rate measured while probing
+}
+
+// EstimateTree predicts the size of the game tree below start for every depth
+// limit from 1 to maxDepth, before committing to a search, so that a feasible
+// depth can be picked instead of discovered the hard way. It uses Knuth's
+// method: each of probes random walks (100 if not positive) multiplies the
+// branching factors it meets, which is an unbiased estimate of the number of
+// nodes at every depth, and the estimates are averaged.
+//
+// The estimates ignore transpositions, which the engine searches once, so they
+// err on the high side for games with many of them. Time only accounts for the
+// game functions, timed while probing; the search itself adds some overhead.
+// r is seeded with 1 if nil.
+func EstimateTree[T comparable](g Game[T], start T, maxDepth, probes int, r *rand.Rand) []Estimate {
+	return estimateTree(start, g.IsTerminal, g.Successors, maxDepth, probes, r)
+}
+
+// estimateTree is EstimateTree with the game functions passed separately
+func estimateTree[T comparable](start T, isTerminal func(*T) bool, successors func(*T) []*T,
+	maxDepth, probes int, r *rand.Rand,
+) []Estimate {
+	if maxDepth <= 0 {
+		return nil
+	}
+	if probes <= 0 {
+		probes = 100
+	}
+	if r == nil {
+		r = rand.New(rand.NewSource(1))
+	}
+
+	levels := make([]float64, maxDepth+1)  // Nodes at each depth, summed over probes
+	branching := make([]float64, maxDepth) // Branching factor at each depth, summed over probes
+	reached := make([]int, maxDepth)       // Probes that expanded a node at each depth
+	var calls int
+	var spent time.Duration
+
+	for range probes {
+		state, width := start, 1.0
+		levels[0]++
+		for depth := 0; depth < maxDepth; depth++ {
+			t := time.Now()
+			var succ []*T
+			if !isTerminal(&state) {
+				succ = successors(&state)
+			}
+			spent += time.Since(t)
+			calls++
+			if len(succ) == 0 {
+				break
+			}
+			width *= float64(len(succ))
+			levels[depth+1] += width
+			branching[depth] += float64(len(succ))
+			reached[depth]++
+			state = *succ[r.Intn(len(succ))]
+		}
+	}
+
+	perNode := spent / time.Duration(calls)
+	estimates := make([]Estimate, maxDepth)
+	nodes := levels[0] / float64(probes)
+	even, odd := 1.0, 1.0 // Products of the branching factors at even and odd depths so far
+	minNodes := 1.0
+	for depth := 1; depth <= maxDepth; depth++ {
+		nodes += levels[depth] / float64(probes)
+		if b := reached[depth-1]; b > 0 {
+			if (depth-1)%2 == 0 {
+				even *= branching[depth-1] / float64(b)
+			} else {
+				odd *= branching[depth-1] / float64(b)
+			}
+			minNodes += even + odd - 1
+		}
+		estimates[depth-1] = Estimate{
+			Depth:    depth,
+			Nodes:    nodes,
+			MinNodes: min(minNodes, nodes),
+			Time:     time.Duration(nodes * float64(perNode)),
+		}
+	}
+	return estimates
+}
@@ -0,0 +1,37 @@
+package minimax
+
+import (
+	"math"
+	"testing"
+)
+
+// TestEstimateTree tests the estimates on a uniform tree, where they are exact, and on Nim.
+func TestEstimateTree(t *testing.T) {
+	path := Game[pathState]{IsTerminal: pathTerminal, Utility: pathUtility, Successors: pathSuccessors}
+	estimates := EstimateTree(path, pathState{}, 4, 10, nil)
+	want := []struct{ nodes, min float64 }{{4, 4}, {13, 9}, {40, 20}, {121, 37}}
+	for i, e := range estimates {
+		if e.Depth != i+1 || e.Nodes != want[i].nodes || e.MinNodes != want[i].min {
+			t.Errorf("Expected %v at depth %d, got %+v", want[i], i+1, e)
+		}
+	}
+
+	// Nim trees are irregular, but the estimate is unbiased
+	nim := Game[nimState]{IsTerminal: nimTerminal, Utility: nimUtility, Successors: nimSuccessors}
+	var count func(s *nimState) float64
+	count = func(s *nimState) float64 {
+		n := 1.0
+		for _, succ := range nimSuccessors(s) {
+			n += count(succ)
+		}
+		return n
+	}
+	start := nimState{stones: 12, aiTurn: true}
+	full := EstimateTree(nim, start, 12, 5000, nil)[11]
+	if actual := count(&start); math.Abs(full.Nodes-actual) > actual/10 {
+		t.Errorf("Expected about %.0f nodes, got %.0f", actual, full.Nodes)
+	}
+	if full.MinNodes >= full.Nodes {
+		t.Errorf("Expected the minimal tree to be smaller, got %+v", full)
+	}
+}
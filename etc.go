@@ -0,0 +1,53 @@
+package minimax
+
+// WithTranspositionCutoffs enables enhanced transposition cutoffs (ETC):
+// before searching the children of a node in order, the engine checks whether
+// any of them was already searched through another path, or has an entry in
+// the table of WithTable deep enough, with a value that causes a cutoff, and
+// if so takes it without searching anything.
+//
+// It only pays off in games with transpositions, where it reliably saves
+// nodes for the price of a pass over the children.
+func WithTranspositionCutoffs() Option {
+	return func(o *options) {
+		o.etc = true
+	}
+}
+
+// transposedCutoff returns the index and value of a child of n whose value,
+// found through a transposition or in the transposition table, already causes
+// a cutoff within n's window.
+// Children repeating a state of path are scored by the repetition, not by the
+// value they have elsewhere, so they are skipped.
+func (s *search[T]) transposedCutoff(n *node[T], path *ancestor[T]) (int, nodeValue, bool) {
+	if !s.etc {
+		return 0, nodeValue{}, false
+	}
+
+	for i, child := range n.children {
+		if path.repeats(child.elem) {
+			continue
+		}
+		// A child being searched by another worker is not known yet
+		if !child.mu.TryLock() {
+			continue
+		}
+		cutoff := child.reusable(n.alpha, n.beta) &&
+			(n.isMax && child.val >= n.beta || !n.isMax && child.val <= n.alpha)
+		cv := nodeValue{child.val, child.unknown}
+		child.mu.Unlock()
+
+		// The child may not have been searched yet, but by an earlier search
+		// that left its value in the table
+		if !cutoff {
+			if v, ok := s.probe(child, n.alpha, n.beta); ok {
+				cutoff, cv = n.isMax && v.val >= n.beta || !n.isMax && v.val <= n.alpha, v
+			}
+		}
+
+		if cutoff {
+			return i, cv, true
+		}
+	}
+	return 0, nodeValue{}, false
+}
@@ -0,0 +1,36 @@
+package minimax
+
+import "testing"
+
+// TestTranspositionCutoffs tests that probing transposed children saves nodes without changing the result.
+func TestTranspositionCutoffs(t *testing.T) {
+	state := nimState{stones: 30, aiTurn: true}
+	plain := Make(&state, nimTerminal, nimUtility, nimSuccessors, true)
+	etc := Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithTranspositionCutoffs())
+
+	if etc.Result().Value != plain.Result().Value || *etc.Solve(state) != *plain.Solve(state) {
+		t.Errorf("Expected the same result, got %+v and %+v", etc.Result(), plain.Result())
+	}
+	if etc.Result().Nodes >= plain.Result().Nodes {
+		t.Errorf("Expected fewer nodes than %d, got %d", plain.Result().Nodes, etc.Result().Nodes)
+	}
+}
+
+// TestTranspositionCutoffsTable tests that children not searched yet are probed in the transposition table.
+func TestTranspositionCutoffsTable(t *testing.T) {
+	filled := func() *Table {
+		tt := NewTable(1 << 12)
+		Make(&nimState{stones: 20, aiTurn: false}, nimTerminal, nimUtility, nimSuccessors, false, WithTable(tt, nimHash))
+		return tt
+	}
+	state := nimState{stones: 21, aiTurn: true}
+	plain := Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithTable(filled(), nimHash))
+	etc := Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithTable(filled(), nimHash), WithTranspositionCutoffs())
+
+	if etc.Result().Value != plain.Result().Value || *etc.Solve(state) != *plain.Solve(state) {
+		t.Errorf("Expected the same result, got %+v and %+v", etc.Result(), plain.Result())
+	}
+	if etc.Result().Nodes >= plain.Result().Nodes {
+		t.Errorf("Expected fewer nodes than %d, got %d", plain.Result().Nodes, etc.Result().Nodes)
+	}
+}
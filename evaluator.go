@@ -0,0 +1,78 @@
+package minimax
+
+// Evaluator estimates the value of states for depth-limited searches, like
+// the function given to WithHeuristic, for evaluations that carry state of
+// their own such as tuned weights
+type Evaluator[T comparable] interface {
+	Evaluate(s *T) int // Estimates the value of s for the AI, higher is better
+}
+
+// NoisyEvaluator is an Evaluator that also knows which moves change its
+// estimate abruptly, such as captures in chess or corners in Othello. With
+// WithQuiescence, those moves are searched past the depth limit, so that a
+// state is not scored in the middle of an exchange.
+type NoisyEvaluator[T comparable] interface {
+	Evaluator[T]
+	Noisy(s *T) []*T // Successors of the non-terminal s reached by noisy moves, possibly none
+}
+
+// WithEvaluator scores states cut off by the depth limit with e, as
+// WithHeuristic does with e.Evaluate. If e is a NoisyEvaluator, its noisy
+// moves are what WithQuiescence searches. It replaces any earlier
+// WithHeuristic or WithStages.
+func WithEvaluator[T comparable](e Evaluator[T]) Option {
+	return func(o *options) {
+		o.heuristic = e.Evaluate
+		o.cutoff = CutoffHeuristic
+		o.stages, o.noisy = nil, nil
+		if ne, ok := e.(NoisyEvaluator[T]); ok {
+			o.noisy = ne.Noisy
+		}
+	}
+}
+
+// WithQuiescence extends the search past the depth limit with a quiescence
+// search: instead of taking the evaluation of a state cut off by the limit,
+// the side to move may either stand on it or play one of the noisy moves of
+// the NoisyEvaluator given to WithEvaluator, for up to plies more plies, and
+// the best of these is the value of the state. It needs such an evaluator.
+func WithQuiescence(plies int) Option {
+	return func(o *options) {
+		o.quiescence = max(plies, 0)
+	}
+}
+
+// quiesce returns the value of state, reached at depth with the AI to move if
+// isMax, standing on its evaluation or playing noisy moves for up to plies
+// plies within the window [alpha, beta]
+func (s *search[T]) quiesce(state *T, isMax bool, alpha, beta, depth, plies int) int {
+	if s.isTerminal(state) {
+		switch u := s.utility(state); {
+		case u > 0:
+			return s.win(depth)
+		case u < 0:
+			return -s.win(depth)
+		}
+		return 0
+	}
+
+	best := max(-MaxHeuristic, min(MaxHeuristic, s.heuristic(state)))
+	if plies == 0 {
+		return best
+	}
+	for _, next := range s.noisy(state) {
+		if isMax && best >= beta || !isMax && best <= alpha {
+			break // Standing, or a move found so far, already cuts off
+		}
+		s.nodes.Add(1)
+		v := s.quiesce(next, !isMax, alpha, beta, depth+1, plies-1)
+		if isMax {
+			best = max(best, v)
+			alpha = max(alpha, best)
+		} else {
+			best = min(best, v)
+			beta = min(beta, best)
+		}
+	}
+	return best
+}
@@ -0,0 +1,66 @@
+package minimax
+
+import "testing"
+
+// nimEvaluator knows nothing of Nim, but that taking the last stones wins
+type nimEvaluator struct{}
+
+func (nimEvaluator) Evaluate(s *nimState) int { return 0 }
+
+// Noisy returns the moves taking the last stones
+func (nimEvaluator) Noisy(s *nimState) []*nimState {
+	if s.stones > 3 {
+		return nil
+	}
+	return []*nimState{{stones: 0, aiTurn: !s.aiTurn}}
+}
+
+// heuristicOnly hides the noisy moves of an evaluator
+type heuristicOnly struct{ Evaluator[nimState] }
+
+// TestEvaluator tests that an Evaluator scores states like the heuristic it implements.
+func TestEvaluator(t *testing.T) {
+	state := nimState{stones: 9, aiTurn: true}
+	limit := WithLimits(Limits{MaxDepth: 2})
+	want := Make(&state, nimTerminal, nimUtility, nimSuccessors, true, limit, WithHeuristic(nimHeuristic))
+	got := Make(&state, nimTerminal, nimUtility, nimSuccessors, true, limit, WithEvaluator[nimState](evalFunc[nimState](nimHeuristic)))
+
+	if got.Result().Value != want.Result().Value || *got.Solve(state) != *want.Solve(state) {
+		t.Errorf("Expected %+v, got %+v", want.Result(), got.Result())
+	}
+}
+
+// evalFunc adapts a heuristic function to Evaluator
+type evalFunc[T comparable] func(*T) int
+
+func (f evalFunc[T]) Evaluate(s *T) int { return f(s) }
+
+// TestQuiescence tests that noisy moves are searched past the depth limit.
+func TestQuiescence(t *testing.T) {
+	// Every move leaves the opponent able to take the last stones
+	state := nimState{stones: 4, aiTurn: true}
+	limit := WithLimits(Limits{MaxDepth: 1})
+
+	quiet := Make(&state, nimTerminal, nimUtility, nimSuccessors, true, limit,
+		WithEvaluator[nimState](heuristicOnly{nimEvaluator{}}))
+	if v := quiet.Result().Value; v != 0 {
+		t.Errorf("Expected the horizon to hide the loss, got %d", v)
+	}
+
+	mm := Make(&state, nimTerminal, nimUtility, nimSuccessors, true, limit,
+		WithEvaluator[nimState](nimEvaluator{}), WithQuiescence(1))
+	res := mm.Result()
+	if plies, ok := Decided(res.Value); !ok || res.Value > 0 || plies != 2 {
+		t.Errorf("Expected a loss in 2 plies, got %d", res.Value)
+	}
+	if res.Nodes <= quiet.Result().Nodes {
+		t.Errorf("Expected quiescence nodes to be counted, got %d", res.Nodes)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected quiescence without noisy moves to panic")
+		}
+	}()
+	Make(&state, nimTerminal, nimUtility, nimSuccessors, true, limit, WithHeuristic(nimHeuristic), WithQuiescence(1))
+}
@@ -0,0 +1,78 @@
+package minimax
+
+import "iter"
+
+// WithExplored keeps the tree explored by each search, for Explored to walk
+// once the search is over. The tree is held in memory for as long as the
+// result of its search is the latest.
+func WithExplored() Option {
+	return func(o *options) {
+		o.explored = true
+	}
+}
+
+// Visit is a node of the tree explored by a search
+type Visit[T comparable] struct {
+	State   *T
+	Depth   int   // Plies below the searched state
+	Value   int   // Value of the state for the AI, on the scale of Result.Value
+	Bound   Bound // How Value relates to the true value, BoundNone if a limit cut the search of the node short
+	Unknown bool  // Whether Value depends on branches cut off by the depth limit
+}
+
+// Explored returns an iterator over the nodes explored by the most recent
+// search, in pre-order, the children of a node in the order they were
+// searched. A state shared by several paths is visited once, below the first.
+// Nothing is visited unless WithExplored was given.
+func (m Minimax[T]) Explored() iter.Seq[Visit[T]] {
+	return func(yield func(Visit[T]) bool) {
+		root, ok := m.result.tree.(*node[T])
+		if !ok {
+			return
+		}
+		seen := make(map[*node[T]]bool)
+		var walk func(n *node[T]) bool
+		walk = func(n *node[T]) bool {
+			seen[n] = true
+			if !yield(Visit[T]{State: n.elem, Depth: n.depth - root.depth, Value: n.val, Bound: n.bound(), Unknown: n.unknown}) {
+				return false
+			}
+			for _, child := range n.children {
+				if !seen[child] && (child.searched || child.expanded) && !walk(child) {
+					return false
+				}
+			}
+			return true
+		}
+		walk(root)
+	}
+}
+
+// bound tells how the value of n relates to its true value
+func (n *node[T]) bound() Bound {
+	switch {
+	case !n.searched:
+		return BoundNone
+	case n.val <= n.lo:
+		return BoundUpper
+	case n.val >= n.hi:
+		return BoundLower
+	default:
+		return BoundExact
+	}
+}
+
+// PV returns an iterator over the principal variation from state: the best
+// move cached for it, then the best move cached for that move, and so on,
+// until a state without a cached move or one already visited
+func (m Minimax[T]) PV(state T) iter.Seq[*T] {
+	return func(yield func(*T) bool) {
+		seen := map[T]bool{state: true}
+		for move := m.moveMap[state]; move != nil && !seen[*move]; move = m.moveMap[*move] {
+			if !yield(move) {
+				return
+			}
+			seen[*move] = true
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package minimax
+
+import "testing"
+
+// TestExplored tests that the explored tree is walked in pre-order, once per node.
+func TestExplored(t *testing.T) {
+	state := nimState{stones: 9, aiTurn: true}
+	mm := Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithExplored())
+
+	var visits []Visit[nimState]
+	seen := make(map[nodeKey[nimState]]bool)
+	for v := range mm.Explored() {
+		key := nodeKey[nimState]{state: *v.State, depth: v.Depth}
+		if seen[key] {
+			t.Errorf("Visited %+v twice", key)
+		}
+		seen[key] = true
+		if len(visits) > 0 && v.Depth > visits[len(visits)-1].Depth+1 {
+			t.Errorf("Expected pre-order, got depth %d after %d", v.Depth, visits[len(visits)-1].Depth)
+		}
+		visits = append(visits, v)
+	}
+
+	if len(visits) < 10 || len(visits) > mm.Result().Nodes {
+		t.Errorf("Expected between 10 and %d nodes, got %d", mm.Result().Nodes, len(visits))
+	}
+	if root := visits[0]; *root.State != state || root.Depth != 0 || root.Value != mm.Result().Value || root.Bound != BoundExact {
+		t.Errorf("Expected the root first, got %+v", root)
+	}
+
+	// Breaking out stops the walk
+	n := 0
+	for range mm.Explored() {
+		if n++; n == 3 {
+			break
+		}
+	}
+	if n != 3 {
+		t.Errorf("Expected to stop after 3 nodes, got %d", n)
+	}
+
+	// Nothing is kept without WithExplored
+	plain := Make(&state, nimTerminal, nimUtility, nimSuccessors, true)
+	for v := range plain.Explored() {
+		t.Errorf("Expected no nodes, got %+v", v)
+	}
+}
+
+// TestPV tests that the principal variation follows the cached best moves to the end of the game.
+func TestPV(t *testing.T) {
+	state := nimState{stones: 10, aiTurn: true}
+	mm := Make(&state, nimTerminal, nimUtility, nimSuccessors, true)
+
+	var pv []*nimState
+	for move := range mm.PV(state) {
+		pv = append(pv, move)
+	}
+	if len(pv) == 0 || pv[0] != mm.Solve(state) || pv[len(pv)-1].stones != 0 {
+		t.Fatalf("Expected a line from the best move to the end of the game, got %v", pv)
+	}
+	plies, _ := Decided(mm.Result().Value)
+	if len(pv) != plies {
+		t.Errorf("Expected %d plies, got %d", plies, len(pv))
+	}
+	for i := 1; i < len(pv); i++ {
+		if pv[i].aiTurn == pv[i-1].aiTurn {
+			t.Errorf("Expected the sides to alternate, got %v after %v", pv[i], pv[i-1])
+		}
+	}
+}
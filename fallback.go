@@ -0,0 +1,68 @@
+package minimax
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// WithFallback makes the search fail safe, for servers that must answer with
+// a move whatever happens: if a game function or option panics while
+// searching, or a hard limit stops the search before any move is evaluated,
+// the move picked by policy is played instead, such as the first legal move.
+// Result.Fallback tells when it was, and Result.Err holds a *PanicError if the
+// search panicked. The fallback move is cached like any other.
+//
+// Panics are recovered from every goroutine of the search, including those of
+// WithParallel and WithParallelExpansion, and abort the search with
+// StopPanic. policy itself must not fail.
+func WithFallback[T comparable](policy func(*T) *T) Option {
+	return func(o *options) {
+		o.fallback = policy
+	}
+}
+
+// PanicError reports a panic recovered by WithFallback
+type PanicError struct {
+	Value any    // Value passed to panic
+	Stack []byte // Stack of the goroutine that panicked
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("minimax: search panicked: %v", e.Value)
+}
+
+// catch recovers a panic of the search, aborting it, if WithFallback was
+// given. It must be deferred directly.
+func (s *search[T]) catch() {
+	if s.fallback == nil {
+		return
+	}
+	if p := recover(); p != nil {
+		s.failed.CompareAndSwap(nil, &PanicError{Value: p, Stack: debug.Stack()})
+		s.halt(StopPanic)
+	}
+}
+
+// recoverBuild turns a panic of build into a result with no move, if
+// WithFallback was given. It must be deferred directly.
+func recoverBuild[T comparable](cf config[T], mm *Minimax[T]) {
+	if cf.fallback == nil {
+		return
+	}
+	if p := recover(); p != nil {
+		err := &PanicError{Value: p, Stack: debug.Stack()}
+		*mm = Minimax[T]{moveMap: make(map[T]*T), config: cf, result: &Result{Stopped: StopPanic, Err: err}}
+	}
+}
+
+// fallBack plays the fallback policy's move from state if the search panicked
+// or found no move
+func (m Minimax[T]) fallBack(state *T) {
+	if m.config.fallback == nil || m.result.Stopped != StopPanic && m.moveMap[*state] != nil || m.config.isTerminal(state) {
+		return
+	}
+	if move := m.config.fallback(state); move != nil {
+		m.moveMap[*m.config.copyOf(state)] = move
+		m.result.Fallback = true
+	}
+}
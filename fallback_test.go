@@ -0,0 +1,68 @@
+package minimax
+
+import (
+	"errors"
+	"testing"
+)
+
+// firstMove is a fallback policy playing the first legal move
+func firstMove(s *nimState) *nimState {
+	return nimSuccessors(s)[0]
+}
+
+// TestFallbackPanic tests that a panicking game function yields the fallback move and an error.
+func TestFallbackPanic(t *testing.T) {
+	buggy := func(s *nimState) []*nimState {
+		if s.stones == 5 {
+			panic("no moves from 5")
+		}
+		return nimSuccessors(s)
+	}
+	for _, opts := range [][]Option{
+		{},
+		{WithParallel(4)},
+		{WithParallelExpansion(2)},
+	} {
+		state := nimState{stones: 12, aiTurn: true}
+		mm := Make(&state, nimTerminal, nimUtility, buggy, true, append(opts, WithFallback(firstMove))...)
+		move := mm.Solve(state)
+		res := mm.Result()
+
+		var pe *PanicError
+		if !errors.As(res.Err, &pe) || pe.Value != "no moves from 5" || len(pe.Stack) == 0 {
+			t.Errorf("%d options: expected a panic error, got %v", len(opts), res.Err)
+		}
+		if move == nil || *move != (nimState{stones: 11}) || !res.Fallback || res.Stopped != StopPanic {
+			t.Errorf("%d options: expected the fallback move, got %v with %+v", len(opts), move, res)
+		}
+	}
+}
+
+// TestFallbackNoMove tests that the fallback move is played when a limit stops the search before any move.
+func TestFallbackNoMove(t *testing.T) {
+	state := nimState{stones: 12, aiTurn: true}
+	mm := Make(&state, nimTerminal, nimUtility, nimSuccessors, true,
+		WithLimits(Limits{MaxNodes: 1}), WithFallback(firstMove))
+	move := mm.Solve(state)
+	if res := mm.Result(); move == nil || !res.Fallback || res.Err != nil || res.Stopped != StopNodes {
+		t.Errorf("Expected the fallback move, got %v with %+v", move, res)
+	}
+
+	// A search that finds a move does not fall back
+	state = nimState{stones: 14, aiTurn: true}
+	mm = Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithFallback(firstMove))
+	if move := mm.Solve(state); *move != (nimState{stones: 12}) || mm.Result().Fallback {
+		t.Errorf("Expected the searched move, got %v with %+v", move, mm.Result())
+	}
+}
+
+// TestFallbackDisabled tests that panics reach the caller without WithFallback.
+func TestFallbackDisabled(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic")
+		}
+	}()
+	state := nimState{stones: 3, aiTurn: true}
+	Make(&state, nimTerminal, func(*nimState) int { panic("utility") }, nimSuccessors, true)
+}
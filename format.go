@@ -0,0 +1,12 @@
+package minimax
+
+// WithFormat writes states with format wherever the search describes them,
+// so that diagnostics show readable positions rather than struct dumps: in
+// the State of trace and observer events, and so in the errors of replayed
+// traces and in the viz viewer. Game.Format does the same for Validate and
+// Game.Make.
+func WithFormat[T comparable](format func(*T) string) Option {
+	return func(o *options) {
+		o.format = format
+	}
+}
@@ -0,0 +1,66 @@
+package minimax
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// nimFormat writes nim states as people would
+func nimFormat(s *nimState) string {
+	side := "opponent"
+	if s.aiTurn {
+		side = "AI"
+	}
+	return fmt.Sprintf("%d stones, %s to move", s.stones, side)
+}
+
+// TestFormatTrace tests that traces show formatted states, which replays ignore.
+func TestFormatTrace(t *testing.T) {
+	state := nimState{stones: 7, aiTurn: true}
+	var trace bytes.Buffer
+	Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithTrace(&trace), WithFormat(nimFormat))
+
+	first, _, _ := strings.Cut(trace.String(), "\n")
+	if !strings.Contains(first, `"state":"7 stones, AI to move"`) {
+		t.Errorf("Expected the root state in the first event, got %s", first)
+	}
+
+	replay := Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithReplay(bytes.NewReader(trace.Bytes())))
+	if err := replay.Result().Err; err != nil {
+		t.Errorf("Expected a faithful replay without a format, got %v", err)
+	}
+
+	// Divergences show the states
+	replay = Make(&state, nimTerminal, nimUtility, nimSuccessors, false,
+		WithReplay(bytes.NewReader(trace.Bytes())), WithFormat(nimFormat))
+	var div *DivergenceError
+	if !errors.As(replay.Result().Err, &div) || !strings.Contains(div.Error(), ` stones, `) {
+		t.Errorf("Expected a divergence showing the state, got %v", replay.Result().Err)
+	}
+}
+
+// TestFormatGame tests that Game.Format reaches validation errors and searches.
+func TestFormatGame(t *testing.T) {
+	game := Game[nimState]{
+		IsTerminal: nimTerminal,
+		Utility:    func(*nimState) int { return 5 }, // Out of range
+		Successors: nimSuccessors,
+		Format:     nimFormat,
+	}
+	err := Validate(game, []nimState{{stones: 0, aiTurn: true}})
+	if err == nil || !strings.Contains(err.Error(), "at state 0 stones, AI to move:") {
+		t.Errorf("Expected the formatted state in the error, got %v", err)
+	}
+
+	game.Utility = nimUtility
+	var states []string
+	game.Make(&nimState{stones: 2, aiTurn: true}, true, WithObserver(func(ev Event, _ *nimState) {
+		states = append(states, ev.State)
+	}))
+	if len(states) == 0 || states[0] != "2 stones, AI to move" {
+		t.Errorf("Expected formatted states in events, got %q", states)
+	}
+}
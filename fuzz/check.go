@@ -0,0 +1,153 @@
+package fuzz
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+// win is the value of a win at the root in BruteForce. It differs from the
+// engine's, so values are compared by what they mean, as describe tells.
+const win = minimax.MaxHeuristic + 1<<20
+
+// BruteForce returns the minimax value of s, reached at the given depth, by
+// searching every branch. Below limit plies (0 for no limit), nodes are
+// scored by the tree's heuristic, as the engine does with WithHeuristic.
+// Proven wins count down from a constant of its own by depth, so that faster
+// wins are preferred as in the engine.
+func (t *Tree) BruteForce(s State, isMax bool, depth, limit int) int {
+	n := t.nodes[s]
+	switch {
+	case len(n.children) == 0:
+		switch {
+		case n.utility > 0:
+			return win - depth
+		case n.utility < 0:
+			return depth - win
+		default:
+			return 0
+		}
+	case limit > 0 && depth >= limit:
+		return max(-minimax.MaxHeuristic, min(minimax.MaxHeuristic, n.heuristic))
+	}
+
+	best := t.BruteForce(n.children[0], !isMax, depth+1, limit)
+	for _, c := range n.children[1:] {
+		v := t.BruteForce(c, !isMax, depth+1, limit)
+		if isMax && v > best || !isMax && v < best {
+			best = v
+		}
+	}
+	return best
+}
+
+// describe tells what a value of BruteForce means
+func describe(v int) string {
+	switch {
+	case v > minimax.MaxHeuristic:
+		return fmt.Sprintf("win in %d", win-v)
+	case v < -minimax.MaxHeuristic:
+		return fmt.Sprintf("loss in %d", win+v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// describeResult tells what a value of Result.Value means
+func describeResult(v int) string {
+	plies, ok := minimax.Decided(v)
+	switch {
+	case ok && v > 0:
+		return fmt.Sprintf("win in %d", plies)
+	case ok:
+		return fmt.Sprintf("loss in %d", plies)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Engines returns the engine configurations checked by default, each using
+// only features that must not change the value of a search. Features such
+// as tables and histories are new for every call, so call it once per Check.
+func Engines(t *Tree) []minimax.Entrant {
+	countermove := func(from, to *State) State { return *to }
+	clone := func(s *State) *State {
+		c := *s
+		return &c
+	}
+	return []minimax.Entrant{
+		{Name: "plain"},
+		{Name: "parallel", Options: []minimax.Option{minimax.WithParallel(4)}},
+		{Name: "table", Options: []minimax.Option{minimax.WithTable(minimax.NewTable(1<<16), t.Hash)}},
+		{Name: "etc", Options: []minimax.Option{minimax.WithTranspositionCutoffs()}},
+		{Name: "iid", Options: []minimax.Option{minimax.WithInternalDeepening(1)}},
+		{Name: "history", Options: []minimax.Option{minimax.WithHistory(minimax.NewHistory[State]())}},
+		{Name: "countermoves", Options: []minimax.Option{minimax.WithCountermoves(minimax.NewCountermoves(countermove))}},
+		{Name: "expansion", Options: []minimax.Option{minimax.WithParallelExpansion(2)}},
+		{Name: "successor cache", Options: []minimax.Option{minimax.WithSuccessorCache(minimax.NewSuccessorCache[State](64))}},
+		{Name: "memory bound", Options: []minimax.Option{minimax.WithMemoryBound(16)}},
+		{Name: "interner", Options: []minimax.Option{minimax.WithInterner(minimax.NewInterner[State]())}},
+		{Name: "alias copy", Options: []minimax.Option{minimax.WithAliasing(minimax.AliasCopy), minimax.WithClone(clone)}},
+		{Name: "interned clones", Options: []minimax.Option{minimax.WithInterner(minimax.NewInterner[State]()), minimax.WithClone(clone)}},
+		{Name: "combined", Options: []minimax.Option{
+			minimax.WithParallel(4),
+			minimax.WithTable(minimax.NewTable(1<<16), t.Hash),
+			minimax.WithTranspositionCutoffs(),
+			minimax.WithHistory(minimax.NewHistory[State]()),
+		}},
+	}
+}
+
+// Check searches the root of t with every engine, with isMax true if the AI
+// moves first and a depth limit of limit plies (0 for none), and returns an
+// error describing the first engine whose value differs from BruteForce or
+// whose move is not among the best.
+func Check(t *Tree, isMax bool, limit int, engines []minimax.Entrant) error {
+	g := t.Game()
+	want := t.BruteForce(t.Root(), isMax, 0, limit)
+	base := []minimax.Option{minimax.WithLimits(minimax.Limits{MaxDepth: limit}), minimax.WithHeuristic(t.Heuristic)}
+
+	for _, e := range engines {
+		root := t.Root()
+		mm := g.Make(&root, isMax, append(base, e.Options...)...)
+		if got := mm.Result().Value; describeResult(got) != describe(want) {
+			return fmt.Errorf("%s: value %s (%d), want %s", e.Name, describeResult(got), got, describe(want))
+		}
+
+		move := mm.Solve(root)
+		if len(t.nodes[root].children) == 0 {
+			continue
+		}
+		if move == nil {
+			return fmt.Errorf("%s: no move", e.Name)
+		}
+		if v := t.BruteForce(*move, !isMax, 1, limit); v != want {
+			return fmt.Errorf("%s: move %d is worth %s, want %s", e.Name, *move, describe(v), describe(want))
+		}
+	}
+	return nil
+}
+
+// Run checks trees generated from cfg with the default engines, and is meant
+// to be called from a native fuzz test, which feeds it seeds:
+//
+//	func FuzzEngines(f *testing.F) {
+//		f.Add(int64(1))
+//		f.Fuzz(func(t *testing.T, seed int64) {
+//			fuzz.Run(t, fuzz.Config{Transpose: 0.3}, seed)
+//		})
+//	}
+//
+// It checks both sides moving first, with and without a depth limit.
+func Run(t testing.TB, cfg Config, seed int64) {
+	t.Helper()
+	tree := Generate(cfg, seed)
+	for _, isMax := range []bool{true, false} {
+		for _, limit := range []int{0, 3} {
+			if err := Check(tree, isMax, limit, Engines(tree)); err != nil {
+				t.Fatalf("seed %d, isMax %v, limit %d: %v\n%v", seed, isMax, limit, err, tree)
+			}
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Package fuzz generates random finite game trees and checks that the
+// engine's pruning, parallel and caching features all agree with a
+// brute-force minimax on them. It is the safety net for new pruning
+// features: add the feature to the engines checked and run
+//
+//	for seed := range int64(500) {
+//		tree := fuzz.Generate(fuzz.Config{}, seed)
+//		if err := fuzz.Check(tree, true, 0, fuzz.Engines(tree)); err != nil {
+//			t.Fatalf("seed %d: %v\n%v", seed, err, tree)
+//		}
+//	}
+//
+// or use the seed as the input of a native Go fuzz test, as Run does. Trees
+// are reproducible from their seed, so a failure can be replayed and printed.
+package fuzz
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+// Config shapes the generated trees. The zero value gives small trees of up
+// to a few thousand nodes with every outcome equally likely.
+type Config struct {
+	MinBranching, MaxBranching int     // Children per interior node; 1 and 4 if zero
+	Depth                      int     // Plies below the root, where every node is a leaf; 6 if zero
+	Leaf                       float64 // Chance of an interior node below the root being a leaf instead
+	Transpose                  float64 // Chance of a child being shared with another node of the same depth
+	Wins, Draws, Losses        float64 // Relative odds of leaf utilities for the AI; equal if all zero
+	Heuristic                  int     // Heuristic values are drawn from [-Heuristic, Heuristic]; 1000 if zero
+}
+
+// State is a node of a generated tree, by index
+type State int32
+
+// node is a node of a generated tree
+type node struct {
+	children  []State
+	utility   int // Outcome for the AI if a leaf
+	heuristic int // Estimate of the value for the AI
+}
+
+// Tree is a generated game tree, which may share nodes between parents of
+// the same depth. It is read-only once generated.
+type Tree struct {
+	Seed  int64
+	nodes []node
+}
+
+// Generate returns a tree shaped by cfg, drawn from seed
+func Generate(cfg Config, seed int64) *Tree {
+	cfg = cfg.defaults()
+	r := rand.New(rand.NewSource(seed))
+	t := &Tree{Seed: seed}
+	t.nodes = append(t.nodes, node{heuristic: cfg.heuristic(r)})
+
+	level := []State{0}
+	for depth := 0; depth < cfg.Depth && len(level) > 0; depth++ {
+		var next []State
+		for _, s := range level {
+			if depth > 0 && r.Float64() < cfg.Leaf {
+				continue
+			}
+			b := cfg.MinBranching + r.Intn(cfg.MaxBranching-cfg.MinBranching+1)
+			for range b {
+				if len(next) > 0 && r.Float64() < cfg.Transpose {
+					t.nodes[s].children = append(t.nodes[s].children, next[r.Intn(len(next))])
+					continue
+				}
+				child := State(len(t.nodes))
+				t.nodes = append(t.nodes, node{heuristic: cfg.heuristic(r)})
+				t.nodes[s].children = append(t.nodes[s].children, child)
+				next = append(next, child)
+			}
+		}
+		level = next
+	}
+
+	for i := range t.nodes {
+		if len(t.nodes[i].children) == 0 {
+			t.nodes[i].utility = cfg.outcome(r)
+		}
+	}
+	return t
+}
+
+// defaults fills in the zero fields of cfg
+func (cfg Config) defaults() Config {
+	if cfg.MinBranching <= 0 {
+		cfg.MinBranching = 1
+	}
+	if cfg.MaxBranching <= 0 {
+		cfg.MaxBranching = 4
+	}
+	cfg.MaxBranching = max(cfg.MaxBranching, cfg.MinBranching)
+	if cfg.Depth <= 0 {
+		cfg.Depth = 6
+	}
+	if cfg.Wins <= 0 && cfg.Draws <= 0 && cfg.Losses <= 0 {
+		cfg.Wins, cfg.Draws, cfg.Losses = 1, 1, 1
+	}
+	if cfg.Heuristic <= 0 {
+		cfg.Heuristic = 1000
+	}
+	return cfg
+}
+
+// outcome draws a leaf utility
+func (cfg Config) outcome(r *rand.Rand) int {
+	x := r.Float64() * (cfg.Wins + cfg.Draws + cfg.Losses)
+	switch {
+	case x < cfg.Wins:
+		return 1
+	case x < cfg.Wins+cfg.Draws:
+		return 0
+	default:
+		return -1
+	}
+}
+
+// heuristic draws a heuristic value
+func (cfg Config) heuristic(r *rand.Rand) int {
+	return r.Intn(2*cfg.Heuristic+1) - cfg.Heuristic
+}
+
+// Root returns the root of the tree
+func (t *Tree) Root() State {
+	return 0
+}
+
+// Len returns the number of nodes of the tree
+func (t *Tree) Len() int {
+	return len(t.nodes)
+}
+
+// Game returns the functions Make needs to search the tree
+func (t *Tree) Game() minimax.Game[State] {
+	return minimax.Game[State]{
+		IsTerminal: func(s *State) bool { return len(t.nodes[*s].children) == 0 },
+		Utility:    func(s *State) int { return t.nodes[*s].utility },
+		Successors: func(s *State) []*State {
+			succ := make([]*State, len(t.nodes[*s].children))
+			for i, c := range t.nodes[*s].children {
+				succ[i] = &c
+			}
+			return succ
+		},
+	}
+}
+
+// Heuristic returns the random heuristic value of a state
+func (t *Tree) Heuristic(s *State) int {
+	return t.nodes[*s].heuristic
+}
+
+// Hash returns a hash of a state, for transposition tables
+func (t *Tree) Hash(s *State) uint64 {
+	x := uint64(*s) + uint64(t.Seed)<<32 + 0x9e3779b97f4a7c15
+	x = (x ^ x>>30) * 0xbf58476d1ce4e5b9
+	x = (x ^ x>>27) * 0x94d049bb133111eb
+	return x ^ x>>31
+}
+
+// String draws the tree, a node per line indented by depth, for failure reports
+func (t *Tree) String() string {
+	var sb strings.Builder
+	var draw func(s State, depth int)
+	draw = func(s State, depth int) {
+		n := t.nodes[s]
+		fmt.Fprintf(&sb, "%s%d", strings.Repeat("  ", depth), s)
+		if len(n.children) == 0 {
+			fmt.Fprintf(&sb, " utility %d", n.utility)
+		}
+		fmt.Fprintf(&sb, " heuristic %d\n", n.heuristic)
+		for _, c := range n.children {
+			draw(c, depth+1)
+		}
+	}
+	draw(t.Root(), 0)
+	return sb.String()
+}
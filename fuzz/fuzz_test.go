@@ -0,0 +1,57 @@
+package fuzz
+
+import (
+	"testing"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+// TestGenerate tests that trees are reproducible and shaped by the configuration.
+func TestGenerate(t *testing.T) {
+	a, b := Generate(Config{Transpose: 0.3}, 7), Generate(Config{Transpose: 0.3}, 7)
+	if a.String() != b.String() {
+		t.Error("Expected the same seed to give the same tree")
+	}
+
+	chain := Generate(Config{MinBranching: 1, MaxBranching: 1, Depth: 5, Wins: 1}, 1)
+	if chain.Len() != 6 || chain.BruteForce(chain.Root(), true, 0, 0) != win-5 {
+		t.Errorf("Expected a chain of 6 nodes ending in a win, got:\n%v", chain)
+	}
+	if got := describe(chain.BruteForce(chain.Root(), true, 0, 0)); got != "win in 5" {
+		t.Errorf("Expected a win in 5, got %s", got)
+	}
+	if got := chain.BruteForce(chain.Root(), true, 0, 2); got != max(-minimax.MaxHeuristic, min(minimax.MaxHeuristic, chain.Heuristic(ptr(State(2))))) {
+		t.Errorf("Expected the heuristic value of the node at the limit, got %d", got)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// TestEngines checks every default engine against brute force on many trees.
+func TestEngines(t *testing.T) {
+	seeds := int64(200)
+	if testing.Short() {
+		seeds = 20
+	}
+	for seed := range seeds {
+		Run(t, Config{Transpose: 0.3, Leaf: 0.1}, seed)
+	}
+}
+
+// TestCheckCatchesErrors tests that an engine giving wrong values is caught.
+func TestCheckCatchesErrors(t *testing.T) {
+	tree := Generate(Config{Depth: 4}, 3)
+	wrong := []minimax.Entrant{{Name: "pessimistic", Options: []minimax.Option{minimax.WithCutoff(minimax.CutoffPessimistic)}}}
+	if err := Check(tree, true, 2, wrong); err == nil {
+		t.Error("Expected scoring the frontier differently to be caught")
+	}
+}
+
+func FuzzEngines(f *testing.F) {
+	f.Add(int64(1))
+	f.Fuzz(func(t *testing.T, seed int64) {
+		Run(t, Config{Transpose: 0.3}, seed)
+	})
+}
@@ -0,0 +1,32 @@
+package minimax
+
+// Game bundles the functions that define a game, for utilities that work with
+// many positions or searches rather than a single call to Make
+type Game[T comparable] struct {
+	IsTerminal func(*T) bool // Returns true if the state is terminal
+	Utility    func(*T) int  // Returns -1, 0 or 1 for a loss, draw or win for the AI
+	Successors func(*T) []*T // Returns the states reachable in one move
+
+	// Optional, used by Validate
+	MinUtility, MaxUtility int           // Declared range of Utility, [-1, 1] if both are zero
+	ToMove                 func(*T) bool // Returns true if it is the AI's turn in the state
+
+	// Optional, writes states in diagnostics such as validation errors, as
+	// WithFormat does for searches made with Make
+	Format func(*T) string
+}
+
+// Position is a state together with whose turn it is
+type Position[T comparable] struct {
+	State T
+	IsMax bool // True if it is the AI's turn
+}
+
+// Make runs Make with the game's functions, and WithFormat if Format is set
+// and opts do not override it
+func (g Game[T]) Make(state *T, isMax bool, opts ...Option) Minimax[T] {
+	if g.Format != nil {
+		opts = append([]Option{WithFormat(g.Format)}, opts...)
+	}
+	return Make(state, g.IsTerminal, g.Utility, g.Successors, isMax, opts...)
+}
@@ -0,0 +1,212 @@
+// Package gomoku implements gomoku (five in a row) for the minimax package.
+// Its branching factor is far too large for a plain search, so moves are
+// generated from threats: only cells near stones are considered, they are
+// ordered by how much they build or block lines, forced moves (completing
+// five or blocking the opponent's four) are the only ones offered when they
+// exist, and the number of moves per position can be capped:
+//
+//	g := gomoku.New(15, gridgame.First)
+//	g.Width = 8
+//	start := g.Start()
+//	mm := g.Game().Make(&start, true,
+//		minimax.WithLimits(minimax.Limits{MaxDepth: 4}),
+//		minimax.WithHeuristic(g.Evaluate))
+package gomoku
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/abtsousa/minimax-go"
+	"github.com/abtsousa/minimax-go/gridgame"
+)
+
+// State is a position: the board and the player to move
+type State = gridgame.State
+
+// win is the number of stones in a row that wins
+const win = 5
+
+// lineWeights scores a line open to a player by how many of its stones it
+// holds: each stone makes the line roughly ten times more dangerous
+var lineWeights = [win + 1]int{0, 1, 10, 100, 1000, 100000}
+
+// Gomoku is a gomoku game on a square board
+type Gomoku struct {
+	Size   int            // Board size
+	AI     gridgame.Piece // Piece the AI plays
+	Radius int            // How far from existing stones moves are considered; 2 if zero
+	Width  int            // Maximum moves per position, best first; all if zero
+
+	lines     [][]int // Every line of five cells
+	cellLines [][]int // Indices of the lines through each cell
+}
+
+// New returns a game on a size by size board for the AI playing ai
+func New(size int, ai gridgame.Piece) *Gomoku {
+	g := &Gomoku{Size: size, AI: ai}
+	g.lines = gridgame.Lines(size, size, win)
+	g.cellLines = make([][]int, size*size)
+	for i, line := range g.lines {
+		for _, c := range line {
+			g.cellLines[c] = append(g.cellLines[c], i)
+		}
+	}
+	return g
+}
+
+// Start returns the empty board with the first player to move
+func (g *Gomoku) Start() State {
+	return State{Board: gridgame.NewBoard(g.Size, g.Size), Turn: gridgame.First}
+}
+
+// Game returns the functions Make needs
+func (g *Gomoku) Game() minimax.Game[State] {
+	return minimax.Game[State]{
+		IsTerminal: g.IsTerminal,
+		Utility:    g.Utility,
+		Successors: g.Successors,
+		ToMove:     func(s *State) bool { return s.Turn == g.AI },
+	}
+}
+
+// IsTerminal reports whether someone has five in a row or the board is full
+func (g *Gomoku) IsTerminal(s *State) bool {
+	return s.Board.Winner(g.lines) != gridgame.Empty || s.Board.Full()
+}
+
+// Utility returns 1 if the AI has five in a row, -1 if its opponent has, and 0 otherwise
+func (g *Gomoku) Utility(s *State) int {
+	switch s.Board.Winner(g.lines) {
+	case g.AI:
+		return 1
+	case gridgame.Empty:
+		return 0
+	default:
+		return -1
+	}
+}
+
+// Successors returns the states after each candidate move, in the order of Candidates
+func (g *Gomoku) Successors(s *State) []*State {
+	if g.IsTerminal(s) {
+		return nil
+	}
+	moves := g.Candidates(s)
+	succ := make([]*State, len(moves))
+	for i, c := range moves {
+		succ[i] = &State{Board: s.Board.Set(c, s.Turn), Turn: s.Turn.Opponent()}
+	}
+	return succ
+}
+
+// threat is a candidate move and how much it builds and blocks lines
+type threat struct {
+	cell           int
+	attack, defend int
+	wins, blocks   bool // Whether it completes five, or stops the opponent from doing so
+}
+
+// Candidates returns the cells worth playing for the player to move, most
+// threatening first. A move completing five is played alone; otherwise, if
+// the opponent has a four, only the moves blocking it are offered.
+func (g *Gomoku) Candidates(s *State) []int {
+	b := s.Board
+	if b.Count(gridgame.Empty) == b.Len() {
+		return []int{b.Index(g.Size/2, g.Size/2)}
+	}
+
+	var threats []threat
+	var wins, blocks bool
+	for c := range b.Len() {
+		if b.At(c) != gridgame.Empty || !g.near(b, c) {
+			continue
+		}
+		t := g.threat(b, c, s.Turn)
+		wins, blocks = wins || t.wins, blocks || t.blocks
+		threats = append(threats, t)
+	}
+
+	switch {
+	case wins:
+		threats = slices.DeleteFunc(threats, func(t threat) bool { return !t.wins })
+	case blocks:
+		threats = slices.DeleteFunc(threats, func(t threat) bool { return !t.blocks })
+	}
+	slices.SortStableFunc(threats, func(a, b threat) int {
+		return cmp.Compare(b.attack+b.defend, a.attack+a.defend)
+	})
+	if g.Width > 0 && len(threats) > g.Width {
+		threats = threats[:g.Width]
+	}
+
+	moves := make([]int, len(threats))
+	for i, t := range threats {
+		moves[i] = t.cell
+	}
+	return moves
+}
+
+// near reports whether cell c is within Radius of a stone
+func (g *Gomoku) near(b gridgame.Board, c int) bool {
+	r := g.Radius
+	if r <= 0 {
+		r = 2
+	}
+	x0, y0 := b.XY(c)
+	for y := y0 - r; y <= y0+r; y++ {
+		for x := x0 - r; x <= x0+r; x++ {
+			if b.Inside(x, y) && b.At(b.Index(x, y)) != gridgame.Empty {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// threat scores p playing on the empty cell c
+func (g *Gomoku) threat(b gridgame.Board, c int, p gridgame.Piece) threat {
+	t := threat{cell: c}
+	for _, li := range g.cellLines[c] {
+		mine, theirs := g.count(b, g.lines[li], p)
+		switch {
+		case theirs == 0:
+			t.attack += lineWeights[mine+1]
+			t.wins = t.wins || mine == win-1
+		case mine == 0:
+			t.defend += lineWeights[theirs]
+			t.blocks = t.blocks || theirs == win-1
+		}
+	}
+	return t
+}
+
+// count returns the stones of p and of its opponent on a line
+func (g *Gomoku) count(b gridgame.Board, line []int, p gridgame.Piece) (mine, theirs int) {
+	for _, c := range line {
+		switch b.At(c) {
+		case p:
+			mine++
+		case p.Opponent():
+			theirs++
+		}
+	}
+	return mine, theirs
+}
+
+// Evaluate estimates a position for the AI, to pass to WithHeuristic: the
+// weight of the lines still open to the AI minus those open to its opponent,
+// within [-MaxHeuristic, MaxHeuristic]
+func (g *Gomoku) Evaluate(s *State) int {
+	score := 0
+	for _, line := range g.lines {
+		mine, theirs := g.count(s.Board, line, g.AI)
+		switch {
+		case theirs == 0:
+			score += lineWeights[mine]
+		case mine == 0:
+			score -= lineWeights[theirs]
+		}
+	}
+	return max(-minimax.MaxHeuristic, min(minimax.MaxHeuristic, score))
+}
@@ -0,0 +1,84 @@
+package gomoku
+
+import (
+	"testing"
+
+	"github.com/abtsousa/minimax-go"
+	"github.com/abtsousa/minimax-go/gridgame"
+)
+
+// parse reads a position with X to move, failing the test if it is malformed
+func parse(t *testing.T, board string) State {
+	t.Helper()
+	b, ok := gridgame.Parse(board, "")
+	if !ok {
+		t.Fatal("Malformed board")
+	}
+	return State{Board: b, Turn: gridgame.First}
+}
+
+// TestForcedMoves tests that wins and blocks are the only moves offered when they exist.
+func TestForcedMoves(t *testing.T) {
+	g := New(9, gridgame.First)
+
+	s := parse(t, `
+		.........
+		.XXXX....
+		.........
+		..OOOO...
+		.........
+		.........
+		.........
+		.........
+		.........`)
+	moves := g.Candidates(&s)
+	if len(moves) != 2 || moves[0] != s.Board.Index(0, 1) && moves[0] != s.Board.Index(5, 1) {
+		t.Errorf("Expected only the two winning moves, got %v", moves)
+	}
+
+	s = parse(t, `
+		.........
+		.XX......
+		.........
+		..OOOO...
+		.........
+		.........
+		.........
+		.........
+		.........`)
+	moves = g.Candidates(&s)
+	if len(moves) != 2 {
+		t.Errorf("Expected only the two blocks, got %v", moves)
+	}
+	if len(g.Candidates(&State{Board: gridgame.NewBoard(9, 9)})) != 1 {
+		t.Error("Expected a single opening move in the center")
+	}
+}
+
+// TestSearch tests a narrow depth-limited search finding a win in three moves.
+func TestSearch(t *testing.T) {
+	g := New(9, gridgame.First)
+	g.Width = 6
+
+	// An open three becomes an open four, which cannot be stopped
+	s := parse(t, `
+		.........
+		.........
+		.........
+		...XXX...
+		.........
+		..O.O....
+		...O.....
+		.........
+		.........`)
+	mm := g.Game().Make(&s, true,
+		minimax.WithLimits(minimax.Limits{MaxDepth: 3}),
+		minimax.WithHeuristic(g.Evaluate))
+	move := mm.Solve(s)
+	if move == nil {
+		t.Fatal("Expected a move")
+	}
+	if res := mm.Result(); res.Value < minimax.MaxHeuristic || res.Unknown {
+		t.Errorf("Expected a proven win, got %+v after\n%v", res, move.Board)
+	}
+}
@@ -0,0 +1,97 @@
+package othello
+
+import (
+	"github.com/abtsousa/minimax-go"
+	"github.com/abtsousa/minimax-go/gridgame"
+)
+
+// weights8 is the classic positional table for the 8x8 board: corners are
+// worth the most, the cells next to them give corners away, and edges are good
+var weights8 = [8][8]int{
+	{100, -20, 10, 5, 5, 10, -20, 100},
+	{-20, -50, -2, -2, -2, -2, -50, -20},
+	{10, -2, 1, 1, 1, 1, -2, 10},
+	{5, -2, 1, 0, 0, 1, -2, 5},
+	{5, -2, 1, 0, 0, 1, -2, 5},
+	{10, -2, 1, 1, 1, 1, -2, 10},
+	{-20, -50, -2, -2, -2, -2, -50, -20},
+	{100, -20, 10, 5, 5, 10, -20, 100},
+}
+
+// Weights for the terms of the evaluation
+const (
+	positionWeight = 10 // Per point of the positional table
+	mobilityWeight = 60 // Per move more than the opponent
+	discWeight     = 5  // Per disc more than the opponent, only late in the game
+)
+
+// weight returns the positional value of cell (x, y) on a size by size board,
+// by mapping it onto the 8x8 table from the nearest corner
+func weight(x, y, size int) int {
+	fold := func(v int) int {
+		if v >= size/2 {
+			v = size - 1 - v
+		}
+		if v >= 4 {
+			return 3
+		}
+		return v
+	}
+	return weights8[fold(y)][fold(x)]
+}
+
+// Evaluate returns the evaluation to pass to WithHeuristic for the AI playing
+// ai. It combines the positional value of the discs, mobility, and, once the
+// board is mostly full, the disc count, and it stays within MaxHeuristic.
+func Evaluate(ai gridgame.Piece) func(*State) int {
+	return Evaluator{AI: ai}.Evaluate
+}
+
+// Evaluator is Evaluate as a minimax.NoisyEvaluator, to pass to WithEvaluator.
+// Its noisy moves are those taking a corner, which swing the positional value
+// the most, so that WithQuiescence settles fights for the corners that the
+// depth limit would cut in the middle.
+type Evaluator struct {
+	AI gridgame.Piece // Player the evaluation is for
+}
+
+// Evaluate returns the evaluation of s for e.AI
+func (e Evaluator) Evaluate(s *State) int {
+	ai, b := e.AI, s.Board
+	score := 0
+	for i := range b.Len() {
+		x, y := b.XY(i)
+		switch b.At(i) {
+		case ai:
+			score += positionWeight * weight(x, y, b.Width())
+		case ai.Opponent():
+			score -= positionWeight * weight(x, y, b.Width())
+		}
+	}
+
+	score += mobilityWeight * (len(Moves(b, ai)) - len(Moves(b, ai.Opponent())))
+	if empty := b.Count(gridgame.Empty); empty < b.Len()/4 {
+		score += discWeight * (b.Count(ai) - b.Count(ai.Opponent()))
+	}
+	return max(-minimax.MaxHeuristic, min(minimax.MaxHeuristic, score))
+}
+
+// Noisy returns the states after the player to move takes a corner
+func (e Evaluator) Noisy(s *State) []*State {
+	b := s.Board
+	last := b.Width() - 1
+	var succ []*State
+	for _, cell := range [4]int{b.Index(0, 0), b.Index(last, 0), b.Index(0, last), b.Index(last, last)} {
+		if len(flips(b, cell, s.Turn)) > 0 {
+			next := Play(*s, cell)
+			succ = append(succ, &next)
+		}
+	}
+	return succ
+}
+
+// ProbCut is a ProbCut model for the 8x8 board with Evaluate, pruning from a
+// 2-ply search at 4 plies from the depth limit. It was fitted by regressing
+// the 4-ply values of 300 positions from random games on their 2-ply values,
+// and prunes at one standard deviation, where it starts saving nodes.
+var ProbCut = minimax.ProbCut{Depth: 4, Shallow: 2, A: 1.005, B: -10, Sigma: 164, T: 1}
@@ -0,0 +1,132 @@
+// Package othello implements Othello (Reversi) on square boards of even size
+// for the minimax package, with a positional evaluation for depth-limited
+// searches. It doubles as an example of a game too large to solve outright,
+// searched with quiescence on corner moves and ProbCut:
+//
+//	start := othello.Start(8)
+//	mm := minimax.Make(&start, othello.IsTerminal, othello.Utility(gridgame.First),
+//		othello.Successors, true,
+//		minimax.WithLimits(minimax.Limits{MaxDepth: 6}),
+//		minimax.WithEvaluator[othello.State](othello.Evaluator{AI: gridgame.First}),
+//		minimax.WithQuiescence(2),
+//		minimax.WithProbCut(othello.ProbCut))
+//	move := mm.Solve(start)
+//
+// Black is gridgame.First and moves first, White is gridgame.Second.
+package othello
+
+import (
+	"github.com/abtsousa/minimax-go"
+	"github.com/abtsousa/minimax-go/gridgame"
+)
+
+// Black and White are the pieces of the two players
+const (
+	Black = gridgame.First
+	White = gridgame.Second
+)
+
+// State is a position: the board and the player to move
+type State = gridgame.State
+
+// directions are the eight directions discs are flipped along
+var directions = [8][2]int{{-1, -1}, {0, -1}, {1, -1}, {-1, 0}, {1, 0}, {-1, 1}, {0, 1}, {1, 1}}
+
+// Start returns the opening position on a size by size board, with the four
+// central discs placed and Black to move
+func Start(size int) State {
+	b := gridgame.NewBoard(size, size)
+	c := size/2 - 1
+	b = b.SetAll(White, b.Index(c, c), b.Index(c+1, c+1))
+	b = b.SetAll(Black, b.Index(c+1, c), b.Index(c, c+1))
+	return State{Board: b, Turn: Black}
+}
+
+// flips returns the discs that p playing on cell would flip, none if the move is illegal
+func flips(b gridgame.Board, cell int, p gridgame.Piece) []int {
+	if b.At(cell) != gridgame.Empty {
+		return nil
+	}
+	x0, y0 := b.XY(cell)
+
+	var flipped []int
+	for _, d := range directions {
+		x, y := x0+d[0], y0+d[1]
+		var run []int
+		for b.Inside(x, y) && b.At(b.Index(x, y)) == p.Opponent() {
+			run = append(run, b.Index(x, y))
+			x, y = x+d[0], y+d[1]
+		}
+		if len(run) > 0 && b.Inside(x, y) && b.At(b.Index(x, y)) == p {
+			flipped = append(flipped, run...)
+		}
+	}
+	return flipped
+}
+
+// Moves returns the cells where p can play
+func Moves(b gridgame.Board, p gridgame.Piece) []int {
+	var moves []int
+	for i := range b.Len() {
+		if len(flips(b, i, p)) > 0 {
+			moves = append(moves, i)
+		}
+	}
+	return moves
+}
+
+// Play returns the state after the player to move plays on cell, which must be legal
+func Play(s State, cell int) State {
+	flipped := flips(s.Board, cell, s.Turn)
+	b := s.Board.SetAll(s.Turn, append(flipped, cell)...)
+	return State{Board: b, Turn: s.Turn.Opponent()}
+}
+
+// IsTerminal reports whether neither player can move
+func IsTerminal(s *State) bool {
+	return len(Moves(s.Board, Black)) == 0 && len(Moves(s.Board, White)) == 0
+}
+
+// Utility returns the outcome for ai of a finished game: whoever has more discs wins
+func Utility(ai gridgame.Piece) func(*State) int {
+	return func(s *State) int {
+		mine, theirs := s.Board.Count(ai), s.Board.Count(ai.Opponent())
+		switch {
+		case mine > theirs:
+			return 1
+		case mine < theirs:
+			return -1
+		default:
+			return 0
+		}
+	}
+}
+
+// Successors returns the states after each legal move. A player without
+// moves passes, which is a move to the same board with the other player to move.
+func Successors(s *State) []*State {
+	moves := Moves(s.Board, s.Turn)
+	if len(moves) == 0 {
+		if IsTerminal(s) {
+			return nil
+		}
+		return []*State{{Board: s.Board, Turn: s.Turn.Opponent()}}
+	}
+
+	succ := make([]*State, len(moves))
+	for i, cell := range moves {
+		next := Play(*s, cell)
+		succ[i] = &next
+	}
+	return succ
+}
+
+// Game returns the functions Make needs, for the AI playing ai
+func Game(ai gridgame.Piece) minimax.Game[State] {
+	return minimax.Game[State]{
+		IsTerminal: IsTerminal,
+		Utility:    Utility(ai),
+		Successors: Successors,
+		ToMove:     func(s *State) bool { return s.Turn == ai },
+	}
+}
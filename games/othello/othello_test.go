@@ -0,0 +1,114 @@
+package othello
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/abtsousa/minimax-go"
+	"github.com/abtsousa/minimax-go/gridgame"
+)
+
+// TestRules tests move generation, flipping and passing.
+func TestRules(t *testing.T) {
+	s := Start(8)
+	b := s.Board
+	moves := Moves(b, Black)
+	want := []int{b.Index(3, 2), b.Index(2, 3), b.Index(5, 4), b.Index(4, 5)}
+	slices.Sort(want)
+	if !slices.Equal(moves, want) {
+		t.Errorf("Expected opening moves %v, got %v", want, moves)
+	}
+
+	next := Play(s, b.Index(3, 2))
+	if next.Board.Count(Black) != 4 || next.Board.Count(White) != 1 || next.Turn != White {
+		t.Errorf("Expected a disc flipped and White to move, got\n%v", next.Board)
+	}
+
+	// White has no move and must pass
+	pass, _ := gridgame.Parse("XXX. XXXO XXXX XXXX", "")
+	ps := State{Board: pass, Turn: White}
+	succ := Successors(&ps)
+	if len(succ) != 1 || succ[0].Board != pass || succ[0].Turn != Black {
+		t.Errorf("Expected a single pass, got %v", succ)
+	}
+}
+
+// TestSolveSmall tests that 4x4 Othello is solved as a win for White.
+func TestSolveSmall(t *testing.T) {
+	s := Start(4)
+	mm := Game(White).Make(&s, false)
+	if res := mm.Result(); res.Value <= 0 || res.Stopped != minimax.StopNone {
+		t.Errorf("Expected a proven win for White, got %+v", res)
+	}
+}
+
+// TestDepthLimited tests a heuristic search on the full board.
+func TestDepthLimited(t *testing.T) {
+	// Black can take the top left corner
+	b, _ := gridgame.Parse(`
+		........
+		.O......
+		..O.....
+		...OX...
+		...XX...
+		........
+		.....O..
+		........`, "")
+	s := State{Board: b, Turn: Black}
+	mm := Game(Black).Make(&s, true,
+		minimax.WithLimits(minimax.Limits{MaxDepth: 3}),
+		minimax.WithHeuristic(Evaluate(Black)))
+
+	move := mm.Solve(s)
+	if move == nil || move.Board.At(0) != Black {
+		t.Errorf("Expected Black to take the corner, got\n%v", move)
+	}
+
+	// The opening is far from decided
+	start := Start(8)
+	mm = Game(Black).Make(&start, true,
+		minimax.WithLimits(minimax.Limits{MaxDepth: 4}),
+		minimax.WithHeuristic(Evaluate(Black)))
+	if res := mm.Result(); !res.Unknown || res.Stopped != minimax.StopDepth || mm.Solve(start) == nil {
+		t.Errorf("Expected a depth-limited heuristic value, got %+v", res)
+	}
+}
+
+// TestEvaluator tests the noisy moves of the evaluator and a search with
+// quiescence and ProbCut on the full board.
+func TestEvaluator(t *testing.T) {
+	b, _ := gridgame.Parse(`
+		........
+		.O......
+		..O.....
+		...OX...
+		...XX...
+		........
+		.....O..
+		........`, "")
+	s := State{Board: b, Turn: Black}
+	e := Evaluator{AI: Black}
+	if noisy := e.Noisy(&s); len(noisy) != 1 || noisy[0].Board.At(0) != Black {
+		t.Errorf("Expected taking the corner to be the only noisy move, got %v", noisy)
+	}
+	if e.Evaluate(&s) != Evaluate(Black)(&s) {
+		t.Error("Expected the evaluator to evaluate like Evaluate")
+	}
+
+	// White to move can take the corner the quiet evaluation is blind to
+	w := State{Board: b, Turn: White}
+	limit := minimax.WithLimits(minimax.Limits{MaxDepth: 1})
+	quiet := Game(Black).Make(&w, false, limit, minimax.WithEvaluator[State](e))
+	deep := Game(Black).Make(&w, false, limit, minimax.WithEvaluator[State](e), minimax.WithQuiescence(2))
+	if deep.Result().Nodes <= quiet.Result().Nodes {
+		t.Errorf("Expected quiescence to search corner moves, got %+v and %+v without", deep.Result(), quiet.Result())
+	}
+
+	start := Start(8)
+	opts := []minimax.Option{minimax.WithLimits(minimax.Limits{MaxDepth: 7}), minimax.WithEvaluator[State](e)}
+	full := Game(Black).Make(&start, true, opts...)
+	cut := Game(Black).Make(&start, true, append(opts, minimax.WithProbCut(ProbCut))...)
+	if cut.Solve(start) == nil || cut.Result().Nodes >= full.Result().Nodes {
+		t.Errorf("Expected ProbCut to save nodes, got %d and %d without", cut.Result().Nodes, full.Result().Nodes)
+	}
+}
@@ -0,0 +1,257 @@
+package gdl
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+// State is a position of a game: the facts true in it, sorted and separated by spaces
+type State string
+
+// Facts returns the facts true in s
+func (s State) Facts() []string {
+	var facts []string
+	for _, f := range parseFacts(string(s)) {
+		facts = append(facts, f.String())
+	}
+	return facts
+}
+
+// Game is a compiled game description. It is read-only once loaded, so any
+// number of goroutines may use it at once.
+type Game struct {
+	rules map[string][]rule
+	roles []string
+}
+
+// Load compiles a game description. It fails if the description does not
+// parse, or lacks roles or initial facts.
+func Load(src string) (*Game, error) {
+	terms, err := parse(src)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &Game{rules: make(map[string][]rule)}
+	for _, t := range terms {
+		r := rule{head: t}
+		if t.name == "<=" {
+			if len(t.args) == 0 {
+				return nil, errors.New("gdl: rule without a head")
+			}
+			r = rule{head: t.args[0], body: t.args[1:]}
+		}
+		if r.head.isVar() {
+			return nil, fmt.Errorf("gdl: rule with the variable %s as its head", r.head)
+		}
+		g.rules[r.head.key()] = append(g.rules[r.head.key()], r)
+	}
+
+	for _, r := range g.prover("").ask(query("role", "?r")) {
+		g.roles = append(g.roles, r.args[0].String())
+	}
+	if len(g.roles) == 0 {
+		return nil, errors.New("gdl: no roles")
+	}
+	if g.Start() == "" {
+		return nil, errors.New("gdl: no initial facts")
+	}
+	return g, nil
+}
+
+// query builds the term (name args...), parsing each argument
+func query(name string, args ...string) *term {
+	t := &term{name: name, args: make([]*term, len(args))}
+	for i, a := range args {
+		t.args[i], _, _ = parseTerm(tokenize(a))
+	}
+	return t
+}
+
+// prover returns a prover for the state s
+func (g *Game) prover(s State, does ...*term) *prover {
+	return &prover{rules: g.rules, facts: parseFacts(string(s)), does: does}
+}
+
+// Roles returns the roles of the game, in the order they are declared
+func (g *Game) Roles() []string {
+	return slices.Clone(g.roles)
+}
+
+// Start returns the initial state
+func (g *Game) Start() State {
+	var facts []*term
+	for _, t := range g.prover("").ask(query("init", "?f")) {
+		facts = append(facts, t.args[0])
+	}
+	return state(facts)
+}
+
+// state makes a State of facts
+func state(facts []*term) State {
+	s := make([]string, len(facts))
+	for i, f := range facts {
+		s[i] = f.String()
+	}
+	slices.Sort(s)
+	return State(strings.Join(slices.Compact(s), " "))
+}
+
+// Legal returns the legal moves of role in s
+func (g *Game) Legal(s State, role string) []string {
+	var moves []string
+	for _, t := range g.legal(s, role) {
+		moves = append(moves, t.String())
+	}
+	return moves
+}
+
+// legal returns the legal moves of role in s as terms
+func (g *Game) legal(s State, role string) []*term {
+	var moves []*term
+	for _, t := range g.prover(s).ask(query("legal", role, "?m")) {
+		moves = append(moves, t.args[1])
+	}
+	return moves
+}
+
+// Next returns the state after every role plays its move in moves. It fails
+// if a move does not parse or a role has no move.
+func (g *Game) Next(s State, moves map[string]string) (State, error) {
+	does := make([]*term, len(g.roles))
+	for i, role := range g.roles {
+		move, ok := moves[role]
+		if !ok {
+			return "", fmt.Errorf("gdl: no move for %s", role)
+		}
+		terms, err := parse(move)
+		if err != nil || len(terms) != 1 {
+			return "", fmt.Errorf("gdl: invalid move %q", move)
+		}
+		does[i] = &term{name: "does", args: []*term{{name: role}, terms[0]}}
+	}
+	return g.next(s, does), nil
+}
+
+// next returns the state after the moves in does, given as (does r m)
+func (g *Game) next(s State, does []*term) State {
+	var facts []*term
+	for _, t := range g.prover(s, does...).ask(query("next", "?f")) {
+		facts = append(facts, t.args[0])
+	}
+	return state(facts)
+}
+
+// IsTerminal reports whether the game is over in s
+func (g *Game) IsTerminal(s State) bool {
+	return g.prover(s).provable(&term{name: "terminal"}, nil)
+}
+
+// Goal returns the goal value of role in s, and false if it has none
+func (g *Game) Goal(s State, role string) (int, bool) {
+	for _, t := range g.prover(s).ask(query("goal", role, "?n")) {
+		if v, err := strconv.Atoi(t.args[1].name); err == nil {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// joint returns every combination of legal moves of the roles in s, as (does r m) terms
+func (g *Game) joint(s State) [][]*term {
+	combos := [][]*term{nil}
+	for _, role := range g.roles {
+		var next [][]*term
+		for _, m := range g.legal(s, role) {
+			does := &term{name: "does", args: []*term{{name: role}, m}}
+			for _, c := range combos {
+				next = append(next, append(slices.Clip(c), does))
+			}
+		}
+		combos = next
+	}
+	return combos
+}
+
+// Move returns the move of every role that leads from one state to the
+// other, and false if no joint move does
+func (g *Game) Move(from, to State) (map[string]string, bool) {
+	for _, does := range g.joint(from) {
+		if g.next(from, does) == to {
+			moves := make(map[string]string, len(does))
+			for _, d := range does {
+				moves[d.args[0].name] = d.args[1].String()
+			}
+			return moves, true
+		}
+	}
+	return nil, false
+}
+
+// Minimax returns the functions the engine needs to play the game as ai.
+// Every joint move of the roles is a move of the engine, so the game should
+// be played in turns, with the roles not in control having a single legal
+// move such as noop. ai wins if its goal value is higher than that of every
+// other role, and loses if some role's is higher. In puzzles with a single
+// role, ai wins with a goal value above 50 and loses below.
+func (g *Game) Minimax(ai string) (minimax.Game[State], error) {
+	if !slices.Contains(g.roles, ai) {
+		return minimax.Game[State]{}, fmt.Errorf("gdl: unknown role %s", ai)
+	}
+	return minimax.Game[State]{
+		IsTerminal: func(s *State) bool { return g.IsTerminal(*s) },
+		Utility:    func(s *State) int { return g.utility(*s, ai) },
+		Successors: func(s *State) []*State {
+			var succ []*State
+			seen := make(map[State]bool)
+			for _, does := range g.joint(*s) {
+				if next := g.next(*s, does); !seen[next] {
+					seen[next] = true
+					succ = append(succ, &next)
+				}
+			}
+			return succ
+		},
+		ToMove: func(s *State) bool { return g.toMove(*s, ai) },
+	}, nil
+}
+
+// utility compares the goal value of ai in s to the best of the other roles
+func (g *Game) utility(s State, ai string) int {
+	mine, _ := g.Goal(s, ai)
+	best := -1
+	for _, role := range g.roles {
+		if role != ai {
+			theirs, _ := g.Goal(s, role)
+			best = max(best, theirs)
+		}
+	}
+	switch {
+	case best < 0: // Single role
+		return max(-1, min(1, mine-50))
+	case mine > best:
+		return 1
+	case mine < best:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// toMove reports whether ai is in control of s: it has a choice of moves, or no role has
+func (g *Game) toMove(s State, ai string) bool {
+	if len(g.legal(s, ai)) > 1 {
+		return true
+	}
+	for _, role := range g.roles {
+		if role != ai && len(g.legal(s, role)) > 1 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,116 @@
+package gdl
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+func loadTicTacToe(t *testing.T) *Game {
+	t.Helper()
+	src, err := os.ReadFile("testdata/tictactoe.gdl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := Load(string(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// TestRules tests the queries of a loaded description.
+func TestRules(t *testing.T) {
+	g := loadTicTacToe(t)
+	if !slices.Equal(g.Roles(), []string{"x", "o"}) {
+		t.Errorf("Expected roles x and o, got %v", g.Roles())
+	}
+	start := g.Start()
+	if len(start.Facts()) != 10 || !strings.Contains(string(start), "(control x)") {
+		t.Errorf("Unexpected start %q", start)
+	}
+	if moves := g.Legal(start, "x"); len(moves) != 9 || moves[0] != "(mark 1 1)" {
+		t.Errorf("Expected 9 marks for x, got %v", moves)
+	}
+	if moves := g.Legal(start, "o"); !slices.Equal(moves, []string{"noop"}) {
+		t.Errorf("Expected o to pass, got %v", moves)
+	}
+
+	s := start
+	for i, m := range []string{"(mark 1 1)", "(mark 2 1)", "(mark 1 2)", "(mark 2 2)", "(mark 1 3)"} {
+		moves := map[string]string{"x": "noop", "o": "noop"}
+		moves[g.Roles()[i%2]] = m
+		next, err := g.Next(s, moves)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back, ok := g.Move(s, next); !ok || back["x"] != moves["x"] || back["o"] != moves["o"] {
+			t.Errorf("Expected Move to recover %v, got %v", moves, back)
+		}
+		s = next
+	}
+	if !g.IsTerminal(s) {
+		t.Errorf("Expected a row of x to end the game: %q", s)
+	}
+	if v, ok := g.Goal(s, "o"); !ok || v != 0 {
+		t.Errorf("Expected o to score 0, got %d", v)
+	}
+	if _, err := g.Next(s, map[string]string{"x": "noop"}); err == nil {
+		t.Error("Expected an error for a missing move")
+	}
+}
+
+// TestMinimax tests that the engine plays a loaded description: tic-tac-toe is a draw.
+func TestMinimax(t *testing.T) {
+	g := loadTicTacToe(t)
+	mg, err := g.Minimax("o")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := g.Start()
+	if mg.ToMove(&start) {
+		t.Error("Expected x to move first")
+	}
+
+	mm := mg.Make(&start, false)
+	if res := mm.Result(); res.Value != 0 || res.Unknown {
+		t.Errorf("Expected a draw, got %+v", res)
+	}
+
+	// x threatens the top row, which o must block
+	s := start
+	for i, m := range []string{"(mark 1 1)", "(mark 2 2)", "(mark 1 2)"} {
+		moves := map[string]string{"x": "noop", "o": "noop"}
+		moves[g.Roles()[i%2]] = m
+		s, _ = g.Next(s, moves)
+	}
+	best := mg.Make(&s, true).Solve(s)
+	if moves, ok := g.Move(s, *best); !ok || moves["o"] != "(mark 1 3)" {
+		t.Errorf("Expected o to block at (1, 3), got %v", moves)
+	}
+
+	if _, err := g.Minimax("z"); err == nil {
+		t.Error("Expected an unknown role to fail")
+	}
+	if err := minimax.Validate(mg, []State{start, s}); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestLoadErrors tests that malformed descriptions are rejected.
+func TestLoadErrors(t *testing.T) {
+	for _, src := range []string{
+		"(role x",
+		")",
+		"(init (cell b))",
+		"(role x)",
+		"(<= ?x (role x))",
+	} {
+		if _, err := Load(src); err == nil {
+			t.Errorf("Expected %q to fail", src)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package gdl
+
+import "strconv"
+
+// rule derives head when every literal of body holds. Facts have no body.
+type rule struct {
+	head *term
+	body []*term
+}
+
+// binding is a persistent list of variable bindings
+type binding struct {
+	name string
+	val  *term
+	next *binding
+}
+
+// lookup returns the value bound to the variable name, or nil
+func (b *binding) lookup(name string) *term {
+	for ; b != nil; b = b.next {
+		if b.name == name {
+			return b.val
+		}
+	}
+	return nil
+}
+
+// walk follows the bindings of t until reaching an unbound variable or a non-variable
+func walk(t *term, b *binding) *term {
+	for t.isVar() {
+		v := b.lookup(t.name)
+		if v == nil {
+			return t
+		}
+		t = v
+	}
+	return t
+}
+
+// resolve substitutes every bound variable of t
+func resolve(t *term, b *binding) *term {
+	t = walk(t, b)
+	if len(t.args) == 0 {
+		return t
+	}
+	r := &term{name: t.name, args: make([]*term, len(t.args))}
+	for i, a := range t.args {
+		r.args[i] = resolve(a, b)
+	}
+	return r
+}
+
+// unify returns the bindings extended so that x and y are equal, and false if they cannot be
+func unify(x, y *term, b *binding) (*binding, bool) {
+	x, y = walk(x, b), walk(y, b)
+	switch {
+	case x.isVar():
+		if y.isVar() && y.name == x.name {
+			return b, true
+		}
+		return &binding{x.name, y, b}, true
+	case y.isVar():
+		return &binding{y.name, x, b}, true
+	case x.name != y.name || len(x.args) != len(y.args):
+		return nil, false
+	}
+	for i := range x.args {
+		var ok bool
+		if b, ok = unify(x.args[i], y.args[i], b); !ok {
+			return nil, false
+		}
+	}
+	return b, true
+}
+
+// prover answers queries about a single state and joint move. It is not safe
+// for concurrent use, so every query gets its own.
+type prover struct {
+	rules   map[string][]rule // Rules by the key of their head
+	facts   []*term           // Facts of the state, for true
+	does    []*term           // Moves of the roles, as (does r m), for does
+	renamed int               // Rules renamed so far, to give their variables fresh names
+}
+
+// solve proves goals under b, calling yield with the bindings of every proof
+// until it returns false. It returns false if yield did.
+func (p *prover) solve(goals []*term, b *binding, yield func(*binding) bool) bool {
+	if len(goals) == 0 {
+		return yield(b)
+	}
+	g, rest := walk(goals[0], b), goals[1:]
+
+	switch {
+	case g.name == "true" && len(g.args) == 1:
+		return p.match(g.args[0], p.facts, rest, b, yield)
+	case g.name == "does" && len(g.args) == 2:
+		return p.match(g, p.does, rest, b, yield)
+	case g.name == "not" && len(g.args) == 1:
+		if p.provable(g.args[0], b) {
+			return true
+		}
+		return p.solve(rest, b, yield)
+	case g.name == "distinct" && len(g.args) == 2:
+		if resolve(g.args[0], b).String() == resolve(g.args[1], b).String() {
+			return true
+		}
+		return p.solve(rest, b, yield)
+	case g.name == "or":
+		for _, alt := range g.args {
+			if !p.solve(append([]*term{alt}, rest...), b, yield) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, r := range p.rules[g.key()] {
+		r = p.rename(r)
+		b2, ok := unify(g, r.head, b)
+		if !ok {
+			continue
+		}
+		if !p.solve(append(r.body[:len(r.body):len(r.body)], rest...), b2, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// match proves pattern against each of terms, then the rest of the goals
+func (p *prover) match(pattern *term, terms, rest []*term, b *binding, yield func(*binding) bool) bool {
+	for _, t := range terms {
+		if b2, ok := unify(pattern, t, b); ok {
+			if !p.solve(rest, b2, yield) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// provable reports whether goal has a proof under b
+func (p *prover) provable(goal *term, b *binding) bool {
+	found := false
+	p.solve([]*term{goal}, b, func(*binding) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+// ask returns the distinct instances of query that can be proven, in the order found
+func (p *prover) ask(query *term) []*term {
+	var answers []*term
+	seen := make(map[string]bool)
+	p.solve([]*term{query}, nil, func(b *binding) bool {
+		t := resolve(query, b)
+		if s := t.String(); !seen[s] {
+			seen[s] = true
+			answers = append(answers, t)
+		}
+		return true
+	})
+	return answers
+}
+
+// rename returns r with fresh variable names, so that they do not clash
+// with those of the goals it is used to prove
+func (p *prover) rename(r rule) rule {
+	if len(r.body) == 0 && !hasVars(r.head) {
+		return r
+	}
+	p.renamed++
+	suffix := "#" + strconv.Itoa(p.renamed)
+	var re func(t *term) *term
+	re = func(t *term) *term {
+		if t.isVar() {
+			return &term{name: t.name + suffix}
+		}
+		if len(t.args) == 0 {
+			return t
+		}
+		c := &term{name: t.name, args: make([]*term, len(t.args))}
+		for i, a := range t.args {
+			c.args[i] = re(a)
+		}
+		return c
+	}
+	renamed := rule{head: re(r.head), body: make([]*term, len(r.body))}
+	for i, l := range r.body {
+		renamed.body[i] = re(l)
+	}
+	return renamed
+}
+
+// hasVars reports whether t contains a variable
+func hasVars(t *term) bool {
+	if t.isVar() {
+		return true
+	}
+	for _, a := range t.args {
+		if hasVars(a) {
+			return true
+		}
+	}
+	return false
+}
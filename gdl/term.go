@@ -0,0 +1,128 @@
+// Package gdl plays games written in GDL-lite, a subset of the Game
+// Description Language of general game playing, without writing Go for each
+// game. A description lists the roles, the initial facts, and rules for the
+// legal moves, the facts of the next state, the goals and the end of the
+// game, in prefix syntax:
+//
+//	(role x) (role o)
+//	(init (control x))
+//	(<= (legal ?p (mark ?c)) (true (control ?p)) (true (empty ?c)))
+//	(<= (next (control o)) (true (control x)))
+//	(<= terminal (true (won ?p)))
+//	(<= (goal x 100) (true (won x)))
+//
+// Load compiles a description into a Game, whose Minimax method returns the
+// functions the engine needs for one of its roles:
+//
+//	g, err := gdl.Load(src)
+//	mg, err := g.Minimax("x")
+//	start := g.Start()
+//	mm := mg.Make(&start, mg.ToMove(&start))
+//
+// Rule bodies may use (true f), (does r m), (not l), (distinct a b), (or l...)
+// and relations defined by facts and other rules. Names starting with ? are
+// variables, and ; starts a comment. Rules are evaluated by backtracking
+// resolution, as in Prolog, so recursive rules must not be left-recursive.
+package gdl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// term is an atom, a variable or a compound term such as (cell 1 1 x)
+type term struct {
+	name string
+	args []*term // nil for atoms and variables
+}
+
+// isVar reports whether t is a variable
+func (t *term) isVar() bool {
+	return t.args == nil && strings.HasPrefix(t.name, "?")
+}
+
+// String returns t in prefix syntax
+func (t *term) String() string {
+	if len(t.args) == 0 {
+		return t.name
+	}
+	var sb strings.Builder
+	sb.WriteString("(")
+	sb.WriteString(t.name)
+	for _, a := range t.args {
+		sb.WriteString(" ")
+		sb.WriteString(a.String())
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
+
+// key identifies the relation of t, such as cell/3
+func (t *term) key() string {
+	return fmt.Sprintf("%s/%d", t.name, len(t.args))
+}
+
+// tokenize splits src into parentheses and atoms, dropping comments
+func tokenize(src string) []string {
+	var tokens []string
+	for _, line := range strings.Split(src, "\n") {
+		if i := strings.Index(line, ";"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.NewReplacer("(", " ( ", ")", " ) ").Replace(line)
+		tokens = append(tokens, strings.Fields(line)...)
+	}
+	return tokens
+}
+
+// parse reads every term of src
+func parse(src string) ([]*term, error) {
+	tokens := tokenize(src)
+	var terms []*term
+	for len(tokens) > 0 {
+		t, rest, err := parseTerm(tokens)
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, t)
+		tokens = rest
+	}
+	return terms, nil
+}
+
+// parseTerm reads the term at the start of tokens and returns the tokens left
+func parseTerm(tokens []string) (*term, []string, error) {
+	switch tok := tokens[0]; tok {
+	case ")":
+		return nil, nil, fmt.Errorf("gdl: unexpected )")
+	case "(":
+		tokens = tokens[1:]
+		if len(tokens) == 0 || tokens[0] == "(" || tokens[0] == ")" {
+			return nil, nil, fmt.Errorf("gdl: expected a name after (")
+		}
+		t := &term{name: tokens[0], args: []*term{}}
+		tokens = tokens[1:]
+		for {
+			if len(tokens) == 0 {
+				return nil, nil, fmt.Errorf("gdl: missing ) after %s", t.name)
+			}
+			if tokens[0] == ")" {
+				return t, tokens[1:], nil
+			}
+			arg, rest, err := parseTerm(tokens)
+			if err != nil {
+				return nil, nil, err
+			}
+			t.args = append(t.args, arg)
+			tokens = rest
+		}
+	default:
+		return &term{name: tok}, tokens[1:], nil
+	}
+}
+
+// parseFacts reads a state written by formatFacts
+func parseFacts(s string) []*term {
+	facts, _ := parse(s)
+	return facts
+}
@@ -0,0 +1,123 @@
+package minimax
+
+import "time"
+
+// Payoffs are the scores of both players in a state, each on its own scale
+type Payoffs struct {
+	AI       int
+	Opponent int
+}
+
+// Ties tells how GeneralSum breaks ties between moves worth the same to the
+// player to move, which decide the outcome of general-sum games
+type Ties int
+
+const (
+	TieFirst    Ties = iota // Play the first of equal moves, in successor order (the default)
+	TieGenerous             // Play the one giving the other player the most
+	TieSpiteful             // Play the one giving the other player the least
+)
+
+// GeneralSum searches two-player games whose payoffs are not zero-sum, such
+// as negotiations where both players can gain or lose together. Utility
+// returns the payoffs of both players, and each player picks the move
+// maximizing its own, so the search finds the subgame-perfect equilibrium
+// instead of assuming the opponent plays against the AI.
+//
+// Alpha-beta pruning relies on one player's gain being the other's loss, so
+// it is unsound here: every move is searched, and positions reached again
+// at the same depth are looked up instead. Games that are zero-sum should
+// use Make, which prunes.
+type GeneralSum[T comparable] struct {
+	IsTerminal func(*T) bool    // Returns true if the state is terminal
+	Utility    func(*T) Payoffs // Returns the payoffs of both players in a terminal state, or one without moves
+	Successors func(*T) []*T    // Returns the states reachable in one move
+	Heuristic  func(*T) Payoffs // Estimates the payoffs of states at MaxDepth; zero if nil
+	Ties       Ties             // How moves worth the same to the player to move are told apart
+	MaxDepth   int              // Plies searched before Heuristic is used; 0 for no limit
+}
+
+// Solve returns the best move from state for the player to move, with isMax
+// true if it is the AI's turn, and the payoffs both players get by playing
+// on perfectly. The result's Value is the AI's payoff. It returns nil for
+// terminal states and states without moves.
+func (g GeneralSum[T]) Solve(state T, isMax bool) (*T, Payoffs, Result) {
+	start := time.Now()
+	res := Result{}
+	memo := make(map[nodeKey[T]]Payoffs)
+
+	var best *T
+	var p Payoffs
+	if g.IsTerminal(&state) {
+		p = g.value(&state, isMax, 0, memo, &res)
+	} else {
+		best, p = g.choose(&state, isMax, 0, memo, &res)
+		res.Nodes++
+	}
+	res.Value = p.AI
+	res.Elapsed = time.Since(start)
+	return best, p, res
+}
+
+// value returns the payoffs of s, reached after depth plies, with isMax true
+// if it is the AI's turn
+func (g GeneralSum[T]) value(s *T, isMax bool, depth int, memo map[nodeKey[T]]Payoffs, res *Result) Payoffs {
+	key := nodeKey[T]{state: *s, depth: depth}
+	if p, ok := memo[key]; ok {
+		return p
+	}
+	res.Nodes++
+	res.Depth = max(res.Depth, depth)
+
+	var p Payoffs
+	switch {
+	case g.IsTerminal(s):
+		p = g.Utility(s)
+	case g.MaxDepth > 0 && depth >= g.MaxDepth:
+		res.Unknown = true
+		res.Stopped = StopDepth
+		if g.Heuristic != nil {
+			p = g.Heuristic(s)
+		}
+	default:
+		_, p = g.choose(s, isMax, depth, memo, res)
+	}
+	memo[key] = p
+	return p
+}
+
+// choose returns the move from s the player to move prefers, and its payoffs
+func (g GeneralSum[T]) choose(s *T, isMax bool, depth int, memo map[nodeKey[T]]Payoffs, res *Result) (*T, Payoffs) {
+	succ := g.Successors(s)
+	if len(succ) == 0 {
+		return nil, g.Utility(s) // No moves, scored as terminal as Make does
+	}
+	var best *T
+	var bp Payoffs
+	for _, next := range succ {
+		p := g.value(next, !isMax, depth+1, memo, res)
+		if best == nil || g.prefers(p, bp, isMax) {
+			best, bp = next, p
+		}
+	}
+	return best, bp
+}
+
+// prefers reports whether the player to move, the AI if isMax, prefers the
+// payoffs a to b
+func (g GeneralSum[T]) prefers(a, b Payoffs, isMax bool) bool {
+	own, other, ownB, otherB := a.AI, a.Opponent, b.AI, b.Opponent
+	if !isMax {
+		own, other, ownB, otherB = other, own, otherB, ownB
+	}
+	if own != ownB {
+		return own > ownB
+	}
+	switch g.Ties {
+	case TieGenerous:
+		return other > otherB
+	case TieSpiteful:
+		return other < otherB
+	}
+	return false
+}
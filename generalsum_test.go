@@ -0,0 +1,114 @@
+package minimax
+
+import "testing"
+
+// ultimatumState is an ultimatum game over 10 coins: the AI offers part of
+// them to the opponent, who accepts the split or rejects it, leaving both
+// with nothing
+type ultimatumState struct {
+	offer int // Coins offered to the opponent, -1 until the AI offers
+	reply int // 1 if accepted, -1 if rejected, 0 until the opponent replies
+}
+
+func ultimatumGame(ties Ties) GeneralSum[ultimatumState] {
+	return GeneralSum[ultimatumState]{
+		IsTerminal: func(s *ultimatumState) bool { return s.reply != 0 },
+		Utility: func(s *ultimatumState) Payoffs {
+			if s.reply < 0 {
+				return Payoffs{}
+			}
+			return Payoffs{AI: 10 - s.offer, Opponent: s.offer}
+		},
+		Successors: func(s *ultimatumState) []*ultimatumState {
+			if s.offer < 0 {
+				var succ []*ultimatumState
+				for offer := range 11 {
+					succ = append(succ, &ultimatumState{offer: offer})
+				}
+				return succ
+			}
+			return []*ultimatumState{{s.offer, -1}, {s.offer, 1}}
+		},
+		Ties: ties,
+	}
+}
+
+// TestGeneralSum tests that each player maximizes its own payoff, breaking ties as told.
+func TestGeneralSum(t *testing.T) {
+	tests := []struct {
+		ties  Ties
+		offer int
+	}{
+		{TieGenerous, 0}, // The opponent accepts nothing, since it costs it nothing
+		{TieSpiteful, 1}, // The opponent rejects nothing, so the AI offers a coin
+		{TieFirst, 1},    // Rejecting comes first
+	}
+	for _, tt := range tests {
+		move, p, res := ultimatumGame(tt.ties).Solve(ultimatumState{offer: -1}, true)
+		if move == nil || move.offer != tt.offer {
+			t.Errorf("Ties %d: expected to offer %d, got %v", tt.ties, tt.offer, move)
+			continue
+		}
+		if want := (Payoffs{10 - tt.offer, tt.offer}); p != want || res.Value != want.AI || res.Unknown {
+			t.Errorf("Ties %d: expected %+v, got %+v and %+v", tt.ties, want, p, res)
+		}
+	}
+
+	// The opponent accepts any offer worth something to it
+	move, _, _ := ultimatumGame(TieSpiteful).Solve(ultimatumState{offer: 3}, false)
+	if move == nil || move.reply != 1 {
+		t.Errorf("Expected the opponent to accept, got %v", move)
+	}
+}
+
+// TestGeneralSumZeroSum tests that zero-sum payoffs give the outcomes of Make.
+func TestGeneralSumZeroSum(t *testing.T) {
+	g := GeneralSum[nimState]{
+		IsTerminal: nimTerminal,
+		Utility: func(s *nimState) Payoffs {
+			u := nimUtility(s)
+			return Payoffs{u, -u}
+		},
+		Successors: nimSuccessors,
+	}
+	for stones := 1; stones <= 12; stones++ {
+		state := nimState{stones: stones, aiTurn: true}
+		won := Make(&state, nimTerminal, nimUtility, nimSuccessors, true).Result().Value > 0
+		move, p, _ := g.Solve(state, true)
+		switch {
+		case won && (p != Payoffs{1, -1} || move.stones%4 != 0):
+			t.Errorf("%d stones: expected a win leaving a multiple of 4, got %v with %+v", stones, *move, p)
+		case !won && p != Payoffs{-1, 1}:
+			t.Errorf("%d stones: expected a loss, got %+v", stones, p)
+		}
+	}
+}
+
+// TestGeneralSumDepth tests that the heuristic scores states at MaxDepth.
+func TestGeneralSumDepth(t *testing.T) {
+	g := ultimatumGame(TieFirst)
+	g.MaxDepth = 1
+	g.Heuristic = func(s *ultimatumState) Payoffs { return Payoffs{AI: 10 - s.offer, Opponent: s.offer} }
+	move, p, res := g.Solve(ultimatumState{offer: -1}, true)
+	if move == nil || move.offer != 0 || p.AI != 10 || !res.Unknown || res.Stopped != StopDepth {
+		t.Errorf("Expected to offer nothing from the heuristic, got %v, %+v and %+v", move, p, res)
+	}
+}
+
+// TestGeneralSumStuck tests that non-terminal states without moves are scored by Utility, as Make does.
+func TestGeneralSumStuck(t *testing.T) {
+	g := ultimatumGame(TieFirst)
+	g.Successors = func(s *ultimatumState) []*ultimatumState {
+		if s.offer < 0 {
+			return []*ultimatumState{{offer: 4}} // The opponent cannot reply
+		}
+		return nil
+	}
+	want := Payoffs{AI: 6, Opponent: 4}
+	if move, p, _ := g.Solve(ultimatumState{offer: 4}, false); move != nil || p != want {
+		t.Errorf("Expected no move and %+v, got %v and %+v", want, move, p)
+	}
+	if move, p, res := g.Solve(ultimatumState{offer: -1}, true); move == nil || p != want || res.Value != want.AI {
+		t.Errorf("Expected to offer 4 for %+v, got %v, %+v and %+v", want, move, p, res)
+	}
+}
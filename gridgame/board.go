@@ -0,0 +1,227 @@
+// Package gridgame provides the pieces most games played on a rectangular
+// board need: an immutable, comparable board of cells, the symmetries of the
+// rectangle, and scanning of straight lines for wins and evaluations. Rules
+// builds complete games where players take turns placing pieces, such as
+// tic-tac-toe, gomoku or connect four:
+//
+//	rules := gridgame.Rules{Width: 3, Height: 3, K: 3, AI: gridgame.First}
+//	start := rules.Start()
+//	mm := rules.Game().Make(&start.State, start.IsMax)
+//	move := mm.Solve(start.State)
+package gridgame
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Piece is the content of a cell. Games define their own pieces, with Empty
+// as the zero value.
+type Piece uint8
+
+const (
+	Empty  Piece = iota // No piece
+	First               // Piece of the player moving first
+	Second              // Piece of the player moving second
+)
+
+// Opponent returns the other player's piece, for First and Second
+func (p Piece) Opponent() Piece {
+	return 3 - p
+}
+
+// Board is a grid of cells, numbered row by row from the top left corner.
+// Boards are values: setting a cell returns a new board, and boards with the
+// same size and pieces are equal, so they can be used in comparable states.
+type Board struct {
+	w, h  int
+	cells string // A byte per cell
+}
+
+// NewBoard returns an empty board of width w and height h
+func NewBoard(w, h int) Board {
+	return Board{w: w, h: h, cells: strings.Repeat("\x00", w*h)}
+}
+
+// Width returns the number of columns
+func (b Board) Width() int {
+	return b.w
+}
+
+// Height returns the number of rows
+func (b Board) Height() int {
+	return b.h
+}
+
+// Len returns the number of cells
+func (b Board) Len() int {
+	return len(b.cells)
+}
+
+// Index returns the cell in column x and row y
+func (b Board) Index(x, y int) int {
+	return y*b.w + x
+}
+
+// XY returns the column and row of a cell
+func (b Board) XY(i int) (x, y int) {
+	return i % b.w, i / b.w
+}
+
+// Inside reports whether column x and row y are on the board
+func (b Board) Inside(x, y int) bool {
+	return x >= 0 && x < b.w && y >= 0 && y < b.h
+}
+
+// At returns the piece in a cell
+func (b Board) At(i int) Piece {
+	return Piece(b.cells[i])
+}
+
+// Set returns a copy of the board with a cell set to p
+func (b Board) Set(i int, p Piece) Board {
+	cells := []byte(b.cells)
+	cells[i] = byte(p)
+	b.cells = string(cells)
+	return b
+}
+
+// SetAll returns a copy of the board with the given cells set to p, for moves
+// that change several cells at once
+func (b Board) SetAll(p Piece, cells ...int) Board {
+	buf := []byte(b.cells)
+	for _, i := range cells {
+		buf[i] = byte(p)
+	}
+	b.cells = string(buf)
+	return b
+}
+
+// Count returns the number of cells holding p
+func (b Board) Count(p Piece) int {
+	return strings.Count(b.cells, string(rune(p)))
+}
+
+// Full reports whether no cell is empty
+func (b Board) Full() bool {
+	return b.Count(Empty) == 0
+}
+
+// Diff returns the first cell that differs between two boards of the same
+// size, or -1 if they are equal. It finds where a piece was placed.
+func (b Board) Diff(o Board) int {
+	for i := range len(b.cells) {
+		if b.cells[i] != o.cells[i] {
+			return i
+		}
+	}
+	return -1
+}
+
+// Placed returns the first cell that is empty on b and not on o, or -1 if
+// none is. It finds where a piece was placed by moves that also change other
+// cells, such as captures.
+func (b Board) Placed(o Board) int {
+	for i := range len(b.cells) {
+		if b.At(i) == Empty && o.At(i) != Empty {
+			return i
+		}
+	}
+	return -1
+}
+
+// CellName names a cell by its column letter and row number, counted from 1
+// at the top, such as "a1" for the top left corner
+func (b Board) CellName(i int) string {
+	x, y := b.XY(i)
+	return string(rune('a'+x)) + strconv.Itoa(y+1)
+}
+
+// ParseCell returns the cell named by CellName, in either case, and false if
+// the name is not a cell of the board
+func (b Board) ParseCell(name string) (int, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if len(name) < 2 {
+		return 0, false
+	}
+	y, err := strconv.Atoi(name[1:])
+	x := int(name[0]) - 'a'
+	if err != nil || !b.Inside(x, y-1) {
+		return 0, false
+	}
+	return b.Index(x, y-1), true
+}
+
+// String draws the board a row per line, with '.' for empty cells, 'X' and
+// 'O' for the players and digits for other pieces
+func (b Board) String() string {
+	return b.Format("")
+}
+
+// Format draws the board a row per line, with the i-th rune of symbols for
+// piece i, or the symbols of String if symbols is empty
+func (b Board) Format(symbols string) string {
+	if symbols == "" {
+		symbols = ".XO3456789"
+	}
+	runes := []rune(symbols)
+
+	var sb strings.Builder
+	for y := range b.h {
+		for x := range b.w {
+			if p := int(b.At(b.Index(x, y))); p < len(runes) {
+				sb.WriteRune(runes[p])
+			} else {
+				sb.WriteByte('?')
+			}
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// FormatLabeled draws the board like Format, with the column letters above
+// it and the row numbers to its left, as CellName names cells
+func (b Board) FormatLabeled(symbols string) string {
+	width := len(strconv.Itoa(b.h))
+	var sb strings.Builder
+	sb.WriteString(strings.Repeat(" ", width+1))
+	for x := range b.w {
+		sb.WriteRune(rune('a' + x))
+	}
+	sb.WriteByte('\n')
+	for y, row := range strings.Split(strings.TrimSuffix(b.Format(symbols), "\n"), "\n") {
+		fmt.Fprintf(&sb, "%*d %s\n", width, y+1, row)
+	}
+	return sb.String()
+}
+
+// Parse reads a board drawn by Format with the same symbols, ignoring
+// whitespace. It returns false if the rows have different lengths or hold
+// unknown symbols.
+func Parse(s, symbols string) (Board, bool) {
+	if symbols == "" {
+		symbols = ".XO3456789"
+	}
+
+	var b Board
+	var cells []byte
+	for _, row := range strings.Fields(s) {
+		if b.w == 0 {
+			b.w = len([]rune(row))
+		} else if len([]rune(row)) != b.w {
+			return Board{}, false
+		}
+		for _, r := range row {
+			p := strings.IndexRune(symbols, r)
+			if p < 0 {
+				return Board{}, false
+			}
+			cells = append(cells, byte(len([]rune(symbols[:p]))))
+		}
+		b.h++
+	}
+	b.cells = string(cells)
+	return b, true
+}
@@ -0,0 +1,116 @@
+package gridgame
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+// TestSymmetry tests that symmetric boards share a canonical form.
+func TestSymmetry(t *testing.T) {
+	b, ok := Parse("X.. ... ..O", "")
+	if !ok {
+		t.Fatal("Expected the board to parse")
+	}
+	want, _ := b.Canonical()
+	for _, tr := range b.Transforms() {
+		img := b.Apply(tr)
+		if got, _ := img.Canonical(); got != want {
+			t.Errorf("Expected transform %d to share the canonical form:\n%s", tr, img)
+		}
+	}
+	if b.Apply(Rotate90).String() != "..X\n...\nO..\n" {
+		t.Errorf("Unexpected rotation:\n%s", b.Apply(Rotate90))
+	}
+	if len(NewBoard(4, 3).Transforms()) != 4 {
+		t.Error("Expected four symmetries for a rectangle")
+	}
+}
+
+// TestLines tests win detection and threat counting.
+func TestLines(t *testing.T) {
+	lines := Lines(3, 3, 3)
+	if len(lines) != 8 {
+		t.Fatalf("Expected 8 lines on a 3x3 board, got %d", len(lines))
+	}
+
+	b, _ := Parse("XO. .XO ..X", "")
+	if w := b.Winner(lines); w != First {
+		t.Errorf("Expected X to win, got %d", w)
+	}
+	b, _ = Parse("X.. .O. ...", "")
+	if got := b.Threats(lines, First); got[1] != 2 || got[0] != 2 {
+		t.Errorf("Expected 2 empty and 2 single lines for X, got %v", got)
+	}
+}
+
+// TestRules tests that searching tic-tac-toe and a small connect four works end to end.
+func TestRules(t *testing.T) {
+	for _, sym := range []bool{false, true} {
+		rules := Rules{Width: 3, Height: 3, K: 3, Symmetric: sym}
+		start := rules.Start()
+		mm := rules.Game().Make(&start.State, start.IsMax)
+		if mm.Solve(start.State) == nil || mm.Result().Value != 0 {
+			t.Errorf("Expected tic-tac-toe to be a draw (symmetric %v), got %+v", sym, mm.Result())
+		}
+	}
+
+	// X to move wins by completing the bottom row
+	rules := Rules{Width: 4, Height: 3, K: 3, Gravity: true}
+	board, _ := Parse("....  ....  XX.O", "")
+	state := State{Board: board.Set(board.Index(3, 1), Second), Turn: First}
+	game := rules.Game()
+	if n := len(game.Successors(&state)); n != 4 {
+		t.Errorf("Expected a move per column, got %d", n)
+	}
+	mm := game.Make(&state, true)
+	move := mm.Solve(state)
+	if move == nil || move.Board.At(move.Board.Index(2, 2)) != First {
+		t.Errorf("Expected X to drop in the third column, got\n%v", move)
+	}
+
+	row, col, ok := rules.Cell(&state, move)
+	if !ok || row != 2 || col != 2 {
+		t.Errorf("Expected the move at row 2, column 2, got %d, %d", row, col)
+	}
+	if h := rules.Heuristic()(&state); h <= 0 || h > minimax.MaxHeuristic {
+		t.Errorf("Expected a positive evaluation for X, got %d", h)
+	}
+}
+
+// TestCellNames tests naming cells and finding where a piece was placed.
+func TestCellNames(t *testing.T) {
+	b := NewBoard(8, 8)
+	for _, c := range []struct {
+		name string
+		cell int
+	}{{"a1", 0}, {"h1", 7}, {"c2", 10}, {"H8", 63}} {
+		if i, ok := b.ParseCell(c.name); !ok || i != c.cell {
+			t.Errorf("Expected %s to be cell %d, got %d", c.name, c.cell, i)
+		}
+	}
+	if b.CellName(10) != "c2" {
+		t.Errorf("Expected cell 10 to be c2, got %s", b.CellName(10))
+	}
+	for _, name := range []string{"", "a", "i1", "a9", "a0", "1a"} {
+		if _, ok := b.ParseCell(name); ok {
+			t.Errorf("Expected %q not to be a cell", name)
+		}
+	}
+
+	from := b.SetAll(First, 0, 1)
+	to := from.SetAll(Second, 0, 1, 2)
+	if to.Placed(from) != -1 || from.Placed(to) != 2 {
+		t.Errorf("Expected the piece to be placed on cell 2, got %d", from.Placed(to))
+	}
+}
+
+// TestFormatLabeled tests drawing a board with coordinates.
+func TestFormatLabeled(t *testing.T) {
+	b := NewBoard(3, 10).Set(0, First)
+	got := b.FormatLabeled("")
+	if !strings.HasPrefix(got, "   abc\n 1 X..\n 2 ...\n") || !strings.HasSuffix(got, "10 ...\n") {
+		t.Errorf("Unexpected drawing:\n%s", got)
+	}
+}
@@ -0,0 +1,76 @@
+package gridgame
+
+// Lines returns every straight line of k cells on a board of width w and
+// height h: horizontal, vertical and both diagonals. Scanning these lines is
+// how most grid games find wins and threats.
+func Lines(w, h, k int) [][]int {
+	b := Board{w: w, h: h}
+	dirs := [][2]int{{1, 0}, {0, 1}, {1, 1}, {1, -1}}
+
+	var lines [][]int
+	for y := range h {
+		for x := range w {
+			for _, d := range dirs {
+				if !b.Inside(x+(k-1)*d[0], y+(k-1)*d[1]) {
+					continue
+				}
+				line := make([]int, k)
+				for j := range k {
+					line[j] = b.Index(x+j*d[0], y+j*d[1])
+				}
+				lines = append(lines, line)
+			}
+		}
+	}
+	return lines
+}
+
+// Winner returns the piece filling a whole line, or Empty if there is none
+func (b Board) Winner(lines [][]int) Piece {
+	for _, line := range lines {
+		p := b.At(line[0])
+		if p == Empty {
+			continue
+		}
+		full := true
+		for _, i := range line[1:] {
+			if b.At(i) != p {
+				full = false
+				break
+			}
+		}
+		if full {
+			return p
+		}
+	}
+	return Empty
+}
+
+// Threats counts the lines still open to p by how many of its pieces they
+// hold: counts[n] is the number of lines with n pieces of p and the rest
+// empty. It is the usual raw material of grid game evaluations.
+func (b Board) Threats(lines [][]int, p Piece) []int {
+	var counts []int
+	if len(lines) > 0 {
+		counts = make([]int, len(lines[0])+1)
+	}
+	for _, line := range lines {
+		n := 0
+		for _, i := range line {
+			switch b.At(i) {
+			case p:
+				n++
+			case Empty:
+			default:
+				n = -1
+			}
+			if n < 0 {
+				break
+			}
+		}
+		if n >= 0 {
+			counts[n]++
+		}
+	}
+	return counts
+}
@@ -0,0 +1,133 @@
+package gridgame
+
+import "github.com/abtsousa/minimax-go"
+
+// State is a position of a game built by Rules
+type State struct {
+	Board Board
+	Turn  Piece // Piece of the player to move
+}
+
+// Rules describes a game where two players take turns placing pieces on
+// empty cells, and whoever first gets K in a row wins. Tic-tac-toe is
+// Rules{Width: 3, Height: 3, K: 3}, and connect four adds Gravity.
+type Rules struct {
+	Width, Height int
+	K             int   // Pieces in a row needed to win
+	Gravity       bool  // Pieces fall to the lowest empty cell of their column
+	AI            Piece // Piece the AI plays, First if Empty
+	Symmetric     bool  // Drop moves that lead to a position symmetric to an earlier move's
+}
+
+// Start returns the empty board with First to move
+func (r Rules) Start() minimax.Position[State] {
+	return minimax.Position[State]{
+		State: State{Board: NewBoard(r.Width, r.Height), Turn: First},
+		IsMax: r.ai() == First,
+	}
+}
+
+// Game returns the functions Make needs to search the game
+func (r Rules) Game() minimax.Game[State] {
+	lines := Lines(r.Width, r.Height, r.K)
+	ai := r.ai()
+
+	return minimax.Game[State]{
+		IsTerminal: func(s *State) bool {
+			return s.Board.Full() || s.Board.Winner(lines) != Empty
+		},
+		Utility: func(s *State) int {
+			switch s.Board.Winner(lines) {
+			case ai:
+				return 1
+			case Empty:
+				return 0
+			default:
+				return -1
+			}
+		},
+		Successors: func(s *State) []*State {
+			return r.successors(s)
+		},
+		ToMove: func(s *State) bool {
+			return s.Turn == ai
+		},
+	}
+}
+
+// Heuristic returns an evaluation for depth-limited searches: the difference
+// between the lines open to the AI and to its opponent, weighted by how full
+// they are, within [-MaxHeuristic, MaxHeuristic]
+func (r Rules) Heuristic() func(*State) int {
+	lines := Lines(r.Width, r.Height, r.K)
+	ai := r.ai()
+
+	return func(s *State) int {
+		mine, theirs := s.Board.Threats(lines, ai), s.Board.Threats(lines, ai.Opponent())
+		score, weight := 0, 1
+		for n := 1; n < len(mine); n++ {
+			score += weight * (mine[n] - theirs[n])
+			weight *= 8
+		}
+		return max(-minimax.MaxHeuristic, min(minimax.MaxHeuristic, score))
+	}
+}
+
+// Cell returns the cell where the move from one state to the next placed its
+// piece, in the form NewHeatmap expects
+func (r Rules) Cell(from, to *State) (row, col int, ok bool) {
+	i := from.Board.Diff(to.Board)
+	if i < 0 {
+		return 0, 0, false
+	}
+	x, y := from.Board.XY(i)
+	return y, x, true
+}
+
+// ai returns the piece of the AI
+func (r Rules) ai() Piece {
+	if r.AI == Empty {
+		return First
+	}
+	return r.AI
+}
+
+// successors places the piece of the player to move on every free cell
+func (r Rules) successors(s *State) []*State {
+	b := s.Board
+	var cells []int
+	if r.Gravity {
+		for x := range b.Width() {
+			for y := b.Height() - 1; y >= 0; y-- {
+				if i := b.Index(x, y); b.At(i) == Empty {
+					cells = append(cells, i)
+					break
+				}
+			}
+		}
+	} else {
+		for i := range b.Len() {
+			if b.At(i) == Empty {
+				cells = append(cells, i)
+			}
+		}
+	}
+
+	var seen map[Board]bool
+	if r.Symmetric {
+		seen = make(map[Board]bool, len(cells))
+	}
+	succ := make([]*State, 0, len(cells))
+	for _, i := range cells {
+		next := b.Set(i, s.Turn)
+		if seen != nil {
+			canon, _ := next.Canonical()
+			if seen[canon] {
+				continue
+			}
+			seen[canon] = true
+		}
+		succ = append(succ, &State{Board: next, Turn: s.Turn.Opponent()})
+	}
+	return succ
+}
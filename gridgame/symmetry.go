@@ -0,0 +1,74 @@
+package gridgame
+
+// Transform is a symmetry of the board
+type Transform int
+
+const (
+	Identity      Transform = iota // Leaves the board as it is
+	FlipX                          // Mirrors left and right
+	FlipY                          // Mirrors top and bottom
+	Rotate180                      // Turns the board half way round
+	Transpose                      // Mirrors along the main diagonal, square boards only
+	Rotate90                       // Turns the board clockwise, square boards only
+	Rotate270                      // Turns the board counterclockwise, square boards only
+	AntiTranspose                  // Mirrors along the anti-diagonal, square boards only
+)
+
+// Transforms returns the symmetries of the board: all eight if it is square,
+// and the four that keep its shape otherwise
+func (b Board) Transforms() []Transform {
+	if b.w == b.h {
+		return []Transform{Identity, FlipX, FlipY, Rotate180, Transpose, Rotate90, Rotate270, AntiTranspose}
+	}
+	return []Transform{Identity, FlipX, FlipY, Rotate180}
+}
+
+// Map returns the cell that cell i moves to under t
+func (b Board) Map(t Transform, i int) int {
+	x, y := b.XY(i)
+	w, h := b.w-1, b.h-1
+	switch t {
+	case FlipX:
+		x = w - x
+	case FlipY:
+		y = h - y
+	case Rotate180:
+		x, y = w-x, h-y
+	case Transpose:
+		x, y = y, x
+	case Rotate90:
+		x, y = h-y, x
+	case Rotate270:
+		x, y = y, w-x
+	case AntiTranspose:
+		x, y = h-y, w-x
+	}
+	return b.Index(x, y)
+}
+
+// Apply returns the board transformed by t. Transforms that only apply to
+// square boards panic on other boards.
+func (b Board) Apply(t Transform) Board {
+	if t >= Transpose && b.w != b.h {
+		panic("gridgame: transform needs a square board")
+	}
+	cells := make([]byte, len(b.cells))
+	for i := range len(b.cells) {
+		cells[b.Map(t, i)] = b.cells[i]
+	}
+	b.cells = string(cells)
+	return b
+}
+
+// Canonical returns the least of the board's symmetric images, and the
+// transform that produces it. Boards that are symmetric to each other have the
+// same canonical form.
+func (b Board) Canonical() (Board, Transform) {
+	best, bt := b, Identity
+	for _, t := range b.Transforms()[1:] {
+		if img := b.Apply(t); img.cells < best.cells {
+			best, bt = img, t
+		}
+	}
+	return best, bt
+}
@@ -0,0 +1,84 @@
+package minimax
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// Handicap weakens the engine by a precise amount, to grade difficulty levels
+// rather than blunder at random, see WithHandicap
+type Handicap struct {
+	Rank   int // Play the Rank-th best of the candidate moves, 1 (or 0) for the best
+	Margin int // Only moves worth at least Margin less than the best move are candidates; all moves if 0
+}
+
+// WithHandicap makes searches play a weaker move than the best one, as
+// chosen by h: for instance Handicap{Rank: 2} plays the second best move, and
+// Handicap{Margin: 100} the best move worth at least 100 less than the best.
+// If no move is as bad as asked, the worst move is played. Ranking needs the
+// exact value of every move from the searched state, so they are all
+// searched with a full window, as by ScoreMoves, sharing the node and time
+// limits passed to Make. Moves whose search a limit stopped are left out,
+// unless all were, and make Result.Unknown true. Result.Value is the value of the move
+// played.
+func WithHandicap(h Handicap) Option {
+	return func(o *options) {
+		o.handicap = h
+	}
+}
+
+// handicapped searches every move from state and keeps the one the handicap
+// picks as the best move of state. h is the handle of an asynchronous
+// search, or nil.
+func handicapped[T comparable](state *T, cf config[T], h *Search[T]) Minimax[T] {
+	start := time.Now()
+	if cf.isTerminal(state) {
+		return buildAt(state, cf, h, 0)
+	}
+	moves, release := cf.generate(state)
+	defer release()
+	if len(moves) == 0 {
+		return buildAt(state, cf, h, 0)
+	}
+
+	scores, res := scoreMoves(state, moves, cf, h)
+	pick := pickHandicapped(cf.handicap, scores, cf.isMax)
+	res.Value = pick.Value
+	res.Unknown = pick.Unknown || res.Stopped != StopNone && res.Stopped != StopDepth // Moves cut short may rank wrongly
+	res.Elapsed = time.Since(start)
+	h.setBest(pick.Move, pick.Value)
+	return Minimax[T]{
+		moveMap: map[T]*T{*cf.copyOf(state): pick.Move},
+		config:  cf,
+		result:  res,
+	}
+}
+
+// pickHandicapped returns the move hc plays among scores, for the max side if
+// isMax. Moves whose search did not complete are only ranked if none did.
+func pickHandicapped[T comparable](hc Handicap, scores []MoveScore[T], isMax bool) MoveScore[T] {
+	ranked := slices.DeleteFunc(slices.Clone(scores), func(ms MoveScore[T]) bool { return !ms.Complete() })
+	if len(ranked) == 0 {
+		ranked = slices.Clone(scores)
+	}
+	slices.SortStableFunc(ranked, func(a, b MoveScore[T]) int {
+		if isMax {
+			return cmp.Compare(b.Value, a.Value)
+		}
+		return cmp.Compare(a.Value, b.Value)
+	})
+
+	candidates := ranked
+	if hc.Margin > 0 {
+		best := ranked[0].Value
+		i := slices.IndexFunc(ranked, func(ms MoveScore[T]) bool {
+			return isMax && ms.Value <= best-hc.Margin || !isMax && ms.Value >= best+hc.Margin
+		})
+		if i < 0 {
+			return ranked[len(ranked)-1]
+		}
+		candidates = ranked[i:]
+	}
+	return candidates[min(max(hc.Rank, 1), len(candidates))-1]
+}
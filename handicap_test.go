@@ -0,0 +1,81 @@
+package minimax
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+// TestHandicap tests playing the k-th best move and the best move worse than the best by a margin.
+func TestHandicap(t *testing.T) {
+	for _, isMax := range []bool{true, false} {
+		state := pathState{depth: pathDepth - 4}
+		h := WithHeuristic(func(s *pathState) int { return int(s.path%11) - 5 })
+		limits := WithLimits(Limits{MaxDepth: pathDepth - 2})
+
+		// Moves ranked by their exact values, best first for the side to move
+		ranked := Make(&state, pathTerminal, pathUtility, pathSuccessors, isMax, h, limits).ScoreMoves(state)
+		slices.SortStableFunc(ranked, func(a, b MoveScore[pathState]) int {
+			if isMax {
+				return cmp.Compare(b.Value, a.Value)
+			}
+			return cmp.Compare(a.Value, b.Value)
+		})
+
+		for _, tc := range []struct {
+			hc   Handicap
+			want int // Index in ranked
+		}{
+			{Handicap{Rank: 1}, 0},
+			{Handicap{Rank: 2}, 1},
+			{Handicap{Rank: 3}, 2},
+			{Handicap{Rank: 9}, 2},
+			{Handicap{Margin: 1}, slices.IndexFunc(ranked, func(ms MoveScore[pathState]) bool { return ms.Value != ranked[0].Value })},
+			{Handicap{Margin: 3 * score}, 2},
+		} {
+			mm := Make(&state, pathTerminal, pathUtility, pathSuccessors, isMax, h, limits, WithHandicap(tc.hc))
+			move := mm.Solve(state)
+			want := ranked[max(tc.want, 0)]
+			if tc.want < 0 {
+				want = ranked[len(ranked)-1] // All moves equal, the worst is played
+			}
+			if *move != *want.Move || mm.Result().Value != want.Value {
+				t.Errorf("isMax %v, %+v: expected %v worth %d, got %v worth %d", isMax, tc.hc, *want.Move, want.Value, *move, mm.Result().Value)
+			}
+		}
+	}
+}
+
+// TestHandicapForcedWin tests that a margin below a proven win gives it up.
+func TestHandicapForcedWin(t *testing.T) {
+	state := nimState{stones: 9, aiTurn: true}
+	mm := Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithHandicap(Handicap{Margin: 1}))
+	if move := mm.Solve(state); move.stones == 8 || mm.Result().Value >= 0 {
+		t.Errorf("Expected to give up the win, got %v worth %d", move, mm.Result().Value)
+	}
+	if move := mm.Solve(nimState{}); move != nil {
+		t.Errorf("Expected no move from a terminal state, got %v", move)
+	}
+}
+
+// TestHandicapLimits tests that the moves share the node limit and that moves
+// cut short by it are neither ranked nor reported as exact.
+func TestHandicapLimits(t *testing.T) {
+	state := nimState{stones: 40, aiTurn: true}
+	for _, nodes := range []int{3, 30} {
+		res := Make(&state, nimTerminal, nimUtility, nimSuccessors, true,
+			WithLimits(Limits{MaxDepth: 30, MaxNodes: nodes}), WithHandicap(Handicap{Rank: 1})).Result()
+		if res.Nodes > nodes || res.Stopped != StopNodes || !res.Unknown || res.Value == score {
+			t.Errorf("%d nodes: expected an unknown value within the limit, got %+v", nodes, res)
+		}
+	}
+
+	// Complete moves rank above the others, whatever their value
+	scores := []MoveScore[nimState]{
+		{Move: &nimState{stones: 1}, Value: score, Unknown: true, Stopped: StopNodes},
+		{Move: &nimState{stones: 2}, Value: -5},
+	}
+	if pick := pickHandicapped(Handicap{Rank: 1}, scores, true); pick.Move.stones != 2 {
+		t.Errorf("Expected the complete move to be played, got %+v", pick)
+	}
+}
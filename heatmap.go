@@ -0,0 +1,148 @@
+package minimax
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MoveScore is a move from the root together with its value for the AI
+type MoveScore[T comparable] struct {
+	Move    *T
+	Value   int        // Exact value of the move, unlike the bounds alpha-beta leaves for non-best moves
+	Unknown bool       // Whether Value depends on branches cut off by MaxDepth, or a hard limit stopped the move's search
+	Nodes   int        // Nodes visited to score the move
+	Stopped StopReason // Which limit terminated the move's search, as in Result
+}
+
+// Complete reports whether the move's search ran to the end, so that Value
+// is its value, or a heuristic estimate of it if Unknown, rather than what a
+// node or time limit left of it
+func (ms MoveScore[T]) Complete() bool {
+	return ms.Stopped == StopNone || ms.Stopped == StopDepth
+}
+
+// ScoreMoves gives every move from state its exact value, by searching each
+// one with a full window. This costs more than Solve, which only proves the
+// best move. Values are on the scale of Result.Value, and moves are in
+// successor order. The node and time limits passed to Make are shared by the
+// moves' searches, each getting an even part of what the previous ones left;
+// moves whose search a limit stopped are not Complete.
+func (m Minimax[T]) ScoreMoves(state T) []MoveScore[T] {
+	if m.config.isTerminal(&state) {
+		return nil
+	}
+
+	moves, release := m.config.generate(&state)
+	defer release()
+	scores, _ := scoreMoves(&state, moves, m.config, nil)
+	return scores
+}
+
+// scoreMoves searches each of moves from state with a full window, sharing
+// the node and time limits of cf between them, and sums their searches up in
+// a Result. h is the handle of an asynchronous search, or nil; if it is
+// stopped, the moves left are not scored.
+func scoreMoves[T comparable](state *T, moves []*T, cf config[T], h *Search[T]) ([]MoveScore[T], *Result) {
+	start := time.Now()
+	at := cf
+	at.isMax = !cf.isMax
+	scores := make([]MoveScore[T], 0, len(moves))
+	res := &Result{}
+	for i, move := range moves {
+		move = cf.copyOf(move)
+		at.rootCost = cf.rootCost + cf.moveCost(state, move)
+
+		// Each move gets an even part of the budget the previous ones left
+		left := len(moves) - i
+		stopped := StopNone
+		if cf.limits.MaxNodes > 0 {
+			nodes := cf.limits.MaxNodes - res.Nodes
+			if nodes <= 0 {
+				stopped = StopNodes
+			}
+			at.limits.MaxNodes = max(nodes/left, 1)
+		}
+		if cf.limits.MaxTime > 0 {
+			if at.limits.MaxTime = (cf.limits.MaxTime - time.Since(start)) / time.Duration(left); at.limits.MaxTime <= 0 {
+				stopped = StopTime
+			}
+		}
+
+		ms := MoveScore[T]{Move: move, Unknown: true, Stopped: stopped}
+		if stopped == StopNone {
+			r := buildAt(move, at, h, 1).Result()
+			ms.Value, ms.Unknown, ms.Nodes, ms.Stopped = r.Value, r.Unknown, r.Nodes, r.Stopped
+			res.Nodes += r.Nodes
+			res.Depth = max(res.Depth, r.Depth)
+			if r.Err != nil && res.Err == nil {
+				res.Err = r.Err
+			}
+		}
+		scores = append(scores, ms)
+		res.Stopped = max(res.Stopped, ms.Stopped)
+		if h.stopping() {
+			break
+		}
+	}
+	res.Elapsed = time.Since(start)
+	return scores, res
+}
+
+// Heatmap lays out move scores on a grid, for rendering the engine's
+// preferences in grid games. Cells without a move are nil.
+type Heatmap struct {
+	Rows  int      `json:"rows"`
+	Cols  int      `json:"cols"`
+	Cells [][]*int `json:"cells"`
+}
+
+// NewHeatmap places the scores of the moves from state on a rows by cols grid.
+// coord maps a move to its cell, returning false for moves that have none,
+// such as passes. Scores are for the AI, so the min side prefers low values.
+func NewHeatmap[T comparable](state *T, moves []MoveScore[T], rows, cols int,
+	coord func(from, to *T) (row, col int, ok bool),
+) Heatmap {
+	h := Heatmap{Rows: rows, Cols: cols, Cells: make([][]*int, rows)}
+	for r := range h.Cells {
+		h.Cells[r] = make([]*int, cols)
+	}
+
+	for _, m := range moves {
+		r, c, ok := coord(state, m.Move)
+		if !ok || r < 0 || r >= rows || c < 0 || c >= cols {
+			continue
+		}
+		v := m.Value
+		h.Cells[r][c] = &v
+	}
+	return h
+}
+
+// String renders the heatmap as a table of values, with dots for empty cells
+func (h Heatmap) String() string {
+	cells := make([][]string, h.Rows)
+	width := 1
+	for r, row := range h.Cells {
+		cells[r] = make([]string, h.Cols)
+		for c, v := range row {
+			cells[r][c] = "."
+			if v != nil {
+				cells[r][c] = fmt.Sprint(*v)
+			}
+			width = max(width, len(cells[r][c]))
+		}
+	}
+
+	var sb strings.Builder
+	for _, row := range cells {
+		for c, v := range row {
+			if c > 0 {
+				sb.WriteByte(' ')
+			}
+			fmt.Fprintf(&sb, "%*s", width, v)
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
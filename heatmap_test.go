@@ -0,0 +1,73 @@
+package minimax
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestScoreMoves tests that every root move gets its exact value.
+func TestScoreMoves(t *testing.T) {
+	state := nimState{stones: 7, aiTurn: true}
+	mm := Make(&state, nimTerminal, nimUtility, nimSuccessors, true)
+	scores := mm.ScoreMoves(state)
+
+	if len(scores) != 3 {
+		t.Fatalf("Expected 3 moves, got %d", len(scores))
+	}
+	best := mm.Solve(state)
+	for _, s := range scores {
+		one := Make(s.Move, nimTerminal, nimUtility, nimSuccessors, false).Result().Value
+		// The separate search is one ply shallower, so proven results are one point better
+		want := one - 1
+		if one < 0 {
+			want = one + 1
+		}
+		if s.Value != want {
+			t.Errorf("Move to %d stones: expected %d, got %d", s.Move.stones, want, s.Value)
+		}
+		if *s.Move == *best && s.Value != mm.Result().Value {
+			t.Errorf("Expected the best move to score the root value %d, got %d", mm.Result().Value, s.Value)
+		}
+	}
+}
+
+// TestScoreMovesLimits tests that the moves share the node limit and that
+// moves cut short by it are not taken for exact.
+func TestScoreMovesLimits(t *testing.T) {
+	state := nimState{stones: 40, aiTurn: true}
+	mm := Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithLimits(Limits{MaxDepth: 30, MaxNodes: 30}))
+	nodes := 0
+	for _, s := range mm.ScoreMoves(state) {
+		nodes += s.Nodes
+		if s.Complete() || !s.Unknown || s.Stopped != StopNodes {
+			t.Errorf("Move to %d stones: expected a search stopped by the node limit, got %+v", s.Move.stones, s)
+		}
+	}
+	if nodes > 30 {
+		t.Errorf("Expected the moves to share 30 nodes, got %d", nodes)
+	}
+}
+
+// TestHeatmap tests laying out move scores on a grid.
+func TestHeatmap(t *testing.T) {
+	state := nimState{stones: 7, aiTurn: true}
+	mm := Make(&state, nimTerminal, nimUtility, nimSuccessors, true)
+	scores := mm.ScoreMoves(state)
+
+	// Taking 1, 2 or 3 stones maps to the cells of a single row, leaving the last one empty
+	h := NewHeatmap(&state, scores, 1, 4, func(from, to *nimState) (int, int, bool) {
+		return 0, from.stones - to.stones - 1, true
+	})
+	for _, s := range scores {
+		c := state.stones - s.Move.stones - 1
+		if h.Cells[0][c] == nil || *h.Cells[0][c] != s.Value {
+			t.Errorf("Expected cell %d to hold %d, got %v", c, s.Value, h.Cells[0][c])
+		}
+	}
+	if h.Cells[0][3] != nil {
+		t.Errorf("Expected the last cell to be empty")
+	}
+	if got := h.String(); !strings.HasSuffix(strings.TrimSpace(got), ".") || strings.Count(got, "\n") != 1 {
+		t.Errorf("Unexpected rendering %q", got)
+	}
+}
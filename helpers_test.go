@@ -0,0 +1,59 @@
+package minimax
+
+// nimState is a game of Nim where players take 1 to 3 stones and whoever
+// takes the last stone wins. The player to move loses iff stones%4 == 0.
+type nimState struct {
+	stones int
+	aiTurn bool
+}
+
+func nimTerminal(s *nimState) bool {
+	return s.stones == 0
+}
+
+func nimUtility(s *nimState) int {
+	if s.stones > 0 {
+		return 0
+	}
+	// Whoever moved last took the last stone
+	if s.aiTurn {
+		return -1
+	}
+	return 1
+}
+
+func nimSuccessors(s *nimState) []*nimState {
+	var succ []*nimState
+	for take := 1; take <= min(3, s.stones); take++ {
+		succ = append(succ, &nimState{stones: s.stones - take, aiTurn: !s.aiTurn})
+	}
+	return succ
+}
+
+// pathState is a node in a uniform tree with no transpositions: every state
+// remembers the moves that led to it, so the tree grows as 3^depth.
+type pathState struct {
+	path  uint64
+	depth int
+}
+
+const pathDepth = 30
+
+func pathTerminal(s *pathState) bool {
+	return s.depth >= pathDepth
+}
+
+func pathUtility(s *pathState) int {
+	return int(s.path%3) - 1
+}
+
+func pathSuccessors(s *pathState) []*pathState {
+	if pathTerminal(s) {
+		return nil
+	}
+	succ := make([]*pathState, 3)
+	for i := range succ {
+		succ[i] = &pathState{path: s.path*3 + uint64(i), depth: s.depth + 1}
+	}
+	return succ
+}
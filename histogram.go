@@ -0,0 +1,177 @@
+package minimax
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// WithHistograms collects histograms of the values seen by the search, by
+// depth, into Result.Histograms, with buckets width wide (at least 1). They
+// tell how far the heuristic and the searched values spread at each depth,
+// which is what margins such as those of WithRazoring and WithStages, or a
+// mapping from values to win probabilities, are calibrated from.
+func WithHistograms(width int) Option {
+	return func(o *options) {
+		o.histWidth = max(width, 1)
+	}
+}
+
+// Histograms holds the distributions of values seen by a search, indexed by
+// the depth of the nodes they were seen at
+type Histograms struct {
+	Static []Histogram // Heuristic values of nodes scored at the depth limit or razored, clamped
+	Backed []Histogram // Exact values of nodes whose search completed; bounds from cutoffs are left out
+
+	width int // Width of the buckets
+	mu    sync.Mutex
+}
+
+// Histogram counts values in buckets of equal width. Proven wins and losses
+// are counted apart, since they lie far outside the heuristic range.
+type Histogram struct {
+	Width  int   // Width of the buckets
+	Low    int   // Lower bound of the first bucket
+	Counts []int // Counts[i] counts the values in [Low+i*Width, Low+(i+1)*Width)
+	Wins   int   // Proven wins for the AI
+	Losses int   // Proven losses for the AI
+}
+
+// add counts the value v
+func (h *Histogram) add(v int) {
+	switch {
+	case v > MaxHeuristic:
+		h.Wins++
+		return
+	case v < -MaxHeuristic:
+		h.Losses++
+		return
+	}
+
+	low := floorDiv(v, h.Width) * h.Width
+	switch {
+	case len(h.Counts) == 0:
+		h.Low = low
+		h.Counts = []int{0}
+	case low < h.Low:
+		grow := (h.Low - low) / h.Width
+		h.Counts = append(make([]int, grow, grow+len(h.Counts)), h.Counts...)
+		h.Low = low
+	}
+	i := (low - h.Low) / h.Width
+	for len(h.Counts) <= i {
+		h.Counts = append(h.Counts, 0)
+	}
+	h.Counts[i]++
+}
+
+// floorDiv divides a by b > 0, rounding down
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b < 0 {
+		q--
+	}
+	return q
+}
+
+// Total returns the number of values counted, including wins and losses
+func (h Histogram) Total() int {
+	total := h.Wins + h.Losses
+	for _, c := range h.Counts {
+		total += c
+	}
+	return total
+}
+
+// Mean returns the mean of the values in the buckets, each taken at the
+// middle of its bucket, or 0 if there are none
+func (h Histogram) Mean() float64 {
+	var sum float64
+	n := 0
+	for i, c := range h.Counts {
+		sum += float64(c) * (float64(h.Low+i*h.Width) + float64(h.Width-1)/2)
+		n += c
+	}
+	if n == 0 {
+		return 0
+	}
+	return sum / float64(n)
+}
+
+// Quantile returns the lower bound of the bucket holding the q-th quantile
+// (0 ≤ q ≤ 1) of the values in the buckets, or 0 if there are none
+func (h Histogram) Quantile(q float64) int {
+	n := 0
+	for _, c := range h.Counts {
+		n += c
+	}
+	if n == 0 {
+		return 0
+	}
+	rank := max(1, int(q*float64(n)+0.5))
+	for i, c := range h.Counts {
+		if rank <= c {
+			return h.Low + i*h.Width
+		}
+		rank -= c
+	}
+	return h.Low + (len(h.Counts)-1)*h.Width
+}
+
+// String draws the histogram, a bucket per line, skipping empty buckets
+func (h Histogram) String() string {
+	most := max(h.Wins, h.Losses)
+	for _, c := range h.Counts {
+		most = max(most, c)
+	}
+	bar := func(c int) string {
+		if most == 0 {
+			return ""
+		}
+		return strings.Repeat("#", (c*40+most-1)/most)
+	}
+
+	var sb strings.Builder
+	if h.Losses > 0 {
+		fmt.Fprintf(&sb, "%13s %6d %s\n", "loss", h.Losses, bar(h.Losses))
+	}
+	for i, c := range h.Counts {
+		if c > 0 {
+			lo := h.Low + i*h.Width
+			fmt.Fprintf(&sb, "[%5d,%5d) %6d %s\n", lo, lo+h.Width, c, bar(c))
+		}
+	}
+	if h.Wins > 0 {
+		fmt.Fprintf(&sb, "%13s %6d %s\n", "win", h.Wins, bar(h.Wins))
+	}
+	return sb.String()
+}
+
+// at returns the histogram of depth in hs, adding empty ones up to it
+func (hs *Histograms) at(list *[]Histogram, depth int) *Histogram {
+	for len(*list) <= depth {
+		*list = append(*list, Histogram{Width: hs.width})
+	}
+	return &(*list)[depth]
+}
+
+// observeStatic counts the heuristic value v of n, if histograms are collected
+func (s *search[T]) observeStatic(n *node[T], v int) {
+	if s.histograms == nil {
+		return
+	}
+	s.histograms.mu.Lock()
+	s.histograms.at(&s.histograms.Static, n.depth).add(v)
+	s.histograms.mu.Unlock()
+}
+
+// observeBacked counts the value of n, once its search has completed, if
+// histograms are collected and the value is exact
+func (s *search[T]) observeBacked(n *node[T], alpha, beta int) {
+	if s.histograms == nil || !n.searched || n.val <= alpha || n.val >= beta {
+		return
+	}
+	s.histograms.mu.Lock()
+	s.histograms.at(&s.histograms.Backed, n.depth).add(n.val)
+	s.histograms.mu.Unlock()
+}
@@ -0,0 +1,71 @@
+package minimax
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestHistogram tests bucketing, including negative values and proven outcomes.
+func TestHistogram(t *testing.T) {
+	h := Histogram{Width: 10}
+	for _, v := range []int{5, 12, 19, -1, -10, -11, 0, score - 3, 4 - score} {
+		h.add(v)
+	}
+	if want := []int{1, 2, 2, 2}; h.Low != -20 || !slices.Equal(h.Counts, want) {
+		t.Fatalf("Expected counts %v from -20, got %v from %d", want, h.Counts, h.Low)
+	}
+	if h.Wins != 1 || h.Losses != 1 || h.Total() != 9 {
+		t.Errorf("Expected a win, a loss and 9 values, got %+v", h)
+	}
+	if q := h.Quantile(0.5); q != 0 {
+		t.Errorf("Expected the median in [0, 10), got %d", q)
+	}
+	if q := h.Quantile(0); q != -20 {
+		t.Errorf("Expected the minimum in [-20, -10), got %d", q)
+	}
+	if m := h.Mean(); m != 11.5/7 {
+		t.Errorf("Expected a mean of 11.5/7, got %v", m)
+	}
+	if s := h.String(); !strings.Contains(s, "[  -20,  -10)      1") || !strings.Contains(s, "win      1") {
+		t.Errorf("Unexpected drawing:\n%s", s)
+	}
+}
+
+// TestHistograms tests that searches collect static values at the depth
+// limit and exact backed-up values at every depth.
+func TestHistograms(t *testing.T) {
+	state := pathState{depth: pathDepth}
+	h := WithHeuristic(func(s *pathState) int { return 100 * (int(s.path%7) - 3) })
+	mm := Make(&state, pathTerminal, pathUtility, pathSuccessors, true, WithLimits(Limits{MaxDepth: 3}), h, WithHistograms(100))
+	mm.Solve(pathState{})
+	res := mm.Result()
+
+	hs := res.Histograms
+	if hs == nil || len(hs.Static) != 4 || len(hs.Backed) != 4 {
+		t.Fatalf("Expected histograms for depths 0 to 3, got %+v", hs)
+	}
+	for depth := range 3 {
+		if n := hs.Static[depth].Total(); n != 0 {
+			t.Errorf("Expected no static values above the limit, got %d at depth %d", n, depth)
+		}
+	}
+	if n := hs.Static[3].Total(); n == 0 || n > res.Nodes {
+		t.Errorf("Expected up to %d static values at the limit, got %d", res.Nodes, n)
+	}
+	if lo, hi := hs.Static[3].Quantile(0), hs.Static[3].Quantile(1); lo < -300 || hi > 300 {
+		t.Errorf("Expected static values in [-300, 300], got [%d, %d]", lo, hi)
+	}
+	if root := hs.Backed[0]; root.Total() != 1 || root.Quantile(0.5) != floorDiv(res.Value, 100)*100 {
+		t.Errorf("Expected the root value %d, got %+v", res.Value, root)
+	}
+
+	nim := Make(&nimState{stones: 9, aiTurn: true}, nimTerminal, nimUtility, nimSuccessors, true, WithHistograms(1))
+	if hs := nim.Result().Histograms; hs.Backed[0].Wins != 1 || len(hs.Static) != 0 {
+		t.Errorf("Expected a proven win at the root and no static values, got %+v", hs)
+	}
+	nim = Make(&nimState{stones: 9, aiTurn: true}, nimTerminal, nimUtility, nimSuccessors, true)
+	if nim.Result().Histograms != nil {
+		t.Error("Expected no histograms without WithHistograms")
+	}
+}
@@ -0,0 +1,101 @@
+package minimax
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+)
+
+// History is a move-ordering table learned from earlier searches. Every time a
+// child turns out to be the best move of its parent, either by raising its
+// bound the most or by causing a cutoff, its weight at that depth grows, and
+// children are searched heaviest first when a node is expanded. Good moves
+// found in one search are then tried first in the next, which makes alpha-beta
+// prune more.
+//
+// A History can be shared by several searches, including parallel ones, and
+// keeps learning for as long as it is kept.
+type History[T comparable] struct {
+	mu      sync.Mutex
+	weights map[nodeKey[T]]int
+}
+
+// NewHistory returns an empty table
+func NewHistory[T comparable]() *History[T] {
+	return &History[T]{weights: make(map[nodeKey[T]]int)}
+}
+
+// WithHistory orders children by the weights learned in h, and updates h
+// while searching. Children with equal weights keep their successor order.
+//
+// Reordering children can change which of several equally good moves is
+// played, and a trace only replays against a table in the same state it was
+// recorded with.
+func WithHistory[T comparable](h *History[T]) Option {
+	return func(o *options) {
+		o.history = h
+	}
+}
+
+// Weight returns how often state, reached at the given depth, was the best move of its parent
+func (h *History[T]) Weight(state T, depth int) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.weights[nodeKey[T]{state: state, depth: depth}]
+}
+
+// Len returns the number of states with a weight
+func (h *History[T]) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.weights)
+}
+
+// Age halves every weight, forgetting those that reach zero, so that recent
+// searches count more than old ones. Call it between moves of a game.
+func (h *History[T]) Age() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for k, w := range h.weights {
+		if w /= 2; w == 0 {
+			delete(h.weights, k)
+		} else {
+			h.weights[k] = w
+		}
+	}
+}
+
+// Clear forgets everything learned
+func (h *History[T]) Clear() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	clear(h.weights)
+}
+
+// order sorts the children of n, heaviest first
+func (h *History[T]) order(n *node[T]) {
+	if h == nil || len(n.children) < 2 {
+		return
+	}
+
+	h.mu.Lock()
+	weights := make(map[*node[T]]int, len(n.children))
+	for _, child := range n.children {
+		weights[child] = h.weights[nodeKey[T]{state: *child.elem, depth: child.depth}]
+	}
+	h.mu.Unlock()
+
+	slices.SortStableFunc(n.children, func(a, b *node[T]) int {
+		return cmp.Compare(weights[b], weights[a])
+	})
+}
+
+// learn records that child was the best move of its parent
+func (h *History[T]) learn(child *node[T]) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	h.weights[nodeKey[T]{state: *child.elem, depth: child.depth}]++
+	h.mu.Unlock()
+}
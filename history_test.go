@@ -0,0 +1,48 @@
+package minimax
+
+import "testing"
+
+// TestHistoryOrdering tests that a learned ordering keeps the best move and prunes more.
+func TestHistoryOrdering(t *testing.T) {
+	state := pathState{depth: pathDepth - 9}
+	plain := Make(&state, pathTerminal, pathUtility, pathSuccessors, true)
+
+	h := NewHistory[pathState]()
+	first := Make(&state, pathTerminal, pathUtility, pathSuccessors, true, WithHistory(h))
+	if h.Len() == 0 {
+		t.Fatal("Expected the history to learn from the search")
+	}
+	second := Make(&state, pathTerminal, pathUtility, pathSuccessors, true, WithHistory(h))
+
+	if first.Result().Value != plain.Result().Value || second.Result().Value != plain.Result().Value {
+		t.Errorf("Expected value %d, got %d and %d",
+			plain.Result().Value, first.Result().Value, second.Result().Value)
+	}
+	if second.Result().Nodes >= plain.Result().Nodes {
+		t.Errorf("Expected fewer nodes than %d with a learned ordering, got %d",
+			plain.Result().Nodes, second.Result().Nodes)
+	}
+	best := second.Solve(state)
+	if h.Weight(*best, 1) == 0 {
+		t.Errorf("Expected the best move %v to have a weight", *best)
+	}
+}
+
+// TestHistoryAge tests that aging halves weights and forgets small ones.
+func TestHistoryAge(t *testing.T) {
+	h := NewHistory[nimState]()
+	a := &node[nimState]{elem: &nimState{stones: 1}, depth: 1}
+	b := &node[nimState]{elem: &nimState{stones: 2}, depth: 1}
+	for range 4 {
+		h.learn(a)
+	}
+	h.learn(b)
+
+	h.Age()
+	if w := h.Weight(*a.elem, 1); w != 2 {
+		t.Errorf("Expected weight 2, got %d", w)
+	}
+	if h.Len() != 1 {
+		t.Errorf("Expected a single weight left, got %d", h.Len())
+	}
+}
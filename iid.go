@@ -0,0 +1,62 @@
+package minimax
+
+import "slices"
+
+// WithInternalDeepening enables internal iterative deepening (IID): when a
+// node is expanded with no best move known for it, a search reduction plies
+// shallower (2 if reduction is not positive) is run from it first, and the
+// move it prefers is searched first. A good first move makes alpha-beta prune
+// the most, and the shallow search is cheap compared to the full one.
+//
+// Best moves are known from the previous iteration when SolveClock deepens,
+// so IID only runs for nodes that iteration did not reach. It needs a depth
+// limit to reduce, and is skipped where fewer than reduction+2 plies remain.
+func WithInternalDeepening(reduction int) Option {
+	if reduction <= 0 {
+		reduction = 2
+	}
+	return func(o *options) {
+		o.iid = reduction
+	}
+}
+
+// orderFirst moves the best known move from n to the front of its children:
+// the one found by the previous iteration if any, else the one recorded in
+// the transposition table (tableMove, may be nil), else the one found by a
+// reduced search when IID is enabled
+func (s *search[T]) orderFirst(n *node[T], tableMove *T) {
+	if len(n.children) < 2 {
+		return
+	}
+	move := s.hints[*n.elem]
+	if move == nil {
+		move = tableMove
+	}
+	if move == nil {
+		move = s.deepen(n)
+	}
+	if move == nil {
+		return
+	}
+
+	i := slices.IndexFunc(n.children, func(c *node[T]) bool { return *c.elem == *move })
+	if i > 0 {
+		first := n.children[i]
+		copy(n.children[1:i+1], n.children[:i])
+		n.children[0] = first
+	}
+}
+
+// deepen runs the reduced search of internal iterative deepening from n and
+// returns the move it found, or nil
+func (s *search[T]) deepen(n *node[T]) *T {
+	if s.iid == 0 || s.limits.MaxDepth == 0 || s.limits.MaxDepth-n.depth < s.iid+2 {
+		return nil
+	}
+
+	sub, ok := s.subsearch(n, s.limits.MaxDepth-s.iid, -score, score)
+	if !ok {
+		return nil
+	}
+	return sub.moveMap[*n.elem]
+}
@@ -0,0 +1,37 @@
+package minimax
+
+import "testing"
+
+// TestInternalDeepening tests that reduced searches reorder moves without changing the result.
+func TestInternalDeepening(t *testing.T) {
+	state := pathState{depth: pathDepth}
+	for _, depth := range []int{4, 7} {
+		limit := WithLimits(Limits{MaxDepth: depth})
+		h := WithHeuristic(func(s *pathState) int { return int(s.path%7) - 3 })
+		plain := Make(&state, pathTerminal, pathUtility, pathSuccessors, true, limit, h)
+		iid := Make(&state, pathTerminal, pathUtility, pathSuccessors, true, limit, h, WithInternalDeepening(2))
+
+		start := pathState{}
+		plain.Solve(start)
+		if iid.Solve(start) == nil || plain.Result().Value != iid.Result().Value {
+			t.Errorf("depth %d: expected value %d, got %+v", depth, plain.Result().Value, iid.Result())
+		}
+	}
+}
+
+// TestOrderFirst tests that the hinted move is moved to the front of the children.
+func TestOrderFirst(t *testing.T) {
+	root := &pathState{}
+	n := &node[pathState]{elem: root}
+	for _, succ := range pathSuccessors(root) {
+		n.children = append(n.children, &node[pathState]{elem: succ, depth: 1})
+	}
+	s := &search[pathState]{config: &config[pathState]{hints: map[pathState]*pathState{*root: n.children[2].elem}}}
+
+	s.orderFirst(n, nil)
+	for i, want := range []uint64{2, 0, 1} {
+		if n.children[i].elem.path != want {
+			t.Fatalf("Expected children in order 2, 0, 1, got %v", n.children)
+		}
+	}
+}
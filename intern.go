@@ -0,0 +1,97 @@
+package minimax
+
+import "sync"
+
+// Interner stores each distinct state once, so that a state reached along
+// many paths, at several depths or by several searches is kept in a single
+// copy that the search graph, the move cache and principal variations all
+// point to, instead of an allocation per path. For games whose states hold
+// pointers, such as boards copied with WithClone, it saves both the clones
+// and the memory they take, which dominates strong solves.
+//
+// Interned states are shared, so they must not be modified. An Interner can
+// be shared by several searches, including parallel ones, as long as they
+// play the same game. It only grows; Clear empties it between games.
+type Interner[T comparable] struct {
+	mu     sync.Mutex
+	states map[T]internedState[T]
+	hits   int
+	misses int
+}
+
+// internedState is the shared copy of a state
+type internedState[T comparable] struct {
+	p     *T
+	owned bool // Whether p was made by the function of WithClone, rather than returned by the game
+}
+
+// NewInterner returns an empty interner
+func NewInterner[T comparable]() *Interner[T] {
+	return &Interner[T]{states: make(map[T]internedState[T])}
+}
+
+// WithInterner stores the states the engine keeps through in: the states of
+// the search graph, and the states and moves of the cache
+func WithInterner[T comparable](in *Interner[T]) Option {
+	return func(o *options) {
+		o.interner = in
+	}
+}
+
+// Intern returns the shared copy of state, making one from state if it is new
+func (in *Interner[T]) Intern(state *T) *T {
+	return in.intern(state, func(s *T) *T {
+		c := *s
+		return &c
+	}, false)
+}
+
+// intern returns the shared copy of state, storing own(state) if it is new.
+// If owned, the copy must have been made by own: a copy stored from the
+// game's own pointer is then replaced by one made by own.
+func (in *Interner[T]) intern(state *T, own func(*T) *T, owned bool) *T {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if sh, ok := in.states[*state]; ok && (sh.owned || !owned) {
+		in.hits++
+		return sh.p
+	}
+	in.misses++
+	sh := internedState[T]{p: own(state), owned: owned}
+	in.states[*state] = sh
+	in.states[*sh.p] = sh // A clone of a state holding pointers is only equal to itself
+	return sh.p
+}
+
+// Len returns the number of shared copies stored
+func (in *Interner[T]) Len() int {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return in.misses
+}
+
+// Stats returns how many states were found already stored, each saving a
+// copy, and how many had to be stored
+func (in *Interner[T]) Stats() (hits, misses int) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return in.hits, in.misses
+}
+
+// Clear forgets every state and resets the statistics
+func (in *Interner[T]) Clear() {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	clear(in.states)
+	in.hits, in.misses = 0, 0
+}
+
+// shared returns the shared copy of the successor state, if states are
+// interned. It may be the game's own pointer, which copyOf does not hand out
+// when WithClone was given.
+func (cf *config[T]) shared(state *T) *T {
+	if cf.interner == nil {
+		return state
+	}
+	return cf.interner.intern(state, func(s *T) *T { return s }, false)
+}
@@ -0,0 +1,113 @@
+package minimax
+
+import "testing"
+
+// distinctMoves counts the states the moves of mm's cache point to, and the
+// copies of them it holds
+func distinctMoves[T comparable](mm Minimax[T]) (states, copies int) {
+	seen := make(map[T]bool)
+	ptrs := make(map[*T]bool)
+	for _, move := range mm.moveMap {
+		seen[*move] = true
+		ptrs[move] = true
+	}
+	return len(seen), len(ptrs)
+}
+
+// TestInterner tests that interned searches keep one copy of each state.
+func TestInterner(t *testing.T) {
+	root := nimState{stones: 15, aiTurn: true}
+	in := NewInterner[nimState]()
+	mm := Make(&root, nimTerminal, nimUtility, nimSuccessors, true, WithInterner(in))
+	plain := Make(&root, nimTerminal, nimUtility, nimSuccessors, true)
+
+	if states, copies := distinctMoves(plain); copies == states {
+		t.Fatalf("Expected copies of the same moves without interning, got %d for %d states", copies, states)
+	}
+	if states, copies := distinctMoves(mm); copies != states {
+		t.Errorf("Expected a single copy of each move, got %d for %d states", copies, states)
+	}
+	if hits, _ := in.Stats(); hits == 0 {
+		t.Error("Expected states to be found already interned")
+	}
+
+	// The graph shares the copies of the cache
+	for state, move := range mm.moveMap {
+		if p := in.Intern(&state); p == nil || *p != state {
+			t.Fatalf("Expected %v to be interned", state)
+		}
+		if in.Intern(move) != move {
+			t.Errorf("Expected the move %v to be the shared copy", *move)
+		}
+	}
+	if got, want := mm.Solve(root), plain.Solve(root); *got != *want {
+		t.Errorf("Expected %v, got %v", *want, *got)
+	}
+}
+
+// TestInternerClones tests that interning saves clones of states holding pointers.
+func TestInternerClones(t *testing.T) {
+	terminal := func(s *boardState) bool { return s.board[0] >= 4 }
+	utility := func(s *boardState) int { return 1 }
+	successors := func(s *boardState) []*boardState {
+		var succ []*boardState
+		for i := range s.board {
+			next := cloneBoard(s)
+			next.board[i]++
+			next.aiTurn = !s.aiTurn
+			succ = append(succ, next)
+		}
+		return succ
+	}
+	clones := func(opts ...Option) int {
+		n := 0
+		state := boardState{board: &[3]int{}, aiTurn: true}
+		opts = append(opts, WithClone(func(s *boardState) *boardState {
+			n++
+			return cloneBoard(s)
+		}), WithLimits(Limits{MaxDepth: 4}))
+		mm := Make(&state, terminal, utility, successors, true, opts...)
+		mm.ClearCache()
+		mm.Solve(state)
+		return n
+	}
+
+	plain, interned := clones(), clones(WithInterner(NewInterner[boardState]()))
+	if interned >= plain {
+		t.Errorf("Expected fewer clones with interning, got %d, %d without", interned, plain)
+	}
+}
+
+// TestInternerCloneOwned tests that the cache never keeps the game's own
+// pointers when WithClone is given, even for states the graph interned first.
+func TestInternerCloneOwned(t *testing.T) {
+	var returned []*boardState
+	terminal := func(s *boardState) bool { return s.board[0] >= 3 }
+	utility := func(s *boardState) int { return 1 }
+	successors := func(s *boardState) []*boardState {
+		var succ []*boardState
+		for i := range s.board {
+			next := cloneBoard(s)
+			next.board[i]++
+			next.aiTurn = !s.aiTurn
+			succ = append(succ, next)
+		}
+		returned = append(returned, succ...)
+		return succ
+	}
+	state := boardState{board: &[3]int{}, aiTurn: true}
+	mm := Make(&state, terminal, utility, successors, true, WithClone(cloneBoard), WithInterner(NewInterner[boardState]()))
+
+	boards := make(map[*boardState][3]int)
+	for _, move := range mm.moveMap {
+		boards[move] = *move.board
+	}
+	for _, s := range returned {
+		s.board[0] = 99 // The game reuses its memory
+	}
+	for move, board := range boards {
+		if *move.board != board {
+			t.Fatalf("Expected the cached move %v to keep its board, got %v", board, *move.board)
+		}
+	}
+}
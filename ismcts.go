@@ -0,0 +1,228 @@
+package minimax
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// defaultIterations bounds ISMCTS searches given no node or time limit
+const defaultIterations = 1000
+
+// ISMCTS is Information Set Monte Carlo Tree Search, an engine for games of
+// imperfect information. Unlike Determinized, which searches every sample
+// separately, it grows a single tree over moves: each iteration samples a
+// state consistent with what the AI knows, walks the tree down using only the
+// moves legal in that sample, adds a node, and scores a random playout.
+// Statistics are thereby shared between samples, and the engine does not
+// assume it could see the hidden information.
+//
+// Moves are identified across samples by M, as for Determinized. The side to
+// move is given by Game.ToMove if set, and otherwise alternates.
+type ISMCTS[I any, T, M comparable] struct {
+	Game        Game[T]                      // Rules of the game with all information revealed
+	Sample      func(info I, r *rand.Rand) T // Returns a state consistent with info
+	Move        func(from, to *T) M          // Identifies a move independently of hidden information
+	Limits      Limits                       // MaxNodes caps iterations (1000, with Stopped left StopNone, if neither it nor MaxTime is set), MaxDepth caps playouts
+	Exploration float64                      // UCB exploration constant; √2 if not positive
+	Policy      Policy[T]                    // Playout policy, RandomPolicy if nil
+	Progress    func(Progress[M])            // Called every 100ms while searching and when done, may be nil
+	Rand        *rand.Rand                   // Source of samples and playouts, seeded with 1 if nil
+}
+
+// isNode is a node of the ISMCTS tree, reached by playing move from its parent
+type isNode[M comparable] struct {
+	move     M
+	parent   *isNode[M]
+	children []*isNode[M]
+	isMax    bool    // Whether the AI moved to reach this node
+	visits   int     // Iterations through the node
+	avail    int     // Iterations where the node's move was legal
+	reward   float64 // Total reward, for the player who moved to reach the node
+}
+
+// child returns the child reached by move, or nil
+func (n *isNode[M]) child(move M) *isNode[M] {
+	for _, c := range n.children {
+		if c.move == move {
+			return c
+		}
+	}
+	return nil
+}
+
+// Solve returns the move to play in the information set info, with isMax true
+// if it is the AI's turn: the most visited move from the root. The Result
+// counts iterations as nodes and gives an estimate of the value of the
+// position on the heuristic scale, which is never proven. It returns false if
+// no sample had a legal move.
+func (e ISMCTS[I, T, M]) Solve(info I, isMax bool) (M, Result, bool) {
+	r := e.Rand
+	if r == nil {
+		r = rand.New(rand.NewSource(1))
+	}
+	c := e.Exploration
+	if c <= 0 {
+		c = math.Sqrt2
+	}
+	iterations := e.Limits.MaxNodes
+	if iterations <= 0 && e.Limits.MaxTime <= 0 {
+		iterations = defaultIterations
+	}
+
+	start := time.Now()
+	lastReport := start
+	root := &isNode[M]{isMax: !isMax}
+	res := Result{Unknown: true}
+
+	for ; iterations <= 0 || res.Nodes < iterations; res.Nodes++ {
+		if e.Limits.MaxTime > 0 && res.Nodes%64 == 0 && time.Since(start) >= e.Limits.MaxTime {
+			res.Stopped = StopTime
+			break
+		}
+		depth := e.iterate(root, e.Sample(info, r), isMax, c, r)
+		res.Depth = max(res.Depth, depth)
+
+		if e.Progress != nil && time.Since(lastReport) >= defaultProgressInterval {
+			lastReport = time.Now()
+			e.report(root, res, isMax, start, false)
+		}
+	}
+	if e.Limits.MaxNodes > 0 && res.Nodes >= iterations {
+		res.Stopped = StopNodes // Only the caller's own limit counts, not defaultIterations
+	}
+	res.Elapsed = time.Since(start)
+
+	best := e.best(root)
+	if best != nil {
+		res.Value = best.value(isMax)
+	}
+	if e.Progress != nil {
+		e.report(root, res, isMax, start, true)
+	}
+	if best == nil {
+		var zero M
+		return zero, res, false
+	}
+	return best.move, res, true
+}
+
+// iterate runs one iteration from the root on the sampled state, and returns
+// the depth of the tree node it ended at
+func (e ISMCTS[I, T, M]) iterate(root *isNode[M], state T, isMax bool, c float64, r *rand.Rand) int {
+	n, depth := root, 0
+	for {
+		if e.Game.IsTerminal(&state) {
+			break
+		}
+		succ := e.Game.Successors(&state)
+		if len(succ) == 0 {
+			break
+		}
+		toMove := isMax
+		if e.Game.ToMove != nil {
+			toMove = e.Game.ToMove(&state)
+		}
+
+		// Moves legal in this sample, expanding the first one not in the tree yet
+		var legal []*isNode[M]
+		var states []*T
+		var expanded *isNode[M]
+		var expandedState *T
+		for _, next := range succ {
+			m := e.Move(&state, next)
+			child := n.child(m)
+			if child == nil && expanded == nil {
+				child = &isNode[M]{move: m, parent: n, isMax: toMove}
+				n.children = append(n.children, child)
+				expanded, expandedState = child, next
+			}
+			if child != nil {
+				child.avail++
+				legal = append(legal, child)
+				states = append(states, next)
+			}
+		}
+
+		depth++
+		if expanded != nil {
+			n, state = expanded, *expandedState
+			break
+		}
+		i := e.selectChild(legal, c)
+		n, state = legal[i], *states[i]
+		isMax = !toMove
+	}
+
+	// Score a playout from the new node and back it up
+	final, _, finished := Playout(e.Game, state, e.Policy, r, e.Limits.MaxDepth)
+	reward := 0.5
+	if finished {
+		reward = (float64(sign(e.Game.Utility(&final))) + 1) / 2
+	}
+	for ; n != nil; n = n.parent {
+		n.visits++
+		if n.isMax {
+			n.reward += reward
+		} else {
+			n.reward += 1 - reward
+		}
+	}
+	return depth
+}
+
+// selectChild picks the legal child with the best upper confidence bound,
+// counting how often each was available rather than how often its parent was visited
+func (e ISMCTS[I, T, M]) selectChild(legal []*isNode[M], c float64) int {
+	best, bestScore := 0, math.Inf(-1)
+	for i, child := range legal {
+		if child.visits == 0 {
+			return i
+		}
+		ucb := child.reward/float64(child.visits) + c*math.Sqrt(math.Log(float64(child.avail))/float64(child.visits))
+		if ucb > bestScore {
+			best, bestScore = i, ucb
+		}
+	}
+	return best
+}
+
+// value estimates the value of n for the AI on the heuristic scale from its
+// average reward, given whether the AI moved to reach it
+func (n *isNode[M]) value(isMax bool) int {
+	v := int(math.Round((2*n.reward/float64(n.visits) - 1) * MaxHeuristic))
+	if !isMax {
+		return -v
+	}
+	return v
+}
+
+// best returns the most visited child of the root, or nil
+func (e ISMCTS[I, T, M]) best(root *isNode[M]) *isNode[M] {
+	var best *isNode[M]
+	for _, child := range root.children {
+		if best == nil || child.visits > best.visits {
+			best = child
+		}
+	}
+	return best
+}
+
+// report sends the current state of the search to the progress callback
+func (e ISMCTS[I, T, M]) report(root *isNode[M], res Result, isMax bool, start time.Time, done bool) {
+	p := Progress[M]{
+		Depth:   res.Depth,
+		Nodes:   res.Nodes,
+		Elapsed: time.Since(start),
+		Done:    done,
+	}
+	if best := e.best(root); best != nil {
+		move := best.move
+		p.BestMove = &move
+		p.Score = best.value(isMax)
+	}
+	if secs := p.Elapsed.Seconds(); secs > 0 {
+		p.NPS = int(float64(p.Nodes) / secs)
+	}
+	e.Progress(p)
+}
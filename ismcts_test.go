@@ -0,0 +1,71 @@
+package minimax
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestISMCTS tests that ISMCTS follows the belief about hidden information and reports progress.
+func TestISMCTS(t *testing.T) {
+	game := Game[boxState]{
+		IsTerminal: func(s *boxState) bool { return s.pick >= 0 },
+		Utility: func(s *boxState) int {
+			if s.pick == s.prize {
+				return 1
+			}
+			return -1
+		},
+		Successors: func(s *boxState) []*boxState {
+			var succ []*boxState
+			for box := range 3 {
+				succ = append(succ, &boxState{prize: s.prize, pick: box})
+			}
+			return succ
+		},
+	}
+	sample := func(belief float64, r *rand.Rand) boxState {
+		if r.Float64() < belief {
+			return boxState{prize: 2, pick: -1}
+		}
+		return boxState{prize: r.Intn(2), pick: -1}
+	}
+
+	var reports []Progress[int]
+	e := ISMCTS[float64, boxState, int]{
+		Game:     game,
+		Sample:   sample,
+		Move:     func(from, to *boxState) int { return to.pick },
+		Limits:   Limits{MaxNodes: 500},
+		Progress: func(p Progress[int]) { reports = append(reports, p) },
+	}
+	move, res, ok := e.Solve(0.7, true)
+	if !ok || move != 2 {
+		t.Errorf("Expected box 2, got %d", move)
+	}
+	if res.Nodes != 500 || res.Stopped != StopNodes || !res.Unknown || res.Value <= 0 {
+		t.Errorf("Unexpected result %+v", res)
+	}
+	if len(reports) == 0 || !reports[len(reports)-1].Done || *reports[len(reports)-1].BestMove != 2 {
+		t.Errorf("Expected a final report for box 2, got %+v", reports)
+	}
+}
+
+// TestISMCTSGame tests ISMCTS on a perfect-information game, where it should find the winning move.
+func TestISMCTSGame(t *testing.T) {
+	game := Game[nimState]{IsTerminal: nimTerminal, Utility: nimUtility, Successors: nimSuccessors}
+	e := ISMCTS[int, nimState, int]{
+		Game:   game,
+		Sample: func(stones int, r *rand.Rand) nimState { return nimState{stones: stones, aiTurn: true} },
+		Move:   func(from, to *nimState) int { return from.stones - to.stones },
+		Limits: Limits{MaxNodes: 3000},
+	}
+	if take, _, ok := e.Solve(6, true); !ok || take != 2 {
+		t.Errorf("Expected to take 2 stones, took %d", take)
+	}
+
+	// Running out of the default iterations is not a limit of the caller's
+	e.Limits = Limits{}
+	if _, res, _ := e.Solve(6, true); res.Nodes != defaultIterations || res.Stopped != StopNone {
+		t.Errorf("Expected %d iterations and no limit reported, got %+v", defaultIterations, res)
+	}
+}
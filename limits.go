@@ -0,0 +1,170 @@
+package minimax
+
+import "time"
+
+// CheckInterval is how many nodes are visited between checks of the clock
+const CheckInterval = 1024
+
+// Limits bounds the work done by a search. A zero field means no limit.
+//
+// MaxNodes and MaxTime are hard limits: the search stops as soon as either is
+// exceeded and keeps the best root move found so far. Nodes are counted
+// exactly, while the clock is only read every CheckInterval nodes, so MaxTime
+// may be overshot slightly. If both are exceeded at the same time, MaxNodes
+// is reported.
+//
+// MaxDepth is a soft limit: nodes at that depth are not expanded and are
+// scored according to WithCutoff, but the search still runs to completion. It is reported
+// only if no hard limit stopped the search and at least one branch was cut.
+type Limits struct {
+	MaxDepth int           // Maximum depth (in plies) to expand
+	MaxNodes int           // Maximum number of nodes to visit
+	MaxTime  time.Duration // Maximum time to search
+}
+
+// StopReason tells which limit, if any, terminated a search
+type StopReason int
+
+const (
+	StopNone     StopReason = iota // The search explored the whole tree
+	StopDepth                      // The search completed, but MaxDepth truncated some branches
+	StopNodes                      // The search was aborted by MaxNodes
+	StopTime                       // The search was aborted by MaxTime
+	StopCanceled                   // The search was stopped by the caller
+	StopPanic                      // The search was aborted by a panic, recovered by WithFallback
+)
+
+// String returns the name of the stop reason
+func (r StopReason) String() string {
+	switch r {
+	case StopNone:
+		return "none"
+	case StopDepth:
+		return "depth"
+	case StopNodes:
+		return "nodes"
+	case StopTime:
+		return "time"
+	case StopCanceled:
+		return "canceled"
+	case StopPanic:
+		return "panic"
+	default:
+		return "unknown"
+	}
+}
+
+// Result summarises a completed (or aborted) search
+type Result struct {
+	Value      int           // Value of the searched state for the AI, 0 if a hard limit stopped the search before any move was evaluated
+	Nodes      int           // Number of nodes visited
+	Depth      int           // Deepest ply reached
+	Elapsed    time.Duration // Time spent searching
+	Stopped    StopReason    // Which limit terminated the search
+	Unknown    bool          // Whether Value depends on branches cut off by MaxDepth, or a hard limit stopped the search
+	AutoDepth  int           // MaxDepth chosen by WithAutoDepth, 0 if none was
+	Histograms *Histograms   // Values seen by depth, nil unless WithHistograms was given
+	OnlyMove   bool          // Whether the best move is forced, checked if WithOnlyMove was given
+	RootMoves  []RootMove    // Effort spent on each move, in successor order, nil unless WithRootStats was given
+	Retained   int           // Most nodes held in memory at once, counted if WithMemoryBound was given
+	Discarded  int           // Nodes discarded to keep within WithMemoryBound
+	Fallback   bool          // Whether the move was picked by the WithFallback policy, the search having failed
+	Err        error         // Error raised while searching, such as a failed trace write or a replay divergence
+
+	tree any // Root of the explored tree, a *node[T], kept if WithExplored was given
+}
+
+// WithLimits bounds the search by depth, node count and time
+func WithLimits(l Limits) Option {
+	return func(o *options) {
+		o.limits = l
+	}
+}
+
+// visit counts a node, returning false if a hard limit stops the search before it
+func (s *search[T]) visit(n *node[T]) bool {
+	if s.halted() {
+		return false
+	}
+	s.handle.step()
+	if s.handle.stopping() {
+		s.halt(StopCanceled)
+		return false
+	}
+
+	nodes := s.nodes.Add(1)
+	if s.limits.MaxNodes > 0 && nodes > int64(s.limits.MaxNodes) {
+		s.nodes.Add(-1)
+		s.halt(StopNodes)
+		return false
+	}
+	if s.rootStats {
+		s.countBranch(n)
+	}
+	if nodes%CheckInterval == 0 && !s.checkClock() {
+		return false
+	}
+
+	for depth := int64(n.depth); ; {
+		deepest := s.maxDepth.Load()
+		if depth <= deepest || s.maxDepth.CompareAndSwap(deepest, depth) {
+			break
+		}
+	}
+	return true
+}
+
+// checkClock runs the periodic time-based tasks, returning false if the time limit was exceeded
+func (s *search[T]) checkClock() bool {
+	if s.deadline.IsZero() && s.progress == nil {
+		return true
+	}
+
+	now := time.Now()
+	if !s.deadline.IsZero() && now.After(s.deadline) {
+		s.halt(StopTime)
+		return false
+	}
+	s.tick(now)
+	return true
+}
+
+// halt stops the search for the given reason, unless it was already stopped
+func (s *search[T]) halt(reason StopReason) {
+	s.stopped.CompareAndSwap(int32(StopNone), int32(reason))
+}
+
+// halted reports whether a hard limit stopped the search
+func (s *search[T]) halted() bool {
+	return s.stopped.Load() != int32(StopNone)
+}
+
+// result builds the Result of the search rooted at root
+func (s *search[T]) result(root *node[T]) *Result {
+	stopped := StopReason(s.stopped.Load())
+	if stopped == StopNone && s.truncated.Load() {
+		stopped = StopDepth
+	}
+	val, unknown := root.val, root.unknown
+	if stopped != StopNone && stopped != StopDepth {
+		// A search cut short is never exact, and without a finished move
+		// the root holds no value at all
+		unknown = true
+		if root.bestMove == nil {
+			val = 0
+		}
+	}
+	return &Result{
+		Value:   val,
+		Nodes:   int(s.nodes.Load()),
+		Depth:   int(s.maxDepth.Load()),
+		Elapsed: time.Since(s.start),
+		Stopped: stopped,
+		Unknown: unknown,
+		Err:     s.tracer.error(),
+
+		Histograms: s.histograms,
+		Retained:   s.retained,
+		Discarded:  s.discarded,
+	}
+}
@@ -0,0 +1,86 @@
+package minimax
+
+import (
+	"testing"
+	"time"
+)
+
+// TestLimitsNone tests that an unlimited search explores the whole tree.
+func TestLimitsNone(t *testing.T) {
+	state := nimState{stones: 6, aiTurn: true}
+	mm := Make(&state, nimTerminal, nimUtility, nimSuccessors, true)
+
+	res := mm.Result()
+	if res.Stopped != StopNone {
+		t.Errorf("Expected StopNone, got %v", res.Stopped)
+	}
+	if res.Value <= 0 {
+		t.Errorf("Expected a winning value with 6 stones, got %d", res.Value)
+	}
+	if move := mm.Solve(state); move == nil || move.stones != 4 {
+		t.Errorf("Expected to leave 4 stones, got %v", move)
+	}
+}
+
+// TestLimitsDepth tests that a depth limit truncates the search without aborting it.
+func TestLimitsDepth(t *testing.T) {
+	state := nimState{stones: 20, aiTurn: true}
+	mm := Make(&state, nimTerminal, nimUtility, nimSuccessors, true,
+		WithLimits(Limits{MaxDepth: 3}))
+
+	res := mm.Result()
+	if res.Stopped != StopDepth {
+		t.Errorf("Expected StopDepth, got %v", res.Stopped)
+	}
+	if res.Depth > 3 {
+		t.Errorf("Expected depth at most 3, got %d", res.Depth)
+	}
+	if mm.Solve(state) == nil {
+		t.Error("Expected a best move, got nil")
+	}
+}
+
+// TestLimitsNodes tests that a node limit aborts the search and keeps the best move so far.
+func TestLimitsNodes(t *testing.T) {
+	state := pathState{}
+	mm := Make(&state, pathTerminal, pathUtility, pathSuccessors, true,
+		WithLimits(Limits{MaxDepth: 10, MaxNodes: 100}))
+
+	res := mm.Result()
+	if res.Stopped != StopNodes {
+		t.Errorf("Expected StopNodes, got %v", res.Stopped)
+	}
+	if res.Nodes != 100 {
+		t.Errorf("Expected exactly 100 nodes, got %d", res.Nodes)
+	}
+	if !res.Unknown {
+		t.Errorf("Expected a value depending on the limit, got %+v", res)
+	}
+
+	// Stopped before any move from the state was evaluated
+	nim := nimState{stones: 40, aiTurn: true}
+	for nodes := 1; nodes <= 5; nodes++ {
+		mm := Make(&nim, nimTerminal, nimUtility, nimSuccessors, true, WithLimits(Limits{MaxDepth: 30, MaxNodes: nodes}))
+		if res := mm.Result(); res.Value != 0 || !res.Unknown || mm.Solve(nim) != nil {
+			t.Errorf("%d nodes: expected a neutral unknown value and no move, got %+v", nodes, res)
+		}
+	}
+}
+
+// TestLimitsTime tests that a time limit aborts a search that would otherwise not finish.
+func TestLimitsTime(t *testing.T) {
+	state := pathState{}
+	mm := Make(&state, pathTerminal, pathUtility, pathSuccessors, true,
+		WithLimits(Limits{MaxTime: 50 * time.Millisecond}))
+
+	res := mm.Result()
+	if res.Stopped != StopTime {
+		t.Errorf("Expected StopTime, got %v", res.Stopped)
+	}
+	if res.Elapsed > time.Second {
+		t.Errorf("Expected the search to stop quickly, took %v", res.Elapsed)
+	}
+	if !res.Unknown {
+		t.Errorf("Expected a value depending on the limit, got %+v", res)
+	}
+}
@@ -0,0 +1,73 @@
+package minimax
+
+// Entrant is a named engine configuration taking part in matches
+type Entrant struct {
+	Name    string
+	Options []Option
+}
+
+// GameResult is the outcome of a game between two engine configurations
+type GameResult[T comparable] struct {
+	Final    T    // Final state
+	Plies    int  // Number of moves played
+	Finished bool // False if the game was stopped by the move limit
+	Outcome  int  // 1 if the max side won, -1 if the min side won, 0 for a draw or unfinished game
+}
+
+// PlayGame plays a game from start where maxSide chooses the moves of the AI
+// (max) player and minSide those of its opponent. Each move is found with a
+// new search from the current position. The game ends at a terminal state, a
+// state without successors, or after maxPlies moves if maxPlies is positive.
+func PlayGame[T comparable](g Game[T], start Position[T], maxSide, minSide Entrant, maxPlies int) GameResult[T] {
+	return playGame(g, start, maxSide, minSide, maxPlies, nil)
+}
+
+// playGame plays a game, recording its moves if rec is not nil
+func playGame[T comparable](g Game[T], start Position[T], maxSide, minSide Entrant, maxPlies int, rec *Recorder[T]) GameResult[T] {
+	pos := start
+	for plies := 0; ; plies++ {
+		state := pos.State
+		if g.IsTerminal(&state) || len(g.Successors(&state)) == 0 {
+			res := GameResult[T]{Final: state, Plies: plies, Finished: true, Outcome: sign(g.Utility(&state))}
+			if rec != nil {
+				rec.Finish(res.Outcome)
+			}
+			return res
+		}
+		if maxPlies > 0 && plies >= maxPlies {
+			return GameResult[T]{Final: state, Plies: plies}
+		}
+
+		side := minSide
+		if pos.IsMax {
+			side = maxSide
+		}
+		m := g.Make(&state, pos.IsMax, side.Options...)
+		var move *T
+		if rec != nil {
+			move = rec.Solve(m, state, side.Name)
+		} else {
+			move = m.Solve(state)
+		}
+		if move == nil {
+			// The search gave up without a move, play the first legal one
+			move = g.Successors(&state)[0]
+			if rec != nil {
+				rec.Move(*move, side.Name)
+			}
+		}
+		pos = Position[T]{State: *move, IsMax: !pos.IsMax}
+	}
+}
+
+// sign returns -1, 0 or 1 depending on the sign of x
+func sign(x int) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,102 @@
+package minimax
+
+import (
+	"cmp"
+	"slices"
+)
+
+// WithMemoryBound keeps the search graph to about budget nodes (at least 1),
+// in the spirit of SMA*, so that long searches degrade gracefully instead of
+// running out of memory. Whenever the search of a node completes with more
+// nodes held than budget, the subtrees of its least promising children are
+// discarded first, then that of its best child if still needed. A discarded
+// child keeps its own value and the window it was searched with, so the
+// bound it proved still settles later searches of it; only if a wider window
+// asks for more is its subtree searched again.
+//
+// Best moves found are kept regardless, so Solve and Lookup are unaffected.
+// Nodes under search, including those of the current path, are never
+// discarded, so the budget can be exceeded by what a single path expands.
+// Result.Discarded and Result.Retained tell how hard the bound pressed.
+func WithMemoryBound(budget int) Option {
+	return func(o *options) {
+		o.memoryBound = max(budget, 1)
+	}
+}
+
+// forget discards subtrees below n, whose search just completed, while the
+// search graph holds more nodes than the memory bound
+func (s *search[T]) forget(n *node[T]) {
+	if s.memoryBound == 0 || n.from == nil || s.halted() {
+		return
+	}
+	s.tableMu.Lock()
+	defer s.tableMu.Unlock()
+	if len(s.table) <= s.memoryBound {
+		return
+	}
+
+	// Least promising first for the side to move at n, then unsearched ones
+	children := slices.Clone(n.children)
+	side := 1
+	if !n.isMax {
+		side = -1
+	}
+	slices.SortStableFunc(children, func(a, b *node[T]) int {
+		if a.searched != b.searched {
+			return cmp.Compare(btoi(a.searched), btoi(b.searched))
+		}
+		return cmp.Compare(side*a.val, side*b.val)
+	})
+	if i := slices.Index(children, n.bestMove); i >= 0 {
+		children = append(slices.Delete(children, i, i+1), n.bestMove)
+	}
+
+	for _, child := range children {
+		if len(s.table) <= s.memoryBound {
+			break
+		}
+		if child.mu.TryLock() { // Skip children searched by another worker, or an ancestor
+			s.collapse(child)
+			child.mu.Unlock()
+		}
+	}
+}
+
+// collapse drops the subtree below n, which must be locked, from the search
+// graph, keeping n and its value. s.tableMu must be held.
+func (s *search[T]) collapse(n *node[T]) {
+	if !n.expanded {
+		return
+	}
+	for _, child := range n.children {
+		s.drop(child)
+	}
+	n.children, n.successors, n.bestMove = nil, nil, nil
+	n.pending, n.prefetched = nil, false
+	n.narrowed, n.split, n.splitVals = false, false, nil
+	n.expanded = false
+}
+
+// drop removes n and the subtree below it from the table, unless it is being
+// searched or was dropped already. s.tableMu must be held.
+func (s *search[T]) drop(n *node[T]) {
+	key := nodeKey[T]{*n.elem, n.depth, n.cost}
+	if s.table[key] != n || !n.mu.TryLock() {
+		return
+	}
+	defer n.mu.Unlock()
+	delete(s.table, key)
+	s.discarded++
+	for _, child := range n.children {
+		s.drop(child)
+	}
+}
+
+// btoi converts b to 1 if true, else 0
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
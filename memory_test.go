@@ -0,0 +1,45 @@
+package minimax
+
+import "testing"
+
+// TestMemoryBound tests that a bounded search finds the same moves and values while holding fewer nodes.
+func TestMemoryBound(t *testing.T) {
+	heuristic := WithHeuristic(func(s *pathState) int { return int(s.path*0x9e3779b97f4a7c15>>40) % 200 })
+	limits := WithLimits(Limits{MaxDepth: 7})
+	for _, workers := range []int{1, 4} {
+		state := pathState{}
+		plain := Make(&state, pathTerminal, pathUtility, pathSuccessors, true,
+			limits, WithCutoff(CutoffHeuristic), heuristic, WithParallel(workers))
+		bounded := Make(&state, pathTerminal, pathUtility, pathSuccessors, true,
+			limits, WithCutoff(CutoffHeuristic), heuristic, WithParallel(workers), WithMemoryBound(100))
+
+		want, got := plain.Result(), bounded.Result()
+		if got.Value != want.Value || *bounded.Solve(state) != *plain.Solve(state) {
+			t.Errorf("%d workers: expected value %d, got %d", workers, want.Value, got.Value)
+		}
+		if got.Discarded == 0 || got.Retained > 200 {
+			t.Errorf("%d workers: expected at most 200 nodes held and some discarded, got %d and %d",
+				workers, got.Retained, got.Discarded)
+		}
+		if want.Retained != 0 || want.Discarded != 0 {
+			t.Errorf("%d workers: expected nothing counted without a bound, got %+v", workers, want)
+		}
+	}
+}
+
+// TestMemoryBoundTranspositions tests that values stay exact when discarded transpositions are searched again.
+func TestMemoryBoundTranspositions(t *testing.T) {
+	for _, stones := range []int{16, 21, 30} {
+		state := nimState{stones: stones, aiTurn: true}
+		plain := Make(&state, nimTerminal, nimUtility, nimSuccessors, true)
+		bounded := Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithMemoryBound(50))
+
+		want, got := plain.Result(), bounded.Result()
+		if got.Value != want.Value || *bounded.Solve(state) != *plain.Solve(state) {
+			t.Errorf("%d stones: expected value %d, got %d", stones, want.Value, got.Value)
+		}
+		if got.Discarded == 0 || got.Nodes < want.Nodes {
+			t.Errorf("%d stones: expected nodes discarded and searched again, got %+v", stones, got)
+		}
+	}
+}
@@ -14,7 +14,8 @@
 // - successors: a function that returns the possible moves from the state
 // - isMax: true if the initial state is a max node (AI's turn)
 //
-// 3. Create a Minimax instance using the `Make` function.
+// 3. Create a Minimax instance using the `Make` function, optionally passing options
+// such as `WithLimits` to bound the search.
 //
 // 4. Solve for the best move using the `Solve` method.
 //
@@ -24,8 +25,22 @@
 //	bestMove := mm.Solve(state)
 package minimax
 
+import (
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WinScore is the value of a win for the AI at the searched state. A win d
+// plies away is worth WinScore-d and a loss d plies away d-WinScore, see
+// Decided.
+const WinScore = 30000
+
 // score is the default score for the terminal state
-const score = 100
+const score = WinScore
 
 // Node represents a node in the minimax tree
 // T is the type of the state and must be comparable
@@ -39,36 +54,147 @@ type node[T comparable] struct {
 	bestMove *node[T]   // Best move to make (pointer)
 	isMax    bool       // True if the node is a max node
 	expanded bool       // Whether children have been generated
+	searched bool       // Whether val holds the result of a completed search
+	lo, hi   int        // Window val was searched with, to tell exact values from bounds
+	cyclic   bool       // Whether val depends on the path, through a repetition below
+	unknown  bool       // Whether val depends on branches cut off by a limit
+	from     *node[T]   // Parent the node was first reached from, nil for the root
+	narrowed bool       // Whether children were dropped by beam search or widening
+	branch   int32      // Index of the root move the node was first reached from, -1 for the root
+	cost     int        // Cost of the moves leading to the node, with WithCosts
+
+	successors []*node[T] // Children in successor order, kept when a transposition table is used
+
+	pending    []*T // Successors generated ahead of expansion
+	prefetched bool // Whether pending holds the successors
+
+	mu        sync.Mutex  // Held while the node is searched, since nodes are shared
+	split     bool        // Whether the younger children were searched in parallel
+	splitVals []nodeValue // Values of the children searched in parallel
+}
+
+// reusable reports whether the value from the last search of n can stand for a
+// search within the window [alpha, beta]. Exact values always can; bounds can
+// if they still fall outside the new window.
+func (n *node[T]) reusable(alpha, beta int) bool {
+	switch {
+	case !n.searched || n.cyclic:
+		return false
+	case n.val <= n.lo: // Upper bound
+		return n.val <= alpha
+	case n.val >= n.hi: // Lower bound
+		return n.val >= beta
+	default: // Exact
+		return true
+	}
+}
+
+// nodeValue is what searching a node tells its parent
+type nodeValue struct {
+	val     int  // Value of the node
+	unknown bool // Whether val depends on branches cut off by a limit
+}
+
+// nodeKey identifies a node in the search graph. The depth is part of the key
+// because terminal values depend on how deep they are found, and so is the
+// cost accumulated on the way with WithCosts.
+type nodeKey[T comparable] struct {
+	state T
+	depth int
+	cost  int
 }
 
 // Minimax is the main struct that holds the move map (cache)
 type Minimax[T comparable] struct {
-	moveMap map[T]*T // Cache
-	config  struct {
-		isTerminal func(*T) bool
-		utility    func(*T) int
-		successors func(*T) []*T
-		isMax      bool
-	}
+	moveMap map[T]*T      // Cache
+	config  config[T]     // Search configuration, reused when Solve has to search again
+	result  *Result       // Outcome of the most recent search
+	cache   *moveCache[T] // Counters and pins of moveMap, nil for internal searches
 }
 
-// Solve returns the best possible move for the given state
+// config holds the game functions and the options a search runs with
+type config[T comparable] struct {
+	options
+	isTerminal func(*T) bool
+	utility    func(*T) int
+	successors func(*T) []*T
+	isMax      bool
+
+	progress  func(Progress[T]) // Telemetry callback, may be nil
+	tracer    *tracer           // Trace recorder and replayer, may be nil
+	observer  func(Event, *T)   // Called for every search step, may be nil
+	heuristic func(*T) int      // Estimates the value of non-terminal states, may be nil
+	noisy     func(*T) []*T     // Noisy successors searched by quiescence, may be nil
+	endgame   func(*T) bool     // Tells states to solve exactly regardless of depth, may be nil
+	clone     func(*T) *T       // Makes independent copies of states, may be nil
+
+	successorsInto func(*T, []*T) []*T // Buffer-reusing successors, may be nil
+	buffers        *sync.Pool          // Buffers for successorsInto
+	successorCache *SuccessorCache[T]  // Successors generated so far, may be nil
+	aliasGuard     *aliasGuard[T]      // Last successors returned, for AliasCheck, may be nil
+	interner       *Interner[T]        // Shared copies of states, may be nil
+
+	history *History[T] // Learned move ordering, may be nil
+	hints   map[T]*T    // Best moves of a previous iteration, searched first, may be nil
+
+	counters counters[T] // Countermove table, may be nil
+	stages   []Stage[T]  // Staged evaluation, may be nil
+
+	tt   *Table          // Transposition table kept across searches, may be nil
+	hash func(*T) uint64 // Hashes states for tt
+
+	fallback func(*T) *T // Picks a move when the search fails, may be nil
+
+	cost     func(from, to *T) int // Cost of moves, may be nil
+	rootCost int                   // Cost accumulated on the way to the searched state
+	window   [2]int                // Alpha and beta of the searched state, the full window if zero
+
+	format func(*T) string // Writes states in events, may be nil
+}
+
+// Solve returns the best possible move for the given state.
+// It returns nil for terminal states, and also when a node or time limit
+// stopped the search before any move from the state was evaluated.
 func (m Minimax[T]) Solve(state T) *T {
 	if m.config.isTerminal(&state) {
 		return nil
 	}
 
 	bestMove := m.moveMap[state]
+	m.cache.count(bestMove != nil)
 	if bestMove != nil {
 		return bestMove
 	}
 
 	// No best move found, possibly pruned tree (from suboptimal move)
 	// Rerun algorithm to find best move
-	cf := m.config
-	newMM := Make(&state, cf.isTerminal, cf.utility, cf.successors, cf.isMax)
-	m.moveMap = newMM.moveMap
-	return m.Solve(state)
+	newMM := build(&state, m.config, nil)
+	maps.Copy(m.moveMap, newMM.moveMap)
+	*m.result = *newMM.result
+	m.cache.remember(state, m.result.Value)
+	return m.moveMap[state]
+}
+
+// Lookup returns the best move cached for state without searching
+func (m Minimax[T]) Lookup(state T) (*T, bool) {
+	move, ok := m.moveMap[state]
+	return move, ok
+}
+
+// Peek returns what Solve would return for state if it can answer without
+// searching: the cached best move, or nil for terminal states. It reports
+// false if Solve would have to search, so latency-sensitive callers can search
+// with SolveAsync instead of blocking.
+func (m Minimax[T]) Peek(state T) (*T, bool) {
+	if move := m.moveMap[state]; move != nil {
+		return move, true
+	}
+	return nil, m.config.isTerminal(&state)
+}
+
+// Result reports the outcome of the most recent search, including which limit (if any) ended it
+func (m Minimax[T]) Result() Result {
+	return *m.result
 }
 
 // Make creates a new Minimax struct. You must provide:
@@ -77,134 +203,488 @@ func (m Minimax[T]) Solve(state T) *T {
 // - utility: a function that should return -1 if the state is a loss for the AI, 1 if it's a win and 0 if it's a draw
 // - successors: a function that returns the possible moves from the state
 // - isMax: true if the initial state is a max node (AI's turn)
+//
+// Options such as WithLimits can be appended to tune the search.
 func Make[T comparable](state *T, isTerminal func(*T) bool,
-	utility func(*T) int, successors func(*T) []*T, isMax bool,
+	utility func(*T) int, successors func(*T) []*T, isMax bool, opts ...Option,
 ) Minimax[T] {
+	mm := build(state, newConfig(isTerminal, utility, successors, isMax, opts), nil)
+	mm.cache = &moveCache[T]{pinned: make(map[T]bool), values: make(map[T]int)}
+	if mm.moveMap[*state] != nil {
+		mm.cache.remember(*state, mm.result.Value)
+	}
+	return mm
+}
+
+// newConfig applies opts and checks that they fit together
+func newConfig[T comparable](isTerminal func(*T) bool,
+	utility func(*T) int, successors func(*T) []*T, isMax bool, opts []Option,
+) config[T] {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	cf := config[T]{
+		options:    o,
+		isTerminal: isTerminal,
+		utility:    utility,
+		successors: successors,
+		isMax:      isMax,
+
+		progress:  hook[func(Progress[T])](o.progress, "WithProgress"),
+		tracer:    newTracer(o.trace, o.replay),
+		observer:  hook[func(Event, *T)](o.observer, "WithObserver"),
+		heuristic: hook[func(*T) int](o.heuristic, "WithHeuristic"),
+		noisy:     hook[func(*T) []*T](o.noisy, "WithEvaluator"),
+		endgame:   hook[func(*T) bool](o.endgame, "WithEndgame"),
+		clone:     hook[func(*T) *T](o.clone, "WithClone"),
+
+		successorsInto: hook[func(*T, []*T) []*T](o.successorsInto, "WithSuccessorsInto"),
+		buffers:        &sync.Pool{New: func() any { return new([]*T) }},
+		successorCache: hook[*SuccessorCache[T]](o.successorCache, "WithSuccessorCache"),
+		interner:       hook[*Interner[T]](o.interner, "WithInterner"),
+
+		history: hook[*History[T]](o.history, "WithHistory"),
+
+		counters: hook[counters[T]](o.countermoves, "WithCountermoves"),
+		stages:   hook[[]Stage[T]](o.stages, "WithStages"),
+
+		tt:   o.tt,
+		hash: hook[func(*T) uint64](o.hash, "WithTable"),
+
+		fallback: hook[func(*T) *T](o.fallback, "WithFallback"),
+
+		cost: hook[func(from, to *T) int](o.cost, "WithCosts"),
+
+		format: hook[func(*T) string](o.format, "WithFormat"),
+	}
+	if cf.aliasing == AliasCheck {
+		cf.aliasGuard = new(aliasGuard[T])
+	}
+	if cf.cutoff == CutoffHeuristic && cf.heuristic == nil {
+		panic("minimax: CutoffHeuristic requires WithHeuristic")
+	}
+	if cf.razorMargins != nil && cf.heuristic == nil {
+		panic("minimax: WithRazoring requires WithHeuristic")
+	}
+	if cf.quiescence > 0 && (cf.noisy == nil || cf.cutoff != CutoffHeuristic) {
+		panic("minimax: WithQuiescence requires WithEvaluator with a NoisyEvaluator")
+	}
+	if cf.probCut.Depth > 0 && (cf.heuristic == nil || cf.probCut.Shallow >= cf.probCut.Depth) {
+		panic("minimax: WithProbCut requires WithHeuristic and a shallow search shallower than Depth")
+	}
+	if cf.cost != nil && cf.tt != nil {
+		panic("minimax: WithCosts cannot be combined with WithTable")
+	}
+	if cf.gamma != 0 && cf.tt != nil {
+		panic("minimax: WithDiscount cannot be combined with WithTable")
+	}
+	return cf
+}
+
+// build runs a search from state and wraps its results in a Minimax.
+// h is the handle of an asynchronous search, or nil.
+func build[T comparable](state *T, cf config[T], h *Search[T]) (mm Minimax[T]) {
+	if cf.fallback != nil {
+		defer func() { mm.fallBack(state) }()
+		defer recoverBuild(cf, &mm)
+	}
+
+	search := cf
+	depth := cf.autoDepth(state)
+	if depth > 0 {
+		search.limits.MaxDepth = depth
+	}
+	if cf.handicap != (Handicap{}) {
+		mm = handicapped(state, search, h)
+	} else {
+		mm = buildAt(state, search, h, 0)
+	}
+	mm.config = cf // Later searches pick their own depth
+	mm.result.AutoDepth = depth
+	return mm
+}
+
+// buildAt runs a search from state as if it was reached at the given depth,
+// so that its values and depth limit match those of a deeper search
+func buildAt[T comparable](state *T, cf config[T], h *Search[T], depth int) Minimax[T] {
+	alpha, beta := -score, score
+	if cf.window != [2]int{} {
+		alpha, beta = cf.window[0], cf.window[1]
+	}
 	root := &node[T]{
 		val:      0,
-		alpha:    -score,
-		beta:     score,
-		depth:    0,
-		isMax:    isMax,
-		elem:     state,
+		alpha:    alpha,
+		beta:     beta,
+		depth:    depth,
+		isMax:    cf.isMax,
+		elem:     cf.copyOf(state),
 		expanded: false,
+		branch:   -1,
+		cost:     cf.rootCost,
 	}
 
-	mp := make(map[T]*T)
-	minimax(root, isTerminal, utility, successors, mp)
+	s := &search[T]{
+		config: &cf,
+		mp:     make(map[T]*T),
+		start:  time.Now(),
+		handle: h,
+		table:  make(map[nodeKey[T]]*node[T]),
+	}
+	s.lastReport = s.start
+	h.watch(s)
+	defer h.watch(nil)
+	if cf.workers > 1 && !cf.tracing() {
+		s.sem = make(chan struct{}, cf.workers-1)
+	}
+	if cf.limits.MaxTime > 0 {
+		s.deadline = s.start.Add(cf.limits.MaxTime)
+	}
+	if cf.histWidth > 0 {
+		s.histograms = &Histograms{width: cf.histWidth}
+	}
+	only := false
+	func() {
+		defer s.catch()
+		s.minimax(root, alpha, beta, nil)
+		only = s.onlyMove && s.forced(root)
+	}()
+	s.mu.Lock()
+	s.report(time.Now(), true)
+	s.mu.Unlock()
+	s.emit(EventDone, root, 0)
 
+	res := s.result(root)
+	res.OnlyMove = only
+	if cf.explored {
+		res.tree = root
+	}
+	if err := s.failed.Load(); err != nil {
+		res.Err = errors.Join(res.Err, err)
+	}
+	if s.rootStats {
+		res.RootMoves = s.rootMoves(root)
+	}
 	return Minimax[T]{
-		moveMap: mp,
-		config: struct {
-			isTerminal func(*T) bool
-			utility    func(*T) int
-			successors func(*T) []*T
-			isMax      bool
-		}{
-			isTerminal: isTerminal,
-			utility:    utility,
-			successors: successors,
-			isMax:      isMax,
-		},
-	}
-}
-
-// expandNode generates children nodes only when needed
-func expandNode[T comparable](n *node[T], successors func(*T) []*T) {
+		moveMap: s.mp,
+		config:  cf,
+		result:  res,
+	}
+}
+
+// search holds the state shared by all nodes of a single run of the algorithm.
+// Fields that workers update concurrently are atomic or guarded by mu.
+type search[T comparable] struct {
+	*config[T]
+	start     time.Time     // When the search started
+	deadline  time.Time     // Zero if there is no time limit
+	nodes     atomic.Int64  // Nodes visited
+	maxDepth  atomic.Int64  // Deepest ply reached
+	stopped   atomic.Int32  // StopReason of the hard limit that aborted the search, if any
+	truncated atomic.Bool   // Whether the depth limit cut off any branch
+	sem       chan struct{} // Slots for extra workers, nil if searching sequentially
+	path      []int         // Child indices leading from the root to the current node, kept when tracing or observing
+	handle    *Search[T]    // Handle of an asynchronous search, may be nil
+
+	mu         sync.Mutex
+	mp         map[T]*T  // Best moves found so far
+	best       *node[T]  // Best root child found so far
+	bestVal    int       // Value of best
+	lastReport time.Time // When progress was last reported
+
+	tableMu   sync.Mutex
+	table     map[nodeKey[T]]*node[T] // Nodes created so far, shared between transpositions
+	retained  int                     // Most nodes the table held at once, counted when WithMemoryBound was given
+	discarded int                     // Nodes dropped from the table by WithMemoryBound
+
+	histograms *Histograms // Values seen so far, nil unless WithHistograms was given
+
+	failed atomic.Pointer[PanicError] // First panic recovered by WithFallback, nil if none
+
+	branchNodes  []atomic.Int64 // Nodes visited below each root move, in successor order, when WithRootStats was given
+	rootFinished []RootMove     // Values of the root moves when the search left them, in successor order
+}
+
+// expandNode generates children nodes only when needed. Children already in
+// the table are shared, so a state reached along several paths is searched once.
+func (s *search[T]) expandNode(n *node[T]) {
 	if n.expanded {
 		return
 	}
 
-	successorStates := successors(n.elem)
+	var successorStates []*T
+	if n.prefetched {
+		successorStates, n.pending = n.pending, nil
+	} else {
+		succ, release := s.generate(n.elem)
+		defer release()
+		successorStates = succ
+	}
 	n.children = make([]*node[T], 0, len(successorStates))
 
+	s.tableMu.Lock()
 	for _, succ := range successorStates {
-		child := &node[T]{
-			val:      0,
-			alpha:    -score,
-			beta:     score,
-			depth:    n.depth + 1,
-			isMax:    !n.isMax,
-			elem:     succ,
-			expanded: false,
+		cost := n.cost + s.moveCost(n.elem, succ)
+		key := nodeKey[T]{*succ, n.depth + 1, cost}
+		child := s.table[key]
+		if child == nil {
+			child = &node[T]{
+				val:      0,
+				alpha:    -score,
+				beta:     score,
+				depth:    n.depth + 1,
+				isMax:    !n.isMax,
+				elem:     s.shared(succ),
+				expanded: false,
+				from:     n,
+				cost:     cost,
+			}
+			s.table[key] = child
 		}
 		n.children = append(n.children, child)
 	}
+	if s.memoryBound > 0 {
+		s.retained = max(s.retained, len(s.table))
+	}
+	s.tableMu.Unlock()
+	if s.rootStats {
+		s.branch(n, len(successorStates))
+	}
 
+	var tableMove *T
+	if s.tt != nil {
+		n.successors = slices.Clone(n.children)
+		tableMove = s.tableMove(n)
+	}
+	s.history.order(n)
+	if s.counters != nil {
+		s.counters.order(n)
+	}
+	s.orderFirst(n, tableMove)
+	if width := s.width(n); width > 0 {
+		s.narrow(n, width)
+	}
+	if s.expandWorkers > 0 {
+		s.prefetch(n)
+	}
 	n.expanded = true
 }
 
-func minimax[T comparable](n *node[T], isTerminal func(*T) bool,
-	utility func(*T) int, successors func(*T) []*T, mp map[T]*T,
-) {
-	// Best move already calculated, skipping
-	if n.bestMove != nil {
-		return
+// minimax searches n within the window [alpha, beta] and returns its value.
+// path holds the states leading to n when repetitions are detected, else nil.
+func (s *search[T]) minimax(n *node[T], alpha, beta int, path *ancestor[T]) nodeValue {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	// Repeated state, its value depends on how it was reached
+	if path.repeats(n.elem) {
+		path.dependent.Store(true)
+		return nodeValue{val: s.repetition}
+	}
+
+	// Value already calculated through another path, skipping
+	if n.reusable(alpha, beta) {
+		return nodeValue{n.val, n.unknown}
+	}
+
+	// Value remembered from an earlier search
+	if v, ok := s.probe(n, alpha, beta); ok {
+		n.val, n.unknown, n.cyclic = v.val, v.unknown, false
+		n.searched = true
+		n.lo, n.hi = alpha, beta
+		if s.rootStats {
+			s.finished(n)
+		}
+		return v
 	}
 
+	n.alpha, n.beta = alpha, beta
+	if !s.visit(n) {
+		return nodeValue{n.val, n.unknown}
+	}
+	if s.tracing() {
+		s.emit(EventEnter, n, 0)
+		defer s.emit(EventExit, n, 0)
+	}
+	s.evaluate(n, path)
+	if n.cyclic && path != nil {
+		path.dependent.Store(true)
+	}
+
+	n.searched = !s.halted()
+	n.lo, n.hi = alpha, beta
+	s.record(n, alpha, beta)
+	s.observeBacked(n, alpha, beta)
+	if s.rootStats {
+		s.finished(n)
+	}
+	s.forget(n)
+	return nodeValue{n.val, n.unknown}
+}
+
+// evaluate computes the value of n, whose window is already set
+func (s *search[T]) evaluate(n *node[T], path *ancestor[T]) {
+	n.cyclic = false
+	n.unknown = false
+
 	// Terminal move found, return score
-	if isTerminal(n.elem) {
-		switch u := utility(n.elem); {
+	if s.isTerminal(n.elem) {
+		switch u := s.utility(n.elem); {
 		case u > 0:
-			n.val = score - n.depth
+			n.val = s.win(n.depth)
 		case u < 0:
-			n.val = n.depth - score
+			n.val = -s.win(n.depth)
 		default:
 			n.val = 0
 		}
+		n.val = s.charge(n, n.val)
+		return
+	}
+
+	// Depth limit reached, the outcome is unknown
+	if s.cutOff(n) {
+		v := s.cutoffValue(n)
+		if abs(v) <= MaxHeuristic { // Wins found by quiescence are discounted already
+			v = s.discount(n, v)
+		}
+		n.val = s.charge(n, v)
+		n.unknown = true
+		s.truncated.Store(true)
+		return
+	}
+
+	// Hopeless node close to the depth limit
+	if s.razorMargins != nil && s.razor(n) {
+		return
+	}
+	if s.probCut.Depth > 0 && s.probCutoff(n) {
 		return
 	}
 
 	// Lazily expand node
-	expandNode(n, successors)
+	s.expandNode(n)
+	s.emit(EventExpand, n, len(n.children))
 
 	// If no children after expansion, treat as terminal
 	if len(n.children) == 0 {
-		n.val = utility(n.elem)
+		n.val = s.charge(n, s.utility(n.elem))
+		return
+	}
+
+	if s.cycles {
+		path = &ancestor[T]{state: n.elem, up: path}
+		defer func() { n.cyclic = path.dependent.Load() }()
+	}
+
+	if i, cv, ok := s.transposedCutoff(n, path); ok {
+		n.val, n.unknown = cv.val, cv.unknown
+		s.improved(n, n.children[i], cv.val)
+		s.emit(EventCutoff, n, i)
+		s.keep(n, n.children[i])
 		return
 	}
 
 	var bestMove *node[T]
+	var bestUnknown bool
+	anyUnknown := n.narrowed // Dropped children might have been better
+	n.split = false
 	if n.isMax {
 		maxEval := -score
-		for _, child := range n.children {
-			child.alpha = n.alpha
-			child.beta = n.beta
-
-			minimax(child, isTerminal, utility, successors, mp)
-			eval := child.val
+		for i, child := range n.children {
+			cv := s.searchChild(n, i, path)
+			if s.halted() {
+				break // Limit reached, child value is incomplete
+			}
+			eval := cv.val
+			anyUnknown = anyUnknown || cv.unknown
 			if eval > maxEval {
 				maxEval = eval
 				bestMove = child
+				bestUnknown = cv.unknown
+				s.improved(n, child, eval)
 			}
 			n.alpha = max(n.alpha, maxEval)
 
 			if n.beta <= n.alpha {
+				s.prune(n, i)
 				break // Beta cutoff
 			}
 		}
 		n.val = maxEval
 	} else {
 		minEval := score
-		for _, child := range n.children {
-			child.alpha = n.alpha
-			child.beta = n.beta
-
-			minimax(child, isTerminal, utility, successors, mp)
-			eval := child.val
+		for i, child := range n.children {
+			cv := s.searchChild(n, i, path)
+			if s.halted() {
+				break // Limit reached, child value is incomplete
+			}
+			eval := cv.val
+			anyUnknown = anyUnknown || cv.unknown
 			if eval < minEval {
 				minEval = eval
 				bestMove = child
+				bestUnknown = cv.unknown
+				s.improved(n, child, eval)
 			}
 			n.beta = min(n.beta, minEval)
 
 			if n.beta <= n.alpha {
+				s.prune(n, i)
 				break // Alpha cutoff
 			}
 		}
 		n.val = minEval
 	}
+	n.unknown = unknownValue(n, bestUnknown, anyUnknown)
+
+	s.keep(n, bestMove)
+}
 
+// keep records bestMove as the best move from n, once n's value is final
+func (s *search[T]) keep(n, bestMove *node[T]) {
+	// Keep partial results only at the root, where they are the best move so far
+	if bestMove == nil || (s.halted() && n.depth > 0) {
+		return
+	}
+	s.history.learn(bestMove)
 	n.bestMove = bestMove
-	mp[*n.elem] = n.bestMove.elem
+	s.mu.Lock()
+	s.mp[*s.copyOf(n.elem)] = s.copyOf(n.bestMove.elem)
+	s.mu.Unlock()
+}
+
+// searchChild searches the i-th child of n within n's window and returns its value
+func (s *search[T]) searchChild(n *node[T], i int, path *ancestor[T]) nodeValue {
+	if n.split {
+		return n.splitVals[i] // Already searched along with its siblings
+	}
+	if i == 1 && s.splits(n) {
+		s.searchSiblings(n, path)
+		return n.splitVals[i]
+	}
+
+	if s.tracing() {
+		s.path = append(s.path, i)
+		defer func() { s.path = s.path[:len(s.path)-1] }()
+	}
+	return s.minimax(n.children[i], n.alpha, n.beta, path)
+}
+
+// prune is called when the i-th child of n causes a cutoff
+func (s *search[T]) prune(n *node[T], i int) {
+	s.emit(EventCutoff, n, i)
+	if s.counters != nil {
+		s.counters.learn(n, n.children[i])
+	}
+}
+
+// improved is called whenever child, worth val, becomes the best move found so far from n
+func (s *search[T]) improved(n, child *node[T], val int) {
+	if n.depth == 0 {
+		s.mu.Lock()
+		s.best, s.bestVal = child, val
+		s.mu.Unlock()
+		s.handle.setBest(s.copyOf(child.elem), val)
+	}
 }
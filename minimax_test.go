@@ -80,3 +80,39 @@ func TestMinimaxSimpleGame(t *testing.T) {
 		t.Error("Expected a best move, got nil")
 	}
 }
+
+// TestMinimaxTranspositions tests that states reached along several paths are searched once.
+func TestMinimaxTranspositions(t *testing.T) {
+	// Without sharing, Nim with 30 stones has tens of millions of nodes
+	state := nimState{stones: 30, aiTurn: true}
+	mm := Make(&state, nimTerminal, nimUtility, nimSuccessors, true)
+
+	if nodes := mm.Result().Nodes; nodes > 1000 {
+		t.Errorf("Expected transpositions to be shared, visited %d nodes", nodes)
+	}
+	if move := mm.Solve(state); move == nil || move.stones != 28 {
+		t.Errorf("Expected to leave 28 stones, got %v", move)
+	}
+}
+
+// TestPeek tests that Peek answers from the cache and never searches.
+func TestPeek(t *testing.T) {
+	state := nimState{stones: 10, aiTurn: true}
+	mm := Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithLimits(Limits{MaxNodes: 5}))
+	nodes := mm.Result().Nodes
+
+	if move, ok := mm.Peek(nimState{stones: 7, aiTurn: false}); ok || move != nil {
+		t.Errorf("Expected an unsearched state to miss, got %v", move)
+	}
+	if move, ok := mm.Peek(nimState{stones: 0, aiTurn: false}); !ok || move != nil {
+		t.Errorf("Expected a terminal state to need no search, got %v, %v", move, ok)
+	}
+	if mm.Result().Nodes != nodes {
+		t.Errorf("Expected Peek not to search, visited %d nodes", mm.Result().Nodes-nodes)
+	}
+
+	move := mm.Solve(nimState{stones: 3, aiTurn: false})
+	if peeked, ok := mm.Peek(nimState{stones: 3, aiTurn: false}); move == nil || !ok || peeked != move {
+		t.Errorf("Expected Peek to return the solved move %v, got %v", move, peeked)
+	}
+}
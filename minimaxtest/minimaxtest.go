@@ -0,0 +1,111 @@
+// Package minimaxtest provides generators and properties for testing game
+// definitions written for the minimax package with testing/quick, and a
+// runner of test suites of positions with known best moves, see Suite.
+//
+// Usage:
+//
+//	gen := minimaxtest.RandomPositions(game, start, 20)
+//	prop := minimaxtest.ConsistentSolve(game)
+//	if err := quick.Check(prop, minimaxtest.Config(gen, 100)); err != nil {
+//		t.Error(err)
+//	}
+package minimaxtest
+
+import (
+	"math/rand"
+	"reflect"
+	"testing/quick"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+// Generator produces random positions of a game
+type Generator[T comparable] func(r *rand.Rand) minimax.Position[T]
+
+// RandomPositions returns a generator of positions reached by playing up to
+// maxPlies random moves from start, stopping early at terminal states
+func RandomPositions[T comparable](g minimax.Game[T], start minimax.Position[T], maxPlies int) Generator[T] {
+	return func(r *rand.Rand) minimax.Position[T] {
+		pos := start
+		for plies := r.Intn(maxPlies + 1); plies > 0 && !g.IsTerminal(&pos.State); plies-- {
+			succ := g.Successors(&pos.State)
+			if len(succ) == 0 {
+				break
+			}
+			pos = minimax.Position[T]{State: *succ[r.Intn(len(succ))], IsMax: !pos.IsMax}
+		}
+		return pos
+	}
+}
+
+// Config returns a quick.Config that feeds properties count positions from gen
+func Config[T comparable](gen Generator[T], count int) *quick.Config {
+	return &quick.Config{
+		MaxCount: count,
+		Values: func(args []reflect.Value, r *rand.Rand) {
+			for i := range args {
+				args[i] = reflect.ValueOf(gen(r))
+			}
+		},
+	}
+}
+
+// ConsistentSolve returns a property checking that two searches of equal
+// positions agree on the value and the move
+func ConsistentSolve[T comparable](g minimax.Game[T], opts ...minimax.Option) func(minimax.Position[T]) bool {
+	return func(pos minimax.Position[T]) bool {
+		a, b := pos.State, pos.State
+		ma, mb := g.Make(&a, pos.IsMax, opts...), g.Make(&b, pos.IsMax, opts...)
+		if ma.Result().Value != mb.Result().Value {
+			return false
+		}
+		return sameMove(ma.Solve(a), mb.Solve(b))
+	}
+}
+
+// SymmetricValues returns a property checking that every state returned by
+// symmetries (such as the rotations and reflections of a board, or its
+// canonical form) has the same value as the state it was derived from
+func SymmetricValues[T comparable](g minimax.Game[T], symmetries func(T) []T, opts ...minimax.Option) func(minimax.Position[T]) bool {
+	return func(pos minimax.Position[T]) bool {
+		state := pos.State
+		want := g.Make(&state, pos.IsMax, opts...).Result().Value
+		for _, sym := range symmetries(pos.State) {
+			if g.Make(&sym, pos.IsMax, opts...).Result().Value != want {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// DepthMonotonic returns a property checking that searching deeper never
+// contradicts a proven value: once a search to one of the depths finds a value
+// that does not depend on cut-off branches, searches to the following depths
+// and an unlimited search must find the same value. depths must be increasing.
+func DepthMonotonic[T comparable](g minimax.Game[T], depths []int, opts ...minimax.Option) func(minimax.Position[T]) bool {
+	return func(pos minimax.Position[T]) bool {
+		proven, known := 0, false
+		for _, depth := range append(depths[:len(depths):len(depths)], 0) { // 0 searches without limit
+			state := pos.State
+			limited := append(opts[:len(opts):len(opts)], minimax.WithLimits(minimax.Limits{MaxDepth: depth}))
+			res := g.Make(&state, pos.IsMax, limited...).Result()
+
+			switch {
+			case known && res.Value != proven:
+				return false
+			case !known && !res.Unknown:
+				proven, known = res.Value, true
+			}
+		}
+		return true
+	}
+}
+
+// sameMove reports whether two moves are equal, treating nil as no move
+func sameMove[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
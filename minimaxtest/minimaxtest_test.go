@@ -0,0 +1,84 @@
+package minimaxtest
+
+import (
+	"math/rand"
+	"testing"
+	"testing/quick"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+// nim is a game of Nim where players take 1 to 3 stones and whoever takes the last stone wins
+type nim struct {
+	stones int
+	aiTurn bool
+}
+
+var nimGame = minimax.Game[nim]{
+	IsTerminal: func(s *nim) bool { return s.stones == 0 },
+	Utility: func(s *nim) int {
+		switch {
+		case s.stones > 0:
+			return 0
+		case s.aiTurn:
+			return -1
+		default:
+			return 1
+		}
+	},
+	Successors: func(s *nim) []*nim {
+		var succ []*nim
+		for take := 1; take <= min(3, s.stones); take++ {
+			succ = append(succ, &nim{stones: s.stones - take, aiTurn: !s.aiTurn})
+		}
+		return succ
+	},
+}
+
+var nimStart = minimax.Position[nim]{State: nim{stones: 21, aiTurn: true}, IsMax: true}
+
+// TestRandomPositions tests that generated positions are reachable and keep turns consistent.
+func TestRandomPositions(t *testing.T) {
+	gen := RandomPositions(nimGame, nimStart, 10)
+	r := rand.New(rand.NewSource(1))
+	for range 50 {
+		pos := gen(r)
+		if pos.State.aiTurn != pos.IsMax {
+			t.Fatalf("Turn and IsMax disagree in %+v", pos)
+		}
+		if pos.State.stones < 21-30 || pos.State.stones > 21 {
+			t.Fatalf("Unreachable position %+v", pos)
+		}
+	}
+}
+
+// TestProperties tests the properties against a correct game.
+func TestProperties(t *testing.T) {
+	config := Config(RandomPositions(nimGame, nimStart, 15), 30)
+
+	properties := map[string]func(minimax.Position[nim]) bool{
+		"consistent": ConsistentSolve(nimGame),
+		"symmetric": SymmetricValues(nimGame, func(s nim) []nim {
+			return []nim{s} // Nim has no symmetries but the identity
+		}),
+		"monotonic": DepthMonotonic(nimGame, []int{1, 2, 4, 8}),
+	}
+	for name, prop := range properties {
+		if err := quick.Check(prop, config); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+// TestPropertiesCatchBugs tests that a broken symmetry is caught.
+func TestPropertiesCatchBugs(t *testing.T) {
+	config := Config(RandomPositions(nimGame, nimStart, 15), 50)
+
+	// Adding a stone is not a symmetry of Nim
+	prop := SymmetricValues(nimGame, func(s nim) []nim {
+		return []nim{{stones: s.stones + 1, aiTurn: s.aiTurn}}
+	})
+	if quick.Check(prop, config) == nil {
+		t.Error("Expected the broken symmetry to be caught")
+	}
+}
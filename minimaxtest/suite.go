@@ -0,0 +1,208 @@
+package minimaxtest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+// Suite runs test suites of positions with known best moves or values, in the
+// spirit of the EPD suites chess engines are regression-tested against. A
+// suite is a text file with a case per line: the position, written however
+// Parse reads it, followed by operations separated by semicolons:
+//
+//	# Comments and blank lines are skipped
+//	7 ai ; bm take3 ; id seven
+//	8 ai ; value loss
+//	9 ai ; bm take1 ; am take2 take3 ; value win
+//
+// bm lists the acceptable best moves, am moves that must not be played, value
+// the expected value of the position (win, loss, draw, or a number on the
+// scale of Result.Value) and id names the case. Every operation given must
+// hold for the case to pass.
+type Suite[T comparable] struct {
+	Game     minimax.Game[T]
+	Parse    func(string) (minimax.Position[T], error) // Reads the position of a case
+	MoveName func(from, to *T) string                  // Names a move as bm and am write it
+	Options  []minimax.Option                          // Options of every search, such as limits
+}
+
+// Case is a position of a suite with what is expected of it
+type Case[T comparable] struct {
+	ID       string // Name of the case, its line number if not given
+	Line     int    // Line of the case in the suite
+	Position minimax.Position[T]
+	Best     []string // Acceptable best moves, any if empty
+	Avoid    []string // Moves that must not be played
+	Value    string   // Expected value: win, loss, draw or a number, anything if empty
+}
+
+// Load reads the cases of a suite from r
+func (s Suite[T]) Load(r io.Reader) ([]Case[T], error) {
+	var cases []Case[T]
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Split(text, ";")
+		pos, err := s.Parse(strings.TrimSpace(fields[0]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		c := Case[T]{ID: strconv.Itoa(line), Line: line, Position: pos}
+		for _, op := range fields[1:] {
+			words := strings.Fields(op)
+			if len(words) == 0 {
+				continue
+			}
+			switch args := words[1:]; words[0] {
+			case "bm":
+				c.Best = append(c.Best, args...)
+			case "am":
+				c.Avoid = append(c.Avoid, args...)
+			case "id":
+				c.ID = strings.Trim(strings.Join(args, " "), `"`)
+			case "value":
+				if len(args) != 1 || !validValue(args[0]) {
+					return nil, fmt.Errorf("line %d: invalid value %q", line, strings.Join(args, " "))
+				}
+				c.Value = args[0]
+			default:
+				return nil, fmt.Errorf("line %d: unknown operation %q", line, words[0])
+			}
+		}
+		cases = append(cases, c)
+	}
+	return cases, sc.Err()
+}
+
+// validValue reports whether v is a value a case can expect
+func validValue(v string) bool {
+	switch v {
+	case "win", "loss", "draw":
+		return true
+	}
+	_, err := strconv.Atoi(v)
+	return err == nil
+}
+
+// Outcome is the result of running a case
+type Outcome struct {
+	ID      string
+	Passed  bool
+	Move    string // Name of the move played, empty if none
+	Value   int    // Value found, on the scale of Result.Value
+	Nodes   int
+	Elapsed time.Duration
+	Failure string // Why the case failed, empty if it passed
+}
+
+// Report holds the outcomes of a run of a suite
+type Report struct {
+	Outcomes []Outcome
+	Passed   int
+	Elapsed  time.Duration // Time spent searching, in total
+}
+
+// Run searches every case with the suite's options and checks its expectations
+func (s Suite[T]) Run(cases []Case[T]) Report {
+	var rep Report
+	for _, c := range cases {
+		o := s.run(c)
+		rep.Outcomes = append(rep.Outcomes, o)
+		rep.Elapsed += o.Elapsed
+		if o.Passed {
+			rep.Passed++
+		}
+	}
+	return rep
+}
+
+// run searches a case and checks its expectations
+func (s Suite[T]) run(c Case[T]) Outcome {
+	state := c.Position.State
+	mm := s.Game.Make(&state, c.Position.IsMax, s.Options...)
+	move := mm.Solve(state)
+	res := mm.Result()
+
+	o := Outcome{ID: c.ID, Value: res.Value, Nodes: res.Nodes, Elapsed: res.Elapsed}
+	if move != nil {
+		o.Move = s.MoveName(&state, move)
+	}
+	switch {
+	case len(c.Best) > 0 && !slices.Contains(c.Best, o.Move):
+		o.Failure = fmt.Sprintf("played %s, expected %s", or(o.Move, "nothing"), strings.Join(c.Best, " or "))
+	case slices.Contains(c.Avoid, o.Move):
+		o.Failure = fmt.Sprintf("played %s, to avoid", o.Move)
+	case c.Value != "" && !matches(c.Value, res):
+		o.Failure = fmt.Sprintf("value %s, expected %s", describe(res), c.Value)
+	default:
+		o.Passed = true
+	}
+	return o
+}
+
+// matches reports whether the search result has the expected value
+func matches(want string, res minimax.Result) bool {
+	_, decided := minimax.Decided(res.Value)
+	switch want {
+	case "win":
+		return decided && res.Value > 0
+	case "loss":
+		return decided && res.Value < 0
+	case "draw":
+		return res.Value == 0 && !res.Unknown
+	}
+	v, _ := strconv.Atoi(want)
+	return res.Value == v
+}
+
+// describe writes the value of a search result as a case would expect it
+func describe(res minimax.Result) string {
+	if plies, ok := minimax.Decided(res.Value); ok {
+		if res.Value > 0 {
+			return fmt.Sprintf("win in %d", plies)
+		}
+		return fmt.Sprintf("loss in %d", plies)
+	}
+	return strconv.Itoa(res.Value)
+}
+
+// or returns s, or def if s is empty
+func or(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+// WriteTo writes the report to w, a case per line followed by the totals
+func (r Report) WriteTo(w io.Writer) (int64, error) {
+	var sb strings.Builder
+	tw := tabwriter.NewWriter(&sb, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "id\tresult\tmove\tvalue\tnodes\ttime\t")
+	for _, o := range r.Outcomes {
+		result := "pass"
+		if !o.Passed {
+			result = "FAIL: " + o.Failure
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d\t%v\t\n", o.ID, result, or(o.Move, "-"), o.Value, o.Nodes, o.Elapsed.Round(time.Microsecond))
+	}
+	if err := tw.Flush(); err != nil {
+		return 0, err
+	}
+	fmt.Fprintf(&sb, "passed %d/%d in %v\n", r.Passed, len(r.Outcomes), r.Elapsed.Round(time.Microsecond))
+
+	n, err := io.WriteString(w, sb.String())
+	return int64(n), err
+}
@@ -0,0 +1,79 @@
+package minimaxtest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+// nimSuite reads positions such as "7 ai" and names moves take1 to take3
+var nimSuite = Suite[nim]{
+	Game: nimGame,
+	Parse: func(s string) (minimax.Position[nim], error) {
+		var stones int
+		var side string
+		if _, err := fmt.Sscanf(s, "%d %s", &stones, &side); err != nil || side != "ai" && side != "them" {
+			return minimax.Position[nim]{}, errors.New("expected stones and ai or them")
+		}
+		return minimax.Position[nim]{State: nim{stones, side == "ai"}, IsMax: side == "ai"}, nil
+	},
+	MoveName: func(from, to *nim) string { return fmt.Sprintf("take%d", from.stones-to.stones) },
+}
+
+// TestSuite tests loading and running a suite, with passing and failing cases.
+func TestSuite(t *testing.T) {
+	src := `# Nim, taking 1 to 3 stones
+7 ai ; bm take3 ; id "seven stones"
+8 ai ; value loss
+9 ai ; bm take1 ; am take2 take3 ; value win
+9 them ; bm take2
+6 ai ; value draw
+
+5 ai ; value 29997
+`
+	cases, err := nimSuite.Load(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) != 6 || cases[0].ID != "seven stones" || cases[1].ID != "3" || cases[5].Line != 8 {
+		t.Fatalf("Unexpected cases %+v", cases)
+	}
+	if c := cases[2]; len(c.Best) != 1 || len(c.Avoid) != 2 || c.Value != "win" {
+		t.Errorf("Unexpected operations %+v", c)
+	}
+
+	rep := nimSuite.Run(cases)
+	if rep.Passed != 4 {
+		t.Errorf("Expected 4 cases to pass, got %+v", rep)
+	}
+	for i, want := range []string{"", "", "", "played take1, expected take2", "value win in 3, expected draw", ""} {
+		if got := rep.Outcomes[i].Failure; got != want {
+			t.Errorf("Case %s: expected failure %q, got %q", cases[i].ID, want, got)
+		}
+	}
+
+	var out strings.Builder
+	rep.WriteTo(&out)
+	for _, want := range []string{"seven stones  pass", "FAIL: played take1, expected take2", "passed 4/6 in"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected the report to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+// TestSuiteErrors tests that malformed cases are reported with their line.
+func TestSuiteErrors(t *testing.T) {
+	for src, want := range map[string]string{
+		"7 ai\nseven":          "line 2: expected stones",
+		"7 ai ; value big":     `line 1: invalid value "big"`,
+		"7 ai ; pv take3":      `line 1: unknown operation "pv"`,
+		"\n\n7 ai ; value 1 2": `line 3: invalid value "1 2"`,
+	} {
+		if _, err := nimSuite.Load(strings.NewReader(src)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%q: expected an error containing %q, got %v", src, want, err)
+		}
+	}
+}
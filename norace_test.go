@@ -0,0 +1,5 @@
+//go:build !race
+
+package minimax
+
+const raceEnabled = false
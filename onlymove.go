@@ -0,0 +1,74 @@
+package minimax
+
+// WithOnlyMove checks whether the best move from the searched state is
+// forced, reported in Result.OnlyMove: every other move must be a proven loss
+// while the best move is not, or be worth at least margin less than it (if
+// margin is positive). Trainers and puzzle generators use this to find the
+// positions where a single move holds. Moves the search already pruned below
+// the threshold cost nothing more; the others are searched again with a null
+// window, whose nodes count in Result.Nodes, in searches of their own that
+// leave the values, cache and table of the main search untouched. If a node
+// or time limit stops these searches, the move is not reported as forced.
+func WithOnlyMove(margin int) Option {
+	return func(o *options) {
+		o.onlyMove = true
+		o.onlyMargin = margin
+	}
+}
+
+// forced reports whether the best move of the searched root is forced
+func (s *search[T]) forced(root *node[T]) bool {
+	if root.bestMove == nil || s.halted() {
+		return false
+	}
+
+	// Every other move must be worth at most thr to the max side, or at least thr to the min side
+	v := root.val
+	var thr int
+	if root.isMax {
+		thr = -score
+		if v >= -MaxHeuristic {
+			thr = -MaxHeuristic - 1 // Proven losses
+		}
+		if s.onlyMargin > 0 {
+			thr = max(thr, v-s.onlyMargin)
+		}
+	} else {
+		thr = score
+		if v <= MaxHeuristic {
+			thr = MaxHeuristic + 1
+		}
+		if s.onlyMargin > 0 {
+			thr = min(thr, v+s.onlyMargin)
+		}
+	}
+
+	// Each move is checked by a search of its own, so that the values of the
+	// main search, its best moves and its table are left as it found them
+	for _, child := range root.children {
+		if child == root.bestMove {
+			continue
+		}
+		alpha, beta := thr-1, thr
+		if root.isMax {
+			alpha, beta = thr, thr+1
+		}
+		v := child.val
+		if !child.reusable(alpha, beta) {
+			cf, ok := s.subconfig(child, s.limits.MaxDepth, alpha, beta)
+			if !ok {
+				return false
+			}
+			cf.tt = nil
+			sub, ok := s.run(child, cf)
+			if !ok {
+				return false // A limit stopping the check leaves the answer unknown
+			}
+			v = sub.result.Value
+		}
+		if root.isMax && v > thr || !root.isMax && v < thr {
+			return false
+		}
+	}
+	return !root.narrowed // Moves dropped by beam search were never checked
+}
@@ -0,0 +1,88 @@
+package minimax
+
+import "testing"
+
+// TestOnlyMove tests telling the single non-losing move.
+func TestOnlyMove(t *testing.T) {
+	for _, tc := range []struct {
+		stones int
+		want   bool
+	}{
+		{9, true},  // Leaving 8 wins, the rest lose
+		{8, false}, // Every move loses
+		{1, true},  // A single legal move
+	} {
+		state := nimState{stones: tc.stones, aiTurn: true}
+		mm := Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithOnlyMove(0))
+		if got := mm.Result().OnlyMove; got != tc.want {
+			t.Errorf("%d stones: expected OnlyMove %v, got %v", tc.stones, tc.want, got)
+		}
+	}
+
+	state := nimState{stones: 9, aiTurn: true}
+	if Make(&state, nimTerminal, nimUtility, nimSuccessors, true).Result().OnlyMove {
+		t.Error("Expected no check without WithOnlyMove")
+	}
+}
+
+// TestOnlyMoveMargin tests the margin against the exact values of the moves,
+// and that a limit stopping the check does not stop the search.
+func TestOnlyMoveMargin(t *testing.T) {
+	h := WithHeuristic(func(s *pathState) int { return int(s.path*2654435761%1000) - 500 })
+	limits := WithLimits(Limits{MaxDepth: pathDepth - 3})
+	for _, isMax := range []bool{true, false} {
+		for _, start := range []pathState{{path: 1, depth: pathDepth - 6}, {path: 5, depth: pathDepth - 6}} {
+			mm := Make(&start, pathTerminal, pathUtility, pathSuccessors, isMax, h, limits)
+			scores, best := mm.ScoreMoves(start), mm.Solve(start)
+			gap := 2 * score // Smallest difference between the best move and another
+			for _, ms := range scores {
+				if *ms.Move != *best {
+					gap = min(gap, abs(ms.Value-mm.Result().Value))
+				}
+			}
+
+			for _, margin := range []int{1, gap, gap + 1} {
+				if margin <= 0 {
+					continue // Ties, only losses would count
+				}
+				mm := Make(&start, pathTerminal, pathUtility, pathSuccessors, isMax, h, limits, WithOnlyMove(margin))
+				if got, want := mm.Result().OnlyMove, margin <= gap; got != want {
+					t.Errorf("isMax %v from %v, margin %d (gap %d): expected OnlyMove %v, got %v", isMax, start, margin, gap, want, got)
+				}
+			}
+		}
+	}
+
+	state := pathState{depth: pathDepth - 6}
+	plain := Make(&state, pathTerminal, pathUtility, pathSuccessors, true, h, limits).Result()
+	tight := WithLimits(Limits{MaxDepth: pathDepth - 3, MaxNodes: plain.Nodes + 1})
+	res := Make(&state, pathTerminal, pathUtility, pathSuccessors, true, h, tight, WithOnlyMove(score)).Result()
+	if res.OnlyMove || res.Stopped != plain.Stopped || res.Value != plain.Value {
+		t.Errorf("Expected the check to give up without stopping the search, got %+v", res)
+	}
+}
+
+// TestOnlyMoveUntouched tests that the check leaves the statistics, the cache
+// and the table of the main search as they were.
+func TestOnlyMoveUntouched(t *testing.T) {
+	h := WithHeuristic(func(s *pathState) int { return int(s.path*2654435761%1000) - 500 })
+	limits := WithLimits(Limits{MaxDepth: pathDepth - 3})
+	hash := func(s *pathState) uint64 { return s.path*31 + uint64(s.depth) }
+	state := pathState{path: 5, depth: pathDepth - 6}
+
+	plainTable, checkedTable := NewTable(1<<12), NewTable(1<<12)
+	plain := Make(&state, pathTerminal, pathUtility, pathSuccessors, true, h, limits, WithRootStats(), WithTable(plainTable, hash))
+	checked := Make(&state, pathTerminal, pathUtility, pathSuccessors, true, h, limits, WithRootStats(), WithTable(checkedTable, hash), WithOnlyMove(score))
+	if checked.Result().Nodes <= plain.Result().Nodes {
+		t.Errorf("Expected the check to search more nodes than %d, got %d", plain.Result().Nodes, checked.Result().Nodes)
+	}
+	for i, m := range checked.Result().RootMoves {
+		if m != plain.Result().RootMoves[i] {
+			t.Errorf("Expected root move %d as without the check, %+v, got %+v", i, plain.Result().RootMoves[i], m)
+		}
+	}
+	if checked.CacheLen() != plain.CacheLen() || checkedTable.Used() != plainTable.Used() {
+		t.Errorf("Expected the cache and table as without the check, got %d and %d entries, want %d and %d",
+			checked.CacheLen(), checkedTable.Used(), plain.CacheLen(), plainTable.Used())
+	}
+}
@@ -0,0 +1,99 @@
+package minimax
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// Option configures a search. Options passed to Make are remembered by the
+// returned Minimax and reused whenever Solve has to search again.
+type Option func(*options)
+
+// options collects the settings passed to Make
+type options struct {
+	limits     Limits
+	autoProbes int // Probes estimating the tree to pick a depth limit, 0 if disabled
+
+	progress         any // func(Progress[T])
+	progressInterval time.Duration
+
+	trace  io.Writer // Destination of recorded traces
+	replay io.Reader // Source of traces to replay
+
+	observer any // func(Event, *T)
+
+	workers       int // Maximum number of goroutines searching at once
+	expandWorkers int // Goroutines generating grandchildren at once, 0 if disabled
+
+	cycles     bool // Whether repeated states are detected
+	repetition int  // Value of a repeated state
+
+	cutoff     Cutoff // How nodes cut off by the depth limit are scored
+	heuristic  any    // func(*T) int
+	noisy      any    // func(*T) []*T
+	quiescence int    // Plies of quiescence search past the depth limit, 0 if disabled
+	endgame    any    // func(*T) bool
+
+	clone          any // func(*T) *T
+	successorsInto any // func(*T, []*T) []*T
+	successorCache any // *SuccessorCache[T]
+	aliasing       Aliasing
+	interner       any // *Interner[T]
+
+	history any // *History[T]
+
+	etc bool // Whether children are probed for transposition cutoffs first
+	iid int  // Depth reduction of internal iterative deepening, 0 if disabled
+
+	countermoves any // *Countermoves[T, M]
+
+	razorMargins []int // Razoring margins by remaining depth, nil if disabled
+
+	probCut ProbCut // ProbCut model, disabled if Depth is 0
+
+	stages any // []Stage[T]
+
+	tt   *Table // Transposition table kept across searches
+	hash any    // func(*T) uint64
+
+	histWidth int // Width of the buckets of value histograms, 0 if disabled
+
+	handicap Handicap // Weaker moves to play, zero to play the best
+
+	onlyMove   bool // Whether to check that the best root move is forced
+	onlyMargin int  // Margin by which the other root moves must be worse, 0 to only tell losses
+
+	rootStats bool // Whether effort is counted per root move
+
+	memoryBound int // Nodes the search graph is kept to, 0 if unbounded
+
+	fallback any // func(*T) *T
+
+	cost    any      // func(from, to *T) int
+	combine Combiner // Folds accumulated costs into values
+
+	gamma float64 // Discount per ply, 0 if disabled
+
+	explored bool // Whether the explored tree is kept
+
+	format any // func(*T) string
+
+	beam      int // Children kept per node, 0 if disabled
+	widenBase int // Children kept at the depth limit by progressive widening, 0 if disabled
+	widenStep int // Children added per remaining ply by progressive widening
+}
+
+// hook converts an option stored as any back to its typed form, panicking if
+// it was built for a different state type than the one passed to Make
+func hook[F any](v any, name string) F {
+	var zero F
+	if v == nil {
+		return zero
+	}
+	f, ok := v.(F)
+	if !ok {
+		panic(fmt.Sprintf("minimax: %s was built for a different state type (%T)", name, v))
+	}
+	return f
+}
@@ -0,0 +1,175 @@
+package oracle
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+// groupSize is the number of positions per group of a Compact oracle
+const groupSize = 64
+
+// compactMagic starts the files written by Compact.WriteTo
+const compactMagic = "minimax-compact-1\n"
+
+// Compact is a compressed, read-only oracle, for solved games too large to
+// keep as an Oracle. Positions are keyed by hash and sorted, and stored in
+// groups of consecutive keys: each key as its difference from the previous
+// one, then the best move as an index in successor order, the outcome and
+// the distance to the end, all as varints. Queries binary-search the first
+// key of each group, then decode at most one group, so a position takes a
+// few bytes rather than the tens an Oracle spends on it, both on disk and in
+// memory. Hashing canonical states, such as the representative of a
+// position's symmetries, shrinks it further.
+type Compact struct {
+	n       int      // Number of positions
+	data    []byte   // Groups of encoded positions
+	firsts  []uint64 // First key of each group
+	offsets []int    // Offset of each group in data
+}
+
+// Compact compresses the oracle, keying positions by hash, which must be
+// distinct for every position. g must be the game the oracle was solved for.
+func (o *Oracle[T]) Compact(g minimax.Game[T], hash func(minimax.Position[T]) uint64) (*Compact, error) {
+	rows, err := o.rows(g, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Compact{n: len(rows)}
+	var prev uint64
+	for _, r := range rows {
+		c.data = binary.AppendUvarint(c.data, r.key-prev)
+		c.data = binary.AppendUvarint(c.data, uint64(r.move+1)*3+uint64(r.value+1))
+		c.data = binary.AppendUvarint(c.data, uint64(r.plies))
+		prev = r.key
+	}
+	if err := c.index(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// index finds the groups of c.data
+func (c *Compact) index() error {
+	c.firsts, c.offsets = nil, nil
+	var key uint64
+	for i, off := 0, 0; i < c.n; i++ {
+		if i%groupSize == 0 {
+			c.offsets = append(c.offsets, off)
+		}
+		delta, n := binary.Uvarint(c.data[off:])
+		if n <= 0 {
+			return errors.New("oracle: corrupt compact oracle")
+		}
+		key += delta
+		if i%groupSize == 0 {
+			c.firsts = append(c.firsts, key)
+		}
+		off += n
+		for range 2 {
+			if _, n = binary.Uvarint(c.data[off:]); n <= 0 {
+				return errors.New("oracle: corrupt compact oracle")
+			}
+			off += n
+		}
+	}
+	return nil
+}
+
+// Len returns the number of positions the oracle knows
+func (c *Compact) Len() int {
+	return c.n
+}
+
+// Size returns the number of bytes the oracle takes in memory, about what it
+// takes on disk
+func (c *Compact) Size() int {
+	return len(c.data) + len(c.firsts)*8 + len(c.offsets)*8
+}
+
+// Query returns the answer for the position with the given hash: the index of
+// its best move in successor order, -1 at the end of the game, its outcome
+// for the AI and the moves until the game ends under perfect play. It
+// returns false if the position is unknown.
+func (c *Compact) Query(hash uint64) (move, value, plies int, ok bool) {
+	g, found := slices.BinarySearch(c.firsts, hash)
+	if !found {
+		g--
+	}
+	if g < 0 {
+		return 0, 0, 0, false
+	}
+
+	off, key := c.offsets[g], c.firsts[g]
+	for i := g * groupSize; i < min(c.n, (g+1)*groupSize); i++ {
+		delta, n := binary.Uvarint(c.data[off:])
+		off += n
+		if i > g*groupSize {
+			key += delta
+		}
+		answer, n := binary.Uvarint(c.data[off:])
+		off += n
+		p, n := binary.Uvarint(c.data[off:])
+		off += n
+		switch {
+		case key == hash:
+			return int(answer/3) - 1, int(answer%3) - 1, int(p), true
+		case key > hash:
+			return 0, 0, 0, false
+		}
+	}
+	return 0, 0, 0, false
+}
+
+// WriteTo saves the oracle to w
+func (c *Compact) WriteTo(w io.Writer) (int64, error) {
+	buf := binary.AppendUvarint([]byte(compactMagic), uint64(c.n))
+	buf = binary.AppendUvarint(buf, uint64(len(c.data)))
+	n, err := w.Write(buf)
+	if err != nil {
+		return int64(n), err
+	}
+	m, err := w.Write(c.data)
+	return int64(n + m), err
+}
+
+// ReadCompact loads an oracle saved by Compact.WriteTo
+func ReadCompact(r io.Reader) (*Compact, error) {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(compactMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || string(magic) != compactMagic {
+		return nil, errors.New("oracle: not a compact oracle")
+	}
+	n, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, fmt.Errorf("oracle: reading compact oracle: %w", err)
+	}
+	size, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, fmt.Errorf("oracle: reading compact oracle: %w", err)
+	}
+
+	// Positions take three varints each, so a size out of their range is
+	// corrupt, and data is read as it comes rather than allocated up front
+	if n > math.MaxInt/(3*binary.MaxVarintLen64) || size < 3*n || size > 3*binary.MaxVarintLen64*n {
+		return nil, errors.New("oracle: corrupt compact oracle")
+	}
+	var data bytes.Buffer
+	if _, err := io.CopyN(&data, br, int64(size)); err != nil {
+		return nil, fmt.Errorf("oracle: reading compact oracle: %w", err)
+	}
+
+	c := &Compact{n: int(n), data: data.Bytes()}
+	if err := c.index(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,80 @@
+package oracle
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+// TestCompact tests that a compact oracle answers like the oracle it was made from, in a few bytes per position.
+func TestCompact(t *testing.T) {
+	o := Solve(nim, minimax.Position[pile]{State: pile{Stones: 300, AITurn: true}, IsMax: true})
+	c, err := o.Compact(nim, pileHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Len() != o.Len() || c.Size() > 5*c.Len() {
+		t.Errorf("Expected %d positions in at most %d bytes, got %d in %d", o.Len(), 5*o.Len(), c.Len(), c.Size())
+	}
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := ReadCompact(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for pos, a := range o.answers {
+		want := -1
+		if a.Best != nil {
+			want = pos.State.Stones - a.Best.Stones - 1 // Taking 1, 2 or 3 stones
+		}
+		for _, oc := range []*Compact{c, loaded} {
+			move, value, plies, ok := oc.Query(pileHash(pos))
+			if !ok || move != want || value != a.Value || plies != a.Plies {
+				t.Fatalf("%+v: expected %d, %d, %d, got %d, %d, %d, %v", pos, want, a.Value, a.Plies, move, value, plies, ok)
+			}
+		}
+	}
+	for _, hash := range []uint64{1000, 1 << 40} {
+		if _, _, _, ok := c.Query(hash); ok {
+			t.Errorf("Expected hash %d to be unknown", hash)
+		}
+	}
+}
+
+// TestReadCompactErrors tests that other files are refused.
+func TestReadCompactErrors(t *testing.T) {
+	huge := string(binary.AppendUvarint(binary.AppendUvarint([]byte(compactMagic), 1<<40), 1<<44))
+	for _, src := range []string{"", "not an oracle at all", compactMagic + "\x05\x03ab",
+		compactMagic + "\x01\x80\x80\x80\x80\x80\x80\x80\x80\x01", // Size beyond what one position takes
+		huge, // Sizes in range, but the data is missing
+	} {
+		if _, err := ReadCompact(strings.NewReader(src)); err == nil {
+			t.Errorf("%q: expected an error", src)
+		}
+	}
+}
+
+// TestCompactSparse tests queries with hashes spread over 64 bits.
+func TestCompactSparse(t *testing.T) {
+	spread := func(p minimax.Position[pile]) uint64 { return (pileHash(p) + 1) * 0x9e3779b97f4a7c15 }
+	o := Solve(nim, minimax.Position[pile]{State: pile{Stones: 200, AITurn: true}, IsMax: true})
+	c, err := o.Compact(nim, spread)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for pos, a := range o.answers {
+		if _, value, plies, ok := c.Query(spread(pos)); !ok || value != a.Value || plies != a.Plies {
+			t.Fatalf("%+v: expected %d in %d, got %d in %d, %v", pos, a.Value, a.Plies, value, plies, ok)
+		}
+		if _, _, _, ok := c.Query(spread(pos) + 1); ok {
+			t.Errorf("%+v: expected a neighbouring hash to be unknown", pos)
+		}
+	}
+}
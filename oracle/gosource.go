@@ -0,0 +1,137 @@
+package oracle
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"slices"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+// GoTable names what WriteGo generates
+type GoTable struct {
+	Package string // Package of the generated file
+	Name    string // Name of the lookup function; its arrays are named after it
+}
+
+// row is a position as stored in a generated table
+type row struct {
+	key   uint64
+	move  int
+	value int
+	plies int
+}
+
+// WriteGo writes the oracle to w as a Go source file, so that small solved
+// games can be embedded in binaries, with no solving or loading at startup.
+// Positions are keyed by hash, and their best moves stored as indices in the
+// order g generates successors, in sorted arrays a generated function looks
+// up by binary search:
+//
+//	func Name(hash uint64) (move, value, plies int, ok bool)
+//
+// where move is -1 at the end of the game. The hashes must be distinct for
+// every position, which WriteGo checks, and g must be the game the oracle was
+// solved for. Positions must have at most 255 moves and end within 65535 plies.
+func (o *Oracle[T]) WriteGo(w io.Writer, g minimax.Game[T], hash func(minimax.Position[T]) uint64, t GoTable) error {
+	if !token.IsIdentifier(t.Package) || !token.IsIdentifier(t.Name) {
+		return fmt.Errorf("oracle: invalid package or name %q, %q", t.Package, t.Name)
+	}
+
+	rows, err := o.rows(g, hash)
+	if err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if r.move >= 255 || r.plies > 65535 {
+			return fmt.Errorf("oracle: position %#x has its best move at %d or ends in %d plies, beyond 254 and 65535", r.key, r.move, r.plies)
+		}
+	}
+
+	var b bytes.Buffer
+	name, arrays := t.Name, lowerFirst(t.Name)
+	fmt.Fprintf(&b, "// Code generated by oracle.WriteGo; DO NOT EDIT.\n\npackage %s\n\nimport \"slices\"\n\n", t.Package)
+	fmt.Fprintf(&b, "// %sKeys holds the hashes of the %d solved positions, sorted\n", arrays, len(rows))
+	writeArray(&b, arrays+"Keys", "uint64", rows, 4, func(r row) string { return fmt.Sprintf("0x%016x", r.key) })
+	fmt.Fprintf(&b, "// %sMoves holds the index of the best move of each position, 255 at the end of the game\n", arrays)
+	writeArray(&b, arrays+"Moves", "uint8", rows, 16, func(r row) string { return fmt.Sprint(uint8(r.move)) })
+	fmt.Fprintf(&b, "// %sValues holds the outcome of each position for the AI: 1, 0 or -1\n", arrays)
+	writeArray(&b, arrays+"Values", "int8", rows, 16, func(r row) string { return fmt.Sprint(r.value) })
+	fmt.Fprintf(&b, "// %sPlies holds the moves until each position's game ends under perfect play\n", arrays)
+	writeArray(&b, arrays+"Plies", "uint16", rows, 16, func(r row) string { return fmt.Sprint(r.plies) })
+	fmt.Fprintf(&b, `// %[1]s returns the solution of the position with the given hash: the index
+// of its best move in successor order, -1 at the end of the game, its outcome
+// for the AI and the moves until the game ends under perfect play. It returns
+// false if the position was not solved.
+func %[1]s(hash uint64) (move, value, plies int, ok bool) {
+	i, ok := slices.BinarySearch(%[2]sKeys[:], hash)
+	if !ok {
+		return 0, 0, 0, false
+	}
+	move = int(%[2]sMoves[i])
+	if move == 255 {
+		move = -1
+	}
+	return move, int(%[2]sValues[i]), int(%[2]sPlies[i]), true
+}
+`, name, arrays)
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// rows returns the positions of the oracle keyed by hash, sorted by key, with
+// their best moves as indices in the order g generates successors
+func (o *Oracle[T]) rows(g minimax.Game[T], hash func(minimax.Position[T]) uint64) ([]row, error) {
+	rows := make([]row, 0, len(o.answers))
+	for pos, a := range o.answers {
+		r := row{key: hash(pos), move: -1, value: a.Value, plies: a.Plies}
+		if a.Best != nil {
+			state := pos.State
+			r.move = slices.IndexFunc(g.Successors(&state), func(s *T) bool { return *s == *a.Best })
+			if r.move < 0 {
+				return nil, fmt.Errorf("oracle: best move of %v is not among its successors", pos.State)
+			}
+		}
+		rows = append(rows, r)
+	}
+	slices.SortFunc(rows, func(a, b row) int { return cmp.Compare(a.key, b.key) })
+	for i := 1; i < len(rows); i++ {
+		if rows[i].key == rows[i-1].key {
+			return nil, fmt.Errorf("oracle: two positions share the hash %#x", rows[i].key)
+		}
+	}
+	return rows, nil
+}
+
+// writeArray writes a Go array literal of the field of rows that elem formats,
+// perLine elements per line
+func writeArray(b *bytes.Buffer, name, typ string, rows []row, perLine int, elem func(row) string) {
+	fmt.Fprintf(b, "var %s = [...]%s{", name, typ)
+	for i, r := range rows {
+		if i%perLine == 0 {
+			b.WriteString("\n\t")
+		} else {
+			b.WriteByte(' ')
+		}
+		b.WriteString(elem(r))
+		b.WriteByte(',')
+	}
+	b.WriteString("\n}\n\n")
+}
+
+// lowerFirst lowers the first letter of s
+func lowerFirst(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[n:]
+}
@@ -0,0 +1,93 @@
+package oracle
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+// pileHash numbers positions of Nim one after another
+func pileHash(p minimax.Position[pile]) uint64 {
+	h := uint64(p.State.Stones) * 2
+	if p.IsMax {
+		h++
+	}
+	return h
+}
+
+// TestWriteGo tests the source generated for a small solved game.
+func TestWriteGo(t *testing.T) {
+	o := Solve(nim, minimax.Position[pile]{State: pile{Stones: 3, AITurn: true}, IsMax: true})
+	var buf bytes.Buffer
+	if err := o.WriteGo(&buf, nim, pileHash, GoTable{Package: "nimtable", Name: "NimTable"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != wantGo {
+		t.Errorf("Unexpected source:\n%s", got)
+	}
+}
+
+// TestWriteGoErrors tests that invalid names and colliding hashes are refused.
+func TestWriteGoErrors(t *testing.T) {
+	o := Solve(nim, minimax.Position[pile]{State: pile{Stones: 3, AITurn: true}, IsMax: true})
+	for _, tc := range []struct {
+		hash  func(minimax.Position[pile]) uint64
+		table GoTable
+		want  string
+	}{
+		{pileHash, GoTable{Package: "nim table", Name: "NimTable"}, "invalid package or name"},
+		{pileHash, GoTable{Package: "nimtable", Name: "1st"}, "invalid package or name"},
+		{func(p minimax.Position[pile]) uint64 { return uint64(p.State.Stones) }, GoTable{Package: "nimtable", Name: "NimTable"}, "share the hash"},
+	} {
+		err := o.WriteGo(&bytes.Buffer{}, nim, tc.hash, tc.table)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%+v: expected an error mentioning %q, got %v", tc.table, tc.want, err)
+		}
+	}
+}
+
+const wantGo = `// Code generated by oracle.WriteGo; DO NOT EDIT.
+
+package nimtable
+
+import "slices"
+
+// nimTableKeys holds the hashes of the 6 solved positions, sorted
+var nimTableKeys = [...]uint64{
+	0x0000000000000000, 0x0000000000000001, 0x0000000000000002, 0x0000000000000003,
+	0x0000000000000004, 0x0000000000000007,
+}
+
+// nimTableMoves holds the index of the best move of each position, 255 at the end of the game
+var nimTableMoves = [...]uint8{
+	255, 255, 0, 0, 1, 2,
+}
+
+// nimTableValues holds the outcome of each position for the AI: 1, 0 or -1
+var nimTableValues = [...]int8{
+	1, -1, -1, 1, -1, 1,
+}
+
+// nimTablePlies holds the moves until each position's game ends under perfect play
+var nimTablePlies = [...]uint16{
+	0, 0, 1, 1, 1, 1,
+}
+
+// NimTable returns the solution of the position with the given hash: the index
+// of its best move in successor order, -1 at the end of the game, its outcome
+// for the AI and the moves until the game ends under perfect play. It returns
+// false if the position was not solved.
+func NimTable(hash uint64) (move, value, plies int, ok bool) {
+	i, ok := slices.BinarySearch(nimTableKeys[:], hash)
+	if !ok {
+		return 0, 0, 0, false
+	}
+	move = int(nimTableMoves[i])
+	if move == 255 {
+		move = -1
+	}
+	return move, int(nimTableValues[i]), int(nimTablePlies[i]), true
+}
+`
@@ -0,0 +1,129 @@
+package oracle
+
+import "github.com/abtsousa/minimax-go"
+
+// vertex is a position of the game graph built by SolveGraph
+type vertex[T comparable] struct {
+	pos      minimax.Position[T]
+	succ     []*T  // Successor states, in the order the game gave them
+	children []int // Vertices of succ
+	parents  []int // Vertices with a move to this one, once per move
+	left     int   // Children not settled yet
+	settled  bool  // Whether answer is final
+	answer   Answer[T]
+}
+
+// SolveGraph computes the perfect-play outcome of every position reachable
+// from start, like Solve, but also for games whose positions can repeat.
+// Instead of searching the game tree, it enumerates the graph of positions
+// and works backwards from the ends of the game, settling a position as
+// soon as one move wins it for the side to move, or once all of its moves
+// are settled. Positions are settled in order of their distance to the end,
+// so winners still take the fastest win and losers the slowest loss.
+//
+// Positions left unsettled are those where neither side can force a win: the
+// side to move can always keep the game going around a cycle. They are
+// draws, with Plies 0 since the game never ends, and their best move stays
+// on the cycle. All positions must fit in memory, along with their moves.
+func SolveGraph[T comparable](g minimax.Game[T], start minimax.Position[T]) *Oracle[T] {
+	vs := enumerate(g, start)
+
+	// Settle the ends of the game, then work backwards from them
+	var queue []int
+	for i, v := range vs {
+		if len(v.children) == 0 {
+			state := v.pos.State
+			v.answer = Answer[T]{Value: sign(g.Utility(&state)), IsMax: v.pos.IsMax}
+			v.settled = true
+			queue = append(queue, i)
+		}
+	}
+	for len(queue) > 0 {
+		c := vs[queue[0]]
+		queue = queue[1:]
+		for _, p := range c.parents {
+			v := vs[p]
+			if v.settled {
+				continue
+			}
+			v.left--
+			if wins(c.answer.Value, v.pos.IsMax) || v.left == 0 {
+				v.settle(vs)
+				queue = append(queue, p)
+			}
+		}
+	}
+
+	o := &Oracle[T]{start: start, answers: make(map[minimax.Position[T]]Answer[T], len(vs))}
+	for _, v := range vs {
+		if !v.settled {
+			v.answer = Answer[T]{IsMax: v.pos.IsMax, Best: v.cycle(vs)}
+		}
+		o.answers[v.pos] = v.answer
+	}
+	return o
+}
+
+// enumerate returns every position reachable from start, linked to their
+// children and parents, with start first
+func enumerate[T comparable](g minimax.Game[T], start minimax.Position[T]) []*vertex[T] {
+	index := make(map[minimax.Position[T]]int)
+	var vs []*vertex[T]
+	add := func(pos minimax.Position[T]) int {
+		i, ok := index[pos]
+		if !ok {
+			i = len(vs)
+			index[pos] = i
+			vs = append(vs, &vertex[T]{pos: pos})
+		}
+		return i
+	}
+
+	add(start)
+	for i := 0; i < len(vs); i++ {
+		v := vs[i]
+		state := v.pos.State
+		if !g.IsTerminal(&state) {
+			v.succ = g.Successors(&state)
+		}
+		for _, next := range v.succ {
+			c := add(minimax.Position[T]{State: *next, IsMax: !v.pos.IsMax})
+			v.children = append(v.children, c)
+			vs[c].parents = append(vs[c].parents, i)
+		}
+		v.left = len(v.children)
+	}
+	return vs
+}
+
+// wins reports whether the outcome value is a win for the side to move
+func wins(value int, isMax bool) bool {
+	return isMax && value > 0 || !isMax && value < 0
+}
+
+// settle picks the best of the settled children of v as its answer
+func (v *vertex[T]) settle(vs []*vertex[T]) {
+	first := true
+	for i, c := range v.children {
+		child := vs[c]
+		if !child.settled {
+			continue
+		}
+		if first || better(child.answer, v.answer, v.pos.IsMax) {
+			v.answer = Answer[T]{Value: child.answer.Value, Plies: child.answer.Plies + 1, Best: v.succ[i], IsMax: v.pos.IsMax}
+			first = false
+		}
+	}
+	v.settled = true
+}
+
+// cycle returns a move from the unsettled vertex v that keeps the draw: into
+// another unsettled position, or a settled draw
+func (v *vertex[T]) cycle(vs []*vertex[T]) *T {
+	for i, c := range v.children {
+		if child := vs[c]; !child.settled || child.answer.Value == 0 {
+			return v.succ[i]
+		}
+	}
+	return nil
+}
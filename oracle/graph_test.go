@@ -0,0 +1,74 @@
+package oracle
+
+import (
+	"testing"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+// graph is a game played on a directed graph of numbered states. States
+// without moves end the game with the utility in ends.
+func graph(moves map[int][]int, ends map[int]int) minimax.Game[int] {
+	return minimax.Game[int]{
+		IsTerminal: func(s *int) bool { return len(moves[*s]) == 0 },
+		Utility:    func(s *int) int { return ends[*s] },
+		Successors: func(s *int) []*int {
+			var succ []*int
+			for _, m := range moves[*s] {
+				succ = append(succ, &m)
+			}
+			return succ
+		},
+	}
+}
+
+// TestSolveGraphAcyclic tests that SolveGraph agrees with Solve on games without cycles.
+func TestSolveGraphAcyclic(t *testing.T) {
+	want, got := Solve(nim, position(13, true)), SolveGraph(nim, position(13, true))
+	if got.Len() != want.Len() {
+		t.Fatalf("Expected %d positions, got %d", want.Len(), got.Len())
+	}
+	for pos, w := range want.answers {
+		g := got.answers[pos]
+		if g.Value != w.Value || g.Plies != w.Plies || (g.Best == nil) != (w.Best == nil) {
+			t.Errorf("%v: expected %+v, got %+v", pos, w, g)
+		}
+	}
+}
+
+// TestSolveGraphCycles tests positions that loop forever, and forced wins
+// that a cycle cannot escape.
+func TestSolveGraphCycles(t *testing.T) {
+	at := func(s int, isMax bool) minimax.Position[int] { return minimax.Position[int]{State: s, IsMax: isMax} }
+
+	// The AI moves from 0 to 1, where the opponent can return to 0 or give up at 9
+	loop := graph(map[int][]int{0: {1}, 1: {9, 0}}, map[int]int{9: 1})
+	o := SolveGraph(loop, at(0, true))
+	if a, _ := o.Query(at(0, true)); a.Value != 0 || a.Plies != 0 || a.Best == nil || *a.Best != 1 {
+		t.Errorf("Expected a draw by repetition, got %+v", a)
+	}
+	if best, _ := o.Best(at(1, false)); *best != 0 {
+		t.Errorf("Expected the opponent to keep looping, got %d", *best)
+	}
+
+	// The AI can also win directly from 0, or after a detour through the loop
+	exit := graph(map[int][]int{0: {1, 2}, 1: {9, 0}, 2: {3}, 3: {8}}, map[int]int{8: 1, 9: 1})
+	o = SolveGraph(exit, at(0, true))
+	if a, _ := o.Query(at(0, true)); a.Value != 1 || a.Plies != 3 || *a.Best != 2 {
+		t.Errorf("Expected a win in 3 moves through 2, got %+v", a)
+	}
+	if a, _ := o.Query(at(1, false)); a.Value != 1 || a.Plies != 4 || *a.Best != 0 {
+		t.Errorf("Expected the opponent to lose slowest through 0, got %+v", a)
+	}
+
+	// Every move of the opponent from 1 loses, one of them through 3, where
+	// the AI could loop through 4 but wins instead
+	trap := graph(map[int][]int{0: {1}, 1: {2, 3}, 2: {9}, 3: {4, 5}, 4: {3}, 5: {9}}, map[int]int{9: 1})
+	o = SolveGraph(trap, at(0, true))
+	if a, _ := o.Query(at(1, false)); a.Value != 1 || a.Plies != 3 || *a.Best != 3 {
+		t.Errorf("Expected the opponent to lose in 3 moves through 3, got %+v", a)
+	}
+	if a, _ := o.Query(at(3, true)); a.Value != 1 || a.Plies != 2 || *a.Best != 5 {
+		t.Errorf("Expected the AI to leave the loop through 5, got %+v", a)
+	}
+}
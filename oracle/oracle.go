@@ -0,0 +1,212 @@
+// Package oracle answers questions about games that are small enough to be
+// solved completely. Solve computes the outcome of every position reachable
+// from a start position under perfect play, and the resulting Oracle tells
+// the value, the distance to the end of the game and the best move of any of
+// them in constant time:
+//
+//	o := oracle.Solve(game, start)
+//	best, ok := o.Best(pos)
+//
+// Games whose positions can repeat, such as sliding puzzles or games without
+// a move limit, are solved with SolveGraph instead.
+//
+// An Oracle is read-only once built, so any number of goroutines may query it
+// at once. It can be saved as JSON, reloaded without solving the game again,
+// served over HTTP with Handler, embedded in a binary as Go source generated
+// by WriteGo, or compressed into a Compact oracle for larger games.
+package oracle
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+// Answer is what the oracle knows about a position
+type Answer[T comparable] struct {
+	Value int  // Outcome for the AI under perfect play: 1 for a win, -1 for a loss, 0 for a draw
+	Plies int  // Moves until the game ends under perfect play
+	Best  *T   // Best move for the side to move, nil at the end of the game
+	IsMax bool // Whether the AI is to move
+}
+
+// DTW returns the distance to win: the number of moves until the winner wins
+// under perfect play, and false for draws
+func (a Answer[T]) DTW() (int, bool) {
+	return a.Plies, a.Value != 0
+}
+
+// Oracle holds the solution of every position reachable from a start position
+type Oracle[T comparable] struct {
+	start   minimax.Position[T]
+	answers map[minimax.Position[T]]Answer[T]
+}
+
+// Solve computes the perfect-play outcome of every position reachable from
+// start. Winners take the fastest win and losers the slowest loss. The game
+// must be finite and free of cycles, and all its positions must fit in memory.
+func Solve[T comparable](g minimax.Game[T], start minimax.Position[T]) *Oracle[T] {
+	o := &Oracle[T]{start: start, answers: make(map[minimax.Position[T]]Answer[T])}
+	o.solve(g, start)
+	return o
+}
+
+// solve computes and stores the answer for pos, reusing known answers
+func (o *Oracle[T]) solve(g minimax.Game[T], pos minimax.Position[T]) Answer[T] {
+	if a, ok := o.answers[pos]; ok {
+		return a
+	}
+
+	state := pos.State
+	a := Answer[T]{IsMax: pos.IsMax}
+	var succ []*T
+	if !g.IsTerminal(&state) {
+		succ = g.Successors(&state)
+	}
+	if len(succ) == 0 {
+		a.Value = sign(g.Utility(&state))
+		o.answers[pos] = a
+		return a
+	}
+
+	for i, next := range succ {
+		child := o.solve(g, minimax.Position[T]{State: *next, IsMax: !pos.IsMax})
+		if i == 0 || better(child, a, pos.IsMax) {
+			a.Value, a.Plies, a.Best = child.Value, child.Plies+1, next
+		}
+	}
+	o.answers[pos] = a
+	return a
+}
+
+// better reports whether the child answer c beats the best answer so far for
+// the side to move: a better outcome, or the same one reached sooner when
+// winning and later when losing or drawing
+func better[T comparable](c, best Answer[T], isMax bool) bool {
+	v, bv := c.Value, best.Value
+	if !isMax {
+		v, bv = -v, -bv
+	}
+	switch {
+	case v != bv:
+		return v > bv
+	case v > 0:
+		return c.Plies+1 < best.Plies
+	default:
+		return c.Plies+1 > best.Plies
+	}
+}
+
+// sign returns -1, 0 or 1 depending on the sign of x
+func sign(x int) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// Start returns the position the oracle was solved from
+func (o *Oracle[T]) Start() minimax.Position[T] {
+	return o.start
+}
+
+// Len returns the number of positions the oracle knows
+func (o *Oracle[T]) Len() int {
+	return len(o.answers)
+}
+
+// Query returns the answer for pos, and false if pos is not reachable from the start
+func (o *Oracle[T]) Query(pos minimax.Position[T]) (Answer[T], bool) {
+	a, ok := o.answers[pos]
+	return a, ok
+}
+
+// Value returns the outcome of pos for the AI under perfect play
+func (o *Oracle[T]) Value(pos minimax.Position[T]) (int, bool) {
+	a, ok := o.answers[pos]
+	return a.Value, ok
+}
+
+// Best returns the best move from pos, and false if pos is unknown or over
+func (o *Oracle[T]) Best(pos minimax.Position[T]) (*T, bool) {
+	a, ok := o.answers[pos]
+	return a.Best, ok && a.Best != nil
+}
+
+// DTW returns the number of moves until the winner of pos wins under perfect
+// play, and false if pos is unknown or drawn
+func (o *Oracle[T]) DTW(pos minimax.Position[T]) (int, bool) {
+	a, ok := o.answers[pos]
+	if !ok {
+		return 0, false
+	}
+	return a.DTW()
+}
+
+// Merge adds the positions other knows to o, such as those of oracles solved
+// from different start positions, to combine partial solves into one oracle.
+// Both must solve the same game, so positions they share should have the
+// same answer; where they do not, o keeps its own, and the number of such
+// conflicts is returned. o must not be queried during the merge.
+func (o *Oracle[T]) Merge(other *Oracle[T]) int {
+	conflicts := 0
+	for pos, a := range other.answers {
+		mine, ok := o.answers[pos]
+		switch {
+		case !ok:
+			o.answers[pos] = a
+		case mine.Value != a.Value || mine.Plies != a.Plies:
+			conflicts++
+		}
+	}
+	return conflicts
+}
+
+// entry is an answer as saved in JSON
+type entry[T comparable] struct {
+	State T    `json:"state"`
+	IsMax bool `json:"max"`
+	Value int  `json:"value"`
+	Plies int  `json:"plies"`
+	Best  *T   `json:"best,omitempty"`
+}
+
+// file is an oracle as saved in JSON
+type file[T comparable] struct {
+	Start    T          `json:"start"`
+	StartMax bool       `json:"start_max"`
+	Answers  []entry[T] `json:"answers"`
+}
+
+// WriteJSON saves the oracle to w as JSON. The state type must be
+// serializable with encoding/json, as for minimax.GameRecord.
+func (o *Oracle[T]) WriteJSON(w io.Writer) error {
+	f := file[T]{Start: o.start.State, StartMax: o.start.IsMax, Answers: make([]entry[T], 0, len(o.answers))}
+	for pos, a := range o.answers {
+		f.Answers = append(f.Answers, entry[T]{State: pos.State, IsMax: pos.IsMax, Value: a.Value, Plies: a.Plies, Best: a.Best})
+	}
+	return json.NewEncoder(w).Encode(f)
+}
+
+// ReadJSON loads an oracle saved by WriteJSON
+func ReadJSON[T comparable](r io.Reader) (*Oracle[T], error) {
+	var f file[T]
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, err
+	}
+
+	o := &Oracle[T]{
+		start:   minimax.Position[T]{State: f.Start, IsMax: f.StartMax},
+		answers: make(map[minimax.Position[T]]Answer[T], len(f.Answers)),
+	}
+	for _, e := range f.Answers {
+		pos := minimax.Position[T]{State: e.State, IsMax: e.IsMax}
+		o.answers[pos] = Answer[T]{Value: e.Value, Plies: e.Plies, Best: e.Best, IsMax: e.IsMax}
+	}
+	return o, nil
+}
@@ -0,0 +1,156 @@
+package oracle
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+// pile is a game of Nim where players take 1 to 3 stones and whoever takes
+// the last stone wins
+type pile struct {
+	Stones int  `json:"stones"`
+	AITurn bool `json:"ai"`
+}
+
+var nim = minimax.Game[pile]{
+	IsTerminal: func(s *pile) bool { return s.Stones == 0 },
+	Utility: func(s *pile) int {
+		if s.Stones > 0 {
+			return 0
+		}
+		if s.AITurn {
+			return -1
+		}
+		return 1
+	},
+	Successors: func(s *pile) []*pile {
+		var succ []*pile
+		for take := 1; take <= min(3, s.Stones); take++ {
+			succ = append(succ, &pile{Stones: s.Stones - take, AITurn: !s.AITurn})
+		}
+		return succ
+	},
+}
+
+func position(stones int, ai bool) minimax.Position[pile] {
+	return minimax.Position[pile]{State: pile{stones, ai}, IsMax: ai}
+}
+
+// TestSolve tests values, best moves and distances to win.
+func TestSolve(t *testing.T) {
+	o := Solve(nim, position(10, true))
+
+	if v, _ := o.Value(position(10, true)); v != 1 {
+		t.Errorf("Expected a win with 10 stones, got %d", v)
+	}
+	if best, ok := o.Best(position(10, true)); !ok || best.Stones != 8 {
+		t.Errorf("Expected to leave 8 stones, got %v", best)
+	}
+	if dtw, ok := o.DTW(position(10, true)); !ok || dtw != 5 {
+		t.Errorf("Expected a win in 5 moves, got %d", dtw)
+	}
+	// The loser holds out as long as possible
+	if v, _ := o.Value(position(8, false)); v != 1 {
+		t.Errorf("Expected the opponent to lose with 8 stones, got %d", v)
+	}
+	if dtw, _ := o.DTW(position(8, false)); dtw != 4 {
+		t.Errorf("Expected the loss to take 4 moves, got %d", dtw)
+	}
+	if _, ok := o.Query(position(11, true)); ok {
+		t.Error("Expected an unreachable position to be unknown")
+	}
+}
+
+// TestPersistence tests that a saved oracle answers like the original.
+func TestPersistence(t *testing.T) {
+	o := Solve(nim, position(12, false))
+	var buf bytes.Buffer
+	if err := o.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := ReadJSON[pile](&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if loaded.Len() != o.Len() || loaded.Start() != o.Start() {
+		t.Fatalf("Expected %d positions, got %d", o.Len(), loaded.Len())
+	}
+	for stones := range 13 {
+		for _, ai := range []bool{true, false} {
+			want, _ := o.Query(position(stones, ai))
+			got, _ := loaded.Query(position(stones, ai))
+			if got.Value != want.Value || got.Plies != want.Plies || (got.Best == nil) != (want.Best == nil) ||
+				got.Best != nil && *got.Best != *want.Best {
+				t.Errorf("%d stones: expected %+v, got %+v", stones, want, got)
+			}
+		}
+	}
+}
+
+// TestMerge tests that merged oracles answer for the positions of both.
+func TestMerge(t *testing.T) {
+	o, other := Solve(nim, position(10, true)), Solve(nim, position(11, false))
+	size := o.Len()
+	if n := o.Merge(other); n != 0 {
+		t.Errorf("Expected no conflicts, got %d", n)
+	}
+	if _, ok := o.Query(position(11, false)); !ok || o.Len() <= size {
+		t.Error("Expected the positions of the other oracle to be added")
+	}
+	if best, ok := o.Best(position(10, true)); !ok || best.Stones != 8 {
+		t.Errorf("Expected to leave 8 stones, got %v", best)
+	}
+
+	// A disagreeing oracle does not overwrite the answers already known
+	other.answers[position(8, false)] = Answer[pile]{Value: -1, Plies: 1}
+	if n := o.Merge(other); n != 1 {
+		t.Errorf("Expected 1 conflict, got %d", n)
+	}
+	if v, _ := o.Value(position(8, false)); v != 1 {
+		t.Errorf("Expected the known answer to be kept, got %d", v)
+	}
+}
+
+// TestHandler tests queries over HTTP.
+func TestHandler(t *testing.T) {
+	o := Solve(nim, position(10, true))
+	parse := func(s string) (pile, bool) {
+		n, err := strconv.Atoi(s)
+		return pile{Stones: n, AITurn: true}, err == nil
+	}
+	format := func(s *pile) string { return fmt.Sprint(s.Stones) }
+	srv := httptest.NewServer(Handler(o, parse, format))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "?state=10&max=true")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r Response
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if r.Value != 1 || r.Best != "8" || r.DTW == nil || *r.DTW != 5 {
+		t.Errorf("Unexpected response %+v", r)
+	}
+
+	for query, code := range map[string]int{"?state=x&max=true": 400, "?state=11&max=true": 404} {
+		resp, err := http.Get(srv.URL + query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != code {
+			t.Errorf("%s: expected status %d, got %d", query, code, resp.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package oracle
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+// Response is the JSON body Handler answers with
+type Response struct {
+	Value int    `json:"value"`          // Outcome for the AI under perfect play
+	Plies int    `json:"plies"`          // Moves until the game ends
+	DTW   *int   `json:"dtw,omitempty"`  // Moves until the winner wins, absent for draws
+	Best  string `json:"best,omitempty"` // Best move, formatted, absent at the end of the game
+}
+
+// Handler serves queries to o over HTTP. A GET request names the position
+// with the parameters state, read by parse, and max, "true" if the AI is to
+// move; it is answered with a Response, formatting moves with format. Unknown
+// positions get a 404 and malformed ones a 400.
+func Handler[T comparable](o *Oracle[T], parse func(string) (T, bool), format func(*T) string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		q := r.URL.Query()
+		state, ok := parse(q.Get("state"))
+		isMax, err := strconv.ParseBool(q.Get("max"))
+		if !ok || err != nil {
+			http.Error(w, "bad position", http.StatusBadRequest)
+			return
+		}
+
+		a, ok := o.Query(minimax.Position[T]{State: state, IsMax: isMax})
+		if !ok {
+			http.Error(w, "unknown position", http.StatusNotFound)
+			return
+		}
+
+		resp := Response{Value: a.Value, Plies: a.Plies}
+		if dtw, ok := a.DTW(); ok {
+			resp.DTW = &dtw
+		}
+		if a.Best != nil {
+			resp.Best = format(a.Best)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	})
+}
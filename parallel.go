@@ -0,0 +1,61 @@
+package minimax
+
+import (
+	"runtime"
+	"sync"
+)
+
+// splitDepth is the depth below which nodes are no longer split between
+// workers, since their subtrees are too small to be worth a goroutine
+const splitDepth = 8
+
+// WithParallel searches subtrees concurrently using at most workers goroutines
+// (GOMAXPROCS if workers is not positive), including the one calling Make.
+//
+// Siblings are split the "young brothers wait" way: a node's first child is
+// searched alone to establish a bound, then its younger siblings are searched
+// together while worker slots are free, and inline when the pool is busy.
+// The best move is the same as with a sequential search, but the younger
+// siblings get wider windows, so more nodes are usually visited in total.
+//
+// The game functions must be safe to call concurrently. Tracing and
+// replaying always search sequentially, so traces stay deterministic.
+func WithParallel(workers int) Option {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return func(o *options) {
+		o.workers = workers
+	}
+}
+
+// splits reports whether the younger children of n should be searched in parallel
+func (s *search[T]) splits(n *node[T]) bool {
+	return s.sem != nil && n.depth < splitDepth && len(n.children) > 2
+}
+
+// searchSiblings searches every child of n but the first, handing subtrees to
+// new goroutines while worker slots are available, and waits for all of them.
+// The values are left in n.splitVals.
+func (s *search[T]) searchSiblings(n *node[T], path *ancestor[T]) {
+	n.split = true
+	n.splitVals = make([]nodeValue, len(n.children))
+	alpha, beta := n.alpha, n.beta
+
+	var wg sync.WaitGroup
+	defer wg.Wait() // Even if a panic unwinds, so that no worker outlives the search
+	for i, child := range n.children[1:] {
+		select {
+		case s.sem <- struct{}{}:
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-s.sem }()
+				defer s.catch()
+				n.splitVals[i+1] = s.minimax(child, alpha, beta, path)
+			}()
+		default:
+			n.splitVals[i+1] = s.minimax(child, alpha, beta, path)
+		}
+	}
+}
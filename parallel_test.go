@@ -0,0 +1,47 @@
+package minimax
+
+import "testing"
+
+// TestParallelMatchesSequential tests that a parallel search finds the same value and move.
+func TestParallelMatchesSequential(t *testing.T) {
+	for stones := 15; stones <= 18; stones++ {
+		state := nimState{stones: stones, aiTurn: true}
+		seq := Make(&state, nimTerminal, nimUtility, nimSuccessors, true)
+		par := Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithParallel(4))
+
+		if seq.Result().Value != par.Result().Value {
+			t.Errorf("%d stones: expected value %d, got %d",
+				stones, seq.Result().Value, par.Result().Value)
+		}
+		if *seq.Solve(state) != *par.Solve(state) {
+			t.Errorf("%d stones: expected move %v, got %v",
+				stones, *seq.Solve(state), *par.Solve(state))
+		}
+	}
+}
+
+// TestParallelNodeLimit tests that parallel workers respect a shared node limit.
+func TestParallelNodeLimit(t *testing.T) {
+	state := pathState{}
+	mm := Make(&state, pathTerminal, pathUtility, pathSuccessors, true,
+		WithParallel(4), WithLimits(Limits{MaxNodes: 5000}))
+
+	res := mm.Result()
+	if res.Stopped != StopNodes || res.Nodes != 5000 {
+		t.Errorf("Expected to stop at exactly 5000 nodes, got %d (%v)", res.Nodes, res.Stopped)
+	}
+}
+
+// TestParallelMatchesSequentialTree tests parallel search on a tree with varied leaf values.
+func TestParallelMatchesSequentialTree(t *testing.T) {
+	state := pathState{depth: pathDepth - 9}
+	seq := Make(&state, pathTerminal, pathUtility, pathSuccessors, true)
+	par := Make(&state, pathTerminal, pathUtility, pathSuccessors, true, WithParallel(4))
+
+	if seq.Result().Value != par.Result().Value {
+		t.Errorf("Expected value %d, got %d", seq.Result().Value, par.Result().Value)
+	}
+	if *seq.Solve(state) != *par.Solve(state) {
+		t.Errorf("Expected move %v, got %v", *seq.Solve(state), *par.Solve(state))
+	}
+}
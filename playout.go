@@ -0,0 +1,110 @@
+package minimax
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Policy picks the next state among the successors of state during a playout
+type Policy[T comparable] func(state *T, successors []*T, r *rand.Rand) *T
+
+// RandomPolicy picks a successor uniformly at random
+func RandomPolicy[T comparable](state *T, successors []*T, r *rand.Rand) *T {
+	return successors[r.Intn(len(successors))]
+}
+
+// Playout plays moves chosen by policy (RandomPolicy if nil) from start until
+// a terminal state, a state without successors, or maxPlies moves if maxPlies
+// is positive. It returns the final state, the number of moves played, and
+// whether the game actually ended.
+func Playout[T comparable](g Game[T], start T, policy Policy[T], r *rand.Rand, maxPlies int) (T, int, bool) {
+	if policy == nil {
+		policy = RandomPolicy[T]
+	}
+
+	state := start
+	for plies := 0; ; plies++ {
+		if g.IsTerminal(&state) {
+			return state, plies, true
+		}
+		succ := g.Successors(&state)
+		if len(succ) == 0 {
+			return state, plies, true
+		}
+		if maxPlies > 0 && plies >= maxPlies {
+			return state, plies, false
+		}
+		state = *policy(&state, succ, r)
+	}
+}
+
+// PlayoutStats summarises a batch of playouts
+type PlayoutStats struct {
+	Games      int         // Playouts run
+	Unfinished int         // Playouts stopped by the move limit
+	Outcomes   map[int]int // Number of finished playouts by utility of the final state
+	MinLength  int         // Shortest playout, in moves
+	MaxLength  int         // Longest playout, in moves
+	AvgLength  float64     // Average playout length, in moves
+}
+
+// Wins returns the number of finished playouts won by the AI
+func (p PlayoutStats) Wins() int {
+	return p.count(func(u int) bool { return u > 0 })
+}
+
+// Losses returns the number of finished playouts lost by the AI
+func (p PlayoutStats) Losses() int {
+	return p.count(func(u int) bool { return u < 0 })
+}
+
+// Draws returns the number of finished playouts drawn
+func (p PlayoutStats) Draws() int {
+	return p.count(func(u int) bool { return u == 0 })
+}
+
+// count sums the outcomes whose utility satisfies keep
+func (p PlayoutStats) count(keep func(int) bool) int {
+	total := 0
+	for u, n := range p.Outcomes {
+		if keep(u) {
+			total += n
+		}
+	}
+	return total
+}
+
+// String returns a one-line summary of the playouts
+func (p PlayoutStats) String() string {
+	return fmt.Sprintf("%d games: %d wins, %d draws, %d losses, %d unfinished; length %d-%d (avg %.1f)",
+		p.Games, p.Wins(), p.Draws(), p.Losses(), p.Unfinished, p.MinLength, p.MaxLength, p.AvgLength)
+}
+
+// Simulate runs n playouts from start (see Playout) and reports the
+// distribution of their outcomes and lengths. It is a quick sanity check of
+// game rules: a game that never ends, or that one side always wins with
+// random play, is often a bug.
+func Simulate[T comparable](g Game[T], start T, n int, policy Policy[T], r *rand.Rand, maxPlies int) PlayoutStats {
+	stats := PlayoutStats{Outcomes: make(map[int]int)}
+	total := 0
+	for i := range n {
+		final, plies, finished := Playout(g, start, policy, r, maxPlies)
+
+		stats.Games++
+		if finished {
+			stats.Outcomes[g.Utility(&final)]++
+		} else {
+			stats.Unfinished++
+		}
+		if i == 0 || plies < stats.MinLength {
+			stats.MinLength = plies
+		}
+		stats.MaxLength = max(stats.MaxLength, plies)
+		total += plies
+	}
+
+	if n > 0 {
+		stats.AvgLength = float64(total) / float64(n)
+	}
+	return stats
+}
@@ -0,0 +1,40 @@
+package minimax
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestSimulate tests the outcome and length distribution of random Nim games.
+func TestSimulate(t *testing.T) {
+	game := Game[nimState]{IsTerminal: nimTerminal, Utility: nimUtility, Successors: nimSuccessors}
+	start := nimState{stones: 10, aiTurn: true}
+
+	stats := Simulate(game, start, 500, nil, rand.New(rand.NewSource(1)), 0)
+	if stats.Games != 500 || stats.Unfinished != 0 {
+		t.Fatalf("Expected 500 finished games, got %v", stats)
+	}
+	if stats.Wins()+stats.Losses() != 500 || stats.Draws() != 0 {
+		t.Errorf("Expected Nim to never draw, got %v", stats)
+	}
+	// Between 4 moves (taking 3 each time, then 1) and 10 moves (taking 1 each time)
+	if stats.MinLength < 4 || stats.MaxLength > 10 || stats.AvgLength < 4 || stats.AvgLength > 10 {
+		t.Errorf("Unexpected lengths in %v", stats)
+	}
+}
+
+// TestPlayoutPolicy tests that a policy drives the playout and that the move limit stops it.
+func TestPlayoutPolicy(t *testing.T) {
+	game := Game[nimState]{IsTerminal: nimTerminal, Utility: nimUtility, Successors: nimSuccessors}
+	takeOne := func(s *nimState, succ []*nimState, r *rand.Rand) *nimState { return succ[0] }
+
+	final, plies, finished := Playout(game, nimState{stones: 10, aiTurn: true}, takeOne, nil, 0)
+	if !finished || plies != 10 || nimUtility(&final) != -1 {
+		t.Errorf("Expected a 10-move loss, got %+v after %d moves (finished %v)", final, plies, finished)
+	}
+
+	final, plies, finished = Playout(game, nimState{stones: 10, aiTurn: true}, takeOne, nil, 3)
+	if finished || plies != 3 || final.stones != 7 {
+		t.Errorf("Expected to stop after 3 moves, got %+v after %d moves", final, plies)
+	}
+}
@@ -0,0 +1,65 @@
+package minimax
+
+import (
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// WithParallelExpansion generates the successors of a node's children
+// concurrently, using at most workers goroutines (GOMAXPROCS if workers is
+// not positive), as soon as the node is expanded and before alpha-beta
+// searches them one by one. It pays off when generating and validating moves
+// dominates the search, and works with or without WithParallel.
+//
+// Children that are terminal, at the depth limit, or already being searched
+// are skipped. Successors generated for children that a cutoff then prunes
+// are wasted, so more successors are generated in total than sequentially.
+// The game functions must be safe to call concurrently.
+func WithParallelExpansion(workers int) Option {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return func(o *options) {
+		o.expandWorkers = workers
+	}
+}
+
+// prefetch generates the successors of the children of n concurrently,
+// leaving them in each child's pending list for expandNode
+func (s *search[T]) prefetch(n *node[T]) {
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for range min(s.expandWorkers, len(n.children)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer s.catch()
+			for i := int(next.Add(1) - 1); i < len(n.children); i = int(next.Add(1) - 1) {
+				s.pend(n.children[i])
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// pend generates the successors of child into its pending list, unless it
+// is busy or will not be expanded
+func (s *search[T]) pend(child *node[T]) {
+	if !child.mu.TryLock() {
+		return // Searched by another worker, or an ancestor
+	}
+	defer child.mu.Unlock()
+	if !child.expanded && !child.prefetched && !s.leaf(child) {
+		succ, release := s.generate(child.elem)
+		defer release()
+		child.pending = slices.Clone(succ)
+		child.prefetched = true
+	}
+}
+
+// leaf reports whether n will not be expanded, being terminal or at the depth limit
+func (s *search[T]) leaf(n *node[T]) bool {
+	return s.cutOff(n) || s.isTerminal(n.elem)
+}
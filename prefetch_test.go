@@ -0,0 +1,39 @@
+package minimax
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+// TestParallelExpansion tests that generating grandchildren concurrently finds the same move and value.
+func TestParallelExpansion(t *testing.T) {
+	var calls atomic.Int64
+	successors := func(s *nimState) []*nimState {
+		calls.Add(1)
+		return nimSuccessors(s)
+	}
+	state := nimState{stones: 21, aiTurn: true}
+
+	for _, tc := range []struct {
+		name    string
+		workers int
+		opts    []Option
+	}{
+		{"sequential", 4, nil},
+		{"parallel", 0, []Option{WithParallel(4)}},
+		{"limited", 2, []Option{WithLimits(Limits{MaxDepth: 5})}},
+	} {
+		calls.Store(0)
+		want := Make(&state, nimTerminal, nimUtility, successors, true, tc.opts...)
+		sequential := calls.Swap(0)
+		mm := Make(&state, nimTerminal, nimUtility, successors, true, append(tc.opts, WithParallelExpansion(tc.workers))...)
+
+		if *mm.Solve(state) != *want.Solve(state) || mm.Result().Value != want.Result().Value {
+			t.Errorf("%s: expected %v worth %d, got %v worth %d", tc.name,
+				want.Solve(state), want.Result().Value, mm.Solve(state), mm.Result().Value)
+		}
+		if tc.opts == nil && calls.Load() < sequential {
+			t.Errorf("%s: expected at least %d successor calls, got %d", tc.name, sequential, calls.Load())
+		}
+	}
+}
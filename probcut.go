@@ -0,0 +1,133 @@
+package minimax
+
+import (
+	"math"
+	"time"
+)
+
+// ProbCut configures ProbCut (Buro, 1995), which prunes a node from the
+// result of a shallow search: the deep value of a node is predicted from its
+// shallow value v as A*v + B, with errors of standard deviation Sigma. When
+// the prediction falls outside the node's window by more than T standard
+// deviations, the deep search is skipped and the window bound it would most
+// likely have failed at stands as the node's value.
+//
+// A, B and Sigma are fitted by linear regression over pairs of shallow and
+// deep values of positions from real games, such as those collected with
+// WithHistograms or a tuning run.
+type ProbCut struct {
+	Depth   int     // Plies left to the depth limit at which ProbCut is tried
+	Shallow int     // Plies searched by the shallow search, less than Depth
+	A, B    float64 // Linear model of the deep value from the shallow one; A is 1 if 0
+	Sigma   float64 // Standard deviation of the model's error
+	T       float64 // Threshold, in standard deviations; 1.5 if 0
+}
+
+// WithProbCut enables ProbCut with the given model. It needs a depth limit and
+// WithHeuristic or WithEvaluator, and the values it prunes count as unknown.
+// Larger thresholds prune less but err less often.
+func WithProbCut(pc ProbCut) Option {
+	if pc.A == 0 {
+		pc.A = 1
+	}
+	if pc.T == 0 {
+		pc.T = 1.5
+	}
+	return func(o *options) {
+		o.probCut = pc
+	}
+}
+
+// probCutoff reports whether n can be pruned by ProbCut, setting its value if so
+func (s *search[T]) probCutoff(n *node[T]) bool {
+	pc := s.probCut
+	if s.limits.MaxDepth == 0 || s.limits.MaxDepth-n.depth != pc.Depth || s.exact(n) {
+		return false
+	}
+
+	// Shallow values beyond these predict a deep value outside the window
+	high := (float64(n.beta) + pc.T*pc.Sigma - pc.B) / pc.A
+	low := (float64(n.alpha) - pc.T*pc.Sigma - pc.B) / pc.A
+	if pc.A < 0 {
+		high, low = low, high
+	}
+	if high > MaxHeuristic && low < -MaxHeuristic {
+		return false // No heuristic value can cut, skip the shallow search
+	}
+
+	// Null-window shallow searches only tell which side of a threshold the
+	// shallow value is on, which is all it takes and far cheaper
+	if high <= MaxHeuristic {
+		if v, ok := s.shallow(n, int(math.Ceil(high))-1); ok && float64(v) >= high {
+			return s.probPrune(n, n.beta)
+		}
+	}
+	if low >= -MaxHeuristic {
+		if v, ok := s.shallow(n, int(math.Floor(low))); ok && float64(v) <= low {
+			return s.probPrune(n, n.alpha)
+		}
+	}
+	return false
+}
+
+// probPrune sets the value of n, pruned by ProbCut, to the window bound v
+func (s *search[T]) probPrune(n *node[T], v int) bool {
+	n.val = v
+	n.unknown = true
+	s.truncated.Store(true)
+	return true
+}
+
+// shallow returns the value of the shallow search of n within the null
+// window [alpha, alpha+1], if it completed
+func (s *search[T]) shallow(n *node[T], alpha int) (int, bool) {
+	sub, ok := s.subsearch(n, n.depth+s.probCut.Shallow, alpha, alpha+1)
+	return sub.result.Value, ok
+}
+
+// subconfig returns the configuration of a search from n limited to maxDepth
+// plies from the root and within the window [alpha, beta], on the caller's
+// worker, without reporting and within what is left of the node and time
+// limits, or false if nothing is left
+func (s *search[T]) subconfig(n *node[T], maxDepth, alpha, beta int) (config[T], bool) {
+	cf := *s.config
+	cf.limits = Limits{MaxDepth: maxDepth}
+	if s.limits.MaxNodes > 0 {
+		if cf.limits.MaxNodes = s.limits.MaxNodes - int(s.nodes.Load()); cf.limits.MaxNodes <= 0 {
+			return cf, false
+		}
+	}
+	if !s.deadline.IsZero() {
+		if cf.limits.MaxTime = time.Until(s.deadline); cf.limits.MaxTime <= 0 {
+			return cf, false
+		}
+	}
+	cf.progress, cf.tracer, cf.observer, cf.hints = nil, nil, nil, nil
+	cf.onlyMove, cf.rootStats = false, false
+	cf.workers = 0 // The sub-search runs on the caller's worker
+	cf.isMax = n.isMax
+	cf.rootCost = n.cost
+	cf.window = [2]int{alpha, beta}
+	return cf, true
+}
+
+// subsearch runs a search from n configured by subconfig, and returns it if
+// it completed
+func (s *search[T]) subsearch(n *node[T], maxDepth, alpha, beta int) (Minimax[T], bool) {
+	cf, ok := s.subconfig(n, maxDepth, alpha, beta)
+	if !ok {
+		return Minimax[T]{}, false
+	}
+	return s.run(n, cf)
+}
+
+// run searches from n with cf, counting its nodes, and returns the search if
+// it completed
+func (s *search[T]) run(n *node[T], cf config[T]) (Minimax[T], bool) {
+	sub := buildAt(n.elem, cf, nil, n.depth)
+	s.nodes.Add(int64(sub.result.Nodes))
+	if sub.result.Stopped != StopNone && sub.result.Stopped != StopDepth {
+		return Minimax[T]{}, false
+	}
+	return sub, true
+}
@@ -0,0 +1,33 @@
+package minimax
+
+import "testing"
+
+// pathHeuristic adds up the moves that led to a path state, each worth -10, 0
+// or 10 to the AI, with some noise, so that deeper values follow shallower ones
+func pathHeuristic(s *pathState) int {
+	v := 0
+	for p := s.path; p > 0; p /= 3 {
+		v += 10 * (int(p%3) - 1)
+	}
+	return v + int(s.path*2654435761%7) - 3
+}
+
+// TestProbCut tests that ProbCut saves nodes without changing the move when
+// the shallow search predicts the deep one well.
+func TestProbCut(t *testing.T) {
+	state := pathState{}
+	opts := []Option{WithLimits(Limits{MaxDepth: 8}), WithHeuristic(pathHeuristic)}
+	full := Make(&state, pathTerminal, pathUtility, pathSuccessors, true, opts...)
+	cut := Make(&state, pathTerminal, pathUtility, pathSuccessors, true,
+		append(opts, WithProbCut(ProbCut{Depth: 4, Shallow: 2, Sigma: 2}))...)
+
+	if got, want := cut.Solve(state), full.Solve(state); *got != *want {
+		t.Errorf("Expected %v, got %v", *want, *got)
+	}
+	if cut.Result().Nodes >= full.Result().Nodes {
+		t.Errorf("Expected fewer nodes with ProbCut, got %d and %d without", cut.Result().Nodes, full.Result().Nodes)
+	}
+	if !cut.Result().Unknown {
+		t.Error("Expected pruned values to count as unknown")
+	}
+}
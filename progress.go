@@ -0,0 +1,69 @@
+package minimax
+
+import "time"
+
+// defaultProgressInterval is used when WithProgress is given a non-positive interval
+const defaultProgressInterval = 100 * time.Millisecond
+
+// Progress is a snapshot of a running search, in the spirit of the "info"
+// lines chess engines send to their GUIs
+type Progress[T comparable] struct {
+	Depth    int           // Deepest ply reached so far
+	BestMove *T            // Best root move found so far, nil if none yet
+	Score    int           // Value of BestMove for the AI
+	Nodes    int           // Nodes visited so far
+	NPS      int           // Nodes visited per second
+	Elapsed  time.Duration // Time spent searching
+	Done     bool          // True for the final report of a search
+	PV       []*T          // Principal variation, the expected line of play from the searched state; set by Analyze
+}
+
+// WithProgress calls fn periodically while searching, at most once per interval
+// (100ms if interval is not positive), and once more when the search ends.
+// fn runs on the searching goroutine, so it should return quickly.
+func WithProgress[T comparable](interval time.Duration, fn func(Progress[T])) Option {
+	if interval <= 0 {
+		interval = defaultProgressInterval
+	}
+	return func(o *options) {
+		o.progress = fn
+		o.progressInterval = interval
+	}
+}
+
+// tick reports progress if the interval has elapsed since the last report
+func (s *search[T]) tick(now time.Time) {
+	if s.progress == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.lastReport) >= s.progressInterval {
+		s.report(now, false)
+	}
+}
+
+// report sends the current state of the search to the progress callback.
+// The caller must hold s.mu, so that reports are never sent concurrently.
+func (s *search[T]) report(now time.Time, done bool) {
+	if s.progress == nil {
+		return
+	}
+	s.lastReport = now
+
+	p := Progress[T]{
+		Depth:   int(s.maxDepth.Load()),
+		Nodes:   int(s.nodes.Load()),
+		Elapsed: now.Sub(s.start),
+		Done:    done,
+	}
+	if s.best != nil {
+		p.BestMove = s.best.elem
+		p.Score = s.bestVal
+	}
+	if secs := p.Elapsed.Seconds(); secs > 0 {
+		p.NPS = int(float64(p.Nodes) / secs)
+	}
+	s.progress(p)
+}
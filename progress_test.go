@@ -0,0 +1,47 @@
+package minimax
+
+import (
+	"testing"
+	"time"
+)
+
+// TestProgressFinalReport tests that a finished search sends a final report with its best move.
+func TestProgressFinalReport(t *testing.T) {
+	state := nimState{stones: 6, aiTurn: true}
+
+	var reports []Progress[nimState]
+	Make(&state, nimTerminal, nimUtility, nimSuccessors, true,
+		WithProgress(time.Hour, func(p Progress[nimState]) {
+			reports = append(reports, p)
+		}))
+
+	if len(reports) != 1 {
+		t.Fatalf("Expected a single report, got %d", len(reports))
+	}
+	last := reports[0]
+	if !last.Done {
+		t.Error("Expected the final report to be marked done")
+	}
+	if last.BestMove == nil || last.BestMove.stones != 4 {
+		t.Errorf("Expected best move leaving 4 stones, got %v", last.BestMove)
+	}
+	if last.Score <= 0 || last.Nodes == 0 {
+		t.Errorf("Expected a winning score and a node count, got %+v", last)
+	}
+}
+
+// TestProgressPeriodic tests that long searches report periodically.
+func TestProgressPeriodic(t *testing.T) {
+	state := pathState{}
+
+	reports := 0
+	Make(&state, pathTerminal, pathUtility, pathSuccessors, true,
+		WithLimits(Limits{MaxTime: 100 * time.Millisecond}),
+		WithProgress(10*time.Millisecond, func(p Progress[pathState]) {
+			reports++
+		}))
+
+	if reports < 3 {
+		t.Errorf("Expected several reports, got %d", reports)
+	}
+}
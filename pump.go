@@ -0,0 +1,91 @@
+package minimax
+
+import "math"
+
+// Pump is a search that only runs while its owner pumps it, for environments
+// such as browsers (GOOS=js) where a long blocking computation freezes the
+// page. The owner calls Pump from its event loop, for instance once per
+// animation frame, and each call runs a bounded slice of the search before
+// returning control.
+type Pump[T comparable] struct {
+	*Search[T]
+	nodes int // Nodes visited so far, only read between slices
+}
+
+// pumper hands control back and forth between a search and its owner
+type pumper struct {
+	grant  chan int      // Nodes the search may visit before yielding
+	yield  chan struct{} // Sent by the search when it used up its grant
+	credit int           // Nodes left in the current grant
+	nodes  int           // Nodes visited in all grants
+}
+
+// SolvePump prepares a search for the best move from state that runs only
+// inside calls to Pump. Like SolveAsync, the Minimax must not be used again
+// until the search is over. The search is sequential, whatever WithParallel says.
+func (m Minimax[T]) SolvePump(state T) *Pump[T] {
+	h := &Search[T]{done: make(chan struct{}), pump: &pumper{grant: make(chan int), yield: make(chan struct{})}}
+	m.config.workers = 0
+
+	go func() {
+		defer close(h.done)
+		h.pump.credit = <-h.pump.grant
+		m.solveInto(h, state)
+	}()
+	return &Pump[T]{Search: h}
+}
+
+// Pump runs the search for up to budget more nodes and reports whether it is over.
+// The best move so far is available from Best between calls.
+func (p *Pump[T]) Pump(budget int) bool {
+	select {
+	case <-p.done:
+		return true
+	case p.pump.grant <- max(budget, 1):
+	}
+
+	select {
+	case <-p.done:
+		p.nodes = p.pump.nodes
+		return true
+	case <-p.pump.yield:
+		p.nodes = p.pump.nodes
+		return false
+	}
+}
+
+// Progress reports the state of the search between calls to Pump. Depth and
+// timings are not tracked, since the search only runs in slices.
+func (p *Pump[T]) Progress() Progress[T] {
+	best, score := p.Best()
+	done := false
+	select {
+	case <-p.done:
+		done = true
+	default:
+	}
+	return Progress[T]{BestMove: best, Score: score, Nodes: p.nodes, Done: done}
+}
+
+// Stop ends the search, keeping the best move found so far. Unlike
+// Search.Stop, it runs what is left of the search to its end before returning.
+func (p *Pump[T]) Stop() {
+	p.Search.Stop()
+	for !p.Pump(math.MaxInt) {
+	}
+}
+
+// step counts a node against the current grant, waiting for the next one
+// once it is used up. It does nothing if the search is not pumped.
+func (h *Search[T]) step() {
+	if h == nil || h.pump == nil {
+		return
+	}
+	p := h.pump
+	if p.credit == 0 {
+		p.yield <- struct{}{}
+		p.credit = <-p.grant
+	}
+	p.credit--
+	p.nodes++
+}
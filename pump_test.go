@@ -0,0 +1,40 @@
+package minimax
+
+import "testing"
+
+// TestPump tests that a pumped search advances only when pumped and finds the best move.
+func TestPump(t *testing.T) {
+	state := nimState{stones: 6, aiTurn: true}
+	mm := Make(&state, nimTerminal, nimUtility, nimSuccessors, true)
+
+	next := nimState{stones: 11, aiTurn: true}
+	p := mm.SolvePump(next)
+	pumps := 0
+	for !p.Pump(5) {
+		pumps++
+		if n := p.Progress().Nodes; n != 5*pumps {
+			t.Fatalf("Expected %d nodes after %d pumps, got %d", 5*pumps, pumps, n)
+		}
+	}
+	if pumps == 0 || !p.Progress().Done {
+		t.Errorf("Expected the search to take several pumps, took %d", pumps)
+	}
+	if move := p.Wait(); move == nil || move.stones != 8 {
+		t.Errorf("Expected to leave 8 stones, got %v", move)
+	}
+}
+
+// TestPumpStop tests that stopping a pumped search ends it.
+func TestPumpStop(t *testing.T) {
+	state := pathState{depth: pathDepth}
+	mm := Make(&state, pathTerminal, pathUtility, pathSuccessors, true)
+
+	p := mm.SolvePump(pathState{})
+	for range 10 {
+		p.Pump(100)
+	}
+	p.Stop()
+	if !p.Pump(1) || mm.Result().Stopped != StopCanceled || mm.Result().Nodes != 1000 {
+		t.Errorf("Expected a search canceled after 1000 nodes, got %+v", mm.Result())
+	}
+}
@@ -0,0 +1,7 @@
+//go:build race
+
+package minimax
+
+// raceEnabled is true when testing with the race detector, which randomly
+// drops items from sync.Pool and skews allocation counts
+const raceEnabled = true
@@ -0,0 +1,36 @@
+package minimax
+
+// WithRazoring prunes nodes close to the depth limit whose heuristic value is
+// hopeless: a max node with remaining plies left is not searched if its value
+// plus margins[remaining-1] is still at most alpha, and a min node if its
+// value minus that margin is still at least beta. The heuristic value then
+// stands as the bound of the node. Nodes with more plies left than margins
+// are always searched.
+//
+// Larger margins prune less but miss fewer tactics. Razoring needs a depth
+// limit and WithHeuristic, and the values it prunes count as unknown.
+func WithRazoring(margins ...int) Option {
+	return func(o *options) {
+		o.razorMargins = margins
+	}
+}
+
+// razor reports whether n can be pruned by razoring, setting its value if so
+func (s *search[T]) razor(n *node[T]) bool {
+	remaining := s.limits.MaxDepth - n.depth
+	if s.limits.MaxDepth == 0 || remaining < 1 || remaining > len(s.razorMargins) || s.exact(n) {
+		return false
+	}
+
+	margin := s.razorMargins[remaining-1]
+	eval := max(-MaxHeuristic, min(MaxHeuristic, s.heuristic(n.elem)))
+	s.observeStatic(n, eval)
+	eval = s.charge(n, s.discount(n, eval))
+	if n.isMax && eval+margin > n.alpha || !n.isMax && eval-margin < n.beta {
+		return false
+	}
+	n.val = eval
+	n.unknown = true
+	s.truncated.Store(true)
+	return true
+}
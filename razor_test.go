@@ -0,0 +1,32 @@
+package minimax
+
+import "testing"
+
+// TestRazoring tests that razoring prunes hopeless frontier nodes, and nothing with wide margins.
+func TestRazoring(t *testing.T) {
+	state := pathState{depth: pathDepth}
+	limit := WithLimits(Limits{MaxDepth: 6})
+	h := WithHeuristic(func(s *pathState) int { return 1000 * (int(s.path%7) - 3) })
+	build := func(opts ...Option) Result {
+		mm := Make(&state, pathTerminal, pathUtility, pathSuccessors, true, append([]Option{limit, h}, opts...)...)
+		mm.Solve(pathState{})
+		return mm.Result()
+	}
+
+	plain := build()
+	wide := build(WithRazoring(3*MaxHeuristic, 3*MaxHeuristic))
+	if wide.Nodes != plain.Nodes || wide.Value != plain.Value {
+		t.Errorf("Expected wide margins to change nothing, got %+v instead of %+v", wide, plain)
+	}
+	narrow := build(WithRazoring(0, 0))
+	if narrow.Nodes >= plain.Nodes || !narrow.Unknown {
+		t.Errorf("Expected fewer than %d nodes, got %+v", plain.Nodes, narrow)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected razoring without a heuristic to panic")
+		}
+	}()
+	Make(&state, pathTerminal, pathUtility, pathSuccessors, true, WithRazoring(100))
+}
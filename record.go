@@ -0,0 +1,117 @@
+package minimax
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// MoveRecord describes one move of a recorded game
+type MoveRecord[T comparable] struct {
+	Ply     int           `json:"ply"`
+	Player  string        `json:"player,omitempty"` // Name of whoever made the move
+	IsMax   bool          `json:"max"`              // Whether the move was made by the max side
+	State   T             `json:"state"`            // State after the move
+	Value   int           `json:"value"`            // Search value of the position before the move, for the AI
+	Nodes   int           `json:"nodes"`
+	Depth   int           `json:"depth"`
+	Elapsed time.Duration `json:"elapsed"`
+	Stopped string        `json:"stopped,omitempty"` // Limit that ended the search, if any
+	Engine  bool          `json:"engine"`            // False for moves made outside the engine
+}
+
+// GameRecord is the history of a game, suitable for JSON export
+type GameRecord[T comparable] struct {
+	Start    T               `json:"start"`
+	StartMax bool            `json:"start_max"` // Whether the max side moves first
+	Moves    []MoveRecord[T] `json:"moves"`
+	Finished bool            `json:"finished"`
+	Outcome  int             `json:"outcome"` // 1 if the max side won, -1 if the min side won, 0 otherwise
+}
+
+// Recorder captures the moves and evaluations of a game as it is played
+type Recorder[T comparable] struct {
+	record GameRecord[T]
+	isMax  bool
+}
+
+// NewRecorder returns a recorder for a game starting at start
+func NewRecorder[T comparable](start Position[T]) *Recorder[T] {
+	return &Recorder[T]{
+		record: GameRecord[T]{Start: start.State, StartMax: start.IsMax},
+		isMax:  start.IsMax,
+	}
+}
+
+// Solve searches state with m, records the chosen move along with the search
+// result and returns it. Nothing is recorded if the search found no move.
+func (r *Recorder[T]) Solve(m Minimax[T], state T, player string) *T {
+	move := m.Solve(state)
+	if move == nil {
+		return nil
+	}
+
+	res := m.Result()
+	mv := MoveRecord[T]{
+		Player:  player,
+		State:   *move,
+		Value:   res.Value,
+		Nodes:   res.Nodes,
+		Depth:   res.Depth,
+		Elapsed: res.Elapsed,
+		Engine:  true,
+	}
+	if res.Stopped != StopNone {
+		mv.Stopped = res.Stopped.String()
+	}
+	r.add(mv)
+	return move
+}
+
+// Move records a move made outside the engine, such as by a human player
+func (r *Recorder[T]) Move(state T, player string) {
+	r.add(MoveRecord[T]{Player: player, State: state})
+}
+
+// Finish records the outcome of the game
+func (r *Recorder[T]) Finish(outcome int) {
+	r.record.Finished = true
+	r.record.Outcome = outcome
+}
+
+// Record returns the game recorded so far
+func (r *Recorder[T]) Record() GameRecord[T] {
+	rec := r.record
+	rec.Moves = append([]MoveRecord[T](nil), r.record.Moves...)
+	return rec
+}
+
+// add appends a move played by the side to move
+func (r *Recorder[T]) add(mv MoveRecord[T]) {
+	mv.Ply = len(r.record.Moves) + 1
+	mv.IsMax = r.isMax
+	r.record.Moves = append(r.record.Moves, mv)
+	r.isMax = !r.isMax
+}
+
+// RecordGame plays a game like PlayGame and returns its record
+func RecordGame[T comparable](g Game[T], start Position[T], maxSide, minSide Entrant, maxPlies int) GameRecord[T] {
+	r := NewRecorder(start)
+	playGame(g, start, maxSide, minSide, maxPlies, r)
+	return r.Record()
+}
+
+// WriteJSON writes the record to w as indented JSON. The state type must be
+// serialisable by encoding/json.
+func (rec GameRecord[T]) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rec)
+}
+
+// ReadGameRecord decodes a record written by WriteJSON
+func ReadGameRecord[T comparable](r io.Reader) (GameRecord[T], error) {
+	var rec GameRecord[T]
+	err := json.NewDecoder(r).Decode(&rec)
+	return rec, err
+}
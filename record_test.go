@@ -0,0 +1,45 @@
+package minimax
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestRecordGame tests recording a game and its JSON round trip.
+func TestRecordGame(t *testing.T) {
+	game := Game[nimState]{IsTerminal: nimTerminal, Utility: nimUtility, Successors: nimSuccessors}
+	start := Position[nimState]{State: nimState{stones: 10, aiTurn: true}, IsMax: true}
+
+	rec := RecordGame(game, start, Entrant{Name: "a"}, Entrant{Name: "b"}, 0)
+	if !rec.Finished || rec.Outcome != 1 {
+		t.Errorf("Expected a finished win for the max side, got %+v", rec)
+	}
+	if len(rec.Moves) == 0 {
+		t.Fatal("Expected recorded moves")
+	}
+	for i, mv := range rec.Moves {
+		wantMax := i%2 == 0
+		wantPlayer := map[bool]string{true: "a", false: "b"}[wantMax]
+		if mv.Ply != i+1 || mv.IsMax != wantMax || mv.Player != wantPlayer || !mv.Engine {
+			t.Errorf("Unexpected move %d: %+v", i, mv)
+		}
+		if mv.Value <= 0 {
+			t.Errorf("Expected move %d to be evaluated as a win, got %d", i, mv.Value)
+		}
+	}
+	if last := rec.Moves[len(rec.Moves)-1].State; !nimTerminal(&last) {
+		t.Errorf("Expected the last move to end the game, got %+v", last)
+	}
+
+	var buf bytes.Buffer
+	if err := rec.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadGameRecord[nimState](&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Moves) != len(rec.Moves) || got.Outcome != rec.Outcome || got.Moves[0].Value != rec.Moves[0].Value {
+		t.Errorf("Round trip mismatch: %+v", got)
+	}
+}
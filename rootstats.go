@@ -0,0 +1,82 @@
+package minimax
+
+import "sync/atomic"
+
+// WithRootStats reports, in Result.RootMoves, how the search spent its effort
+// among the moves from the searched state: how many nodes each move's subtree
+// took and whether it was refuted early. Good move ordering shows up as most
+// nodes going to the best move, searched first, and the others refuted cheaply.
+func WithRootStats() Option {
+	return func(o *options) {
+		o.rootStats = true
+	}
+}
+
+// RootMove tells how the search treated a move from the searched state
+type RootMove struct {
+	Order   int  // Position in which the move was searched after move ordering, 0 for the first
+	Nodes   int  // Nodes visited below the move, each counted toward the first move that reached it
+	Reached bool // Whether the move was searched at all, rather than skipped after a cutoff or a limit
+	Refuted bool // Whether its search stopped once the move was shown no better than an earlier one, so Value is a bound
+	Best    bool // Whether the move was chosen as the best
+	Value   int  // Value of the move for the AI, or a bound of it if Refuted
+}
+
+// countBranch counts a visit of n toward the root move it was first reached from
+func (s *search[T]) countBranch(n *node[T]) {
+	if n.branch >= 0 && int(n.branch) < len(s.branchNodes) {
+		s.branchNodes[n.branch].Add(1)
+	}
+}
+
+// branch marks the children of n with the root move leading to them, starting
+// the count of nodes per root move when n is the root
+func (s *search[T]) branch(n *node[T], successors int) {
+	if n.from == nil {
+		s.branchNodes = make([]atomic.Int64, successors)
+		s.rootFinished = make([]RootMove, successors)
+	}
+	for i, child := range n.children {
+		if child.from != n {
+			continue // Reached through another node first
+		}
+		child.branch = n.branch
+		if n.from == nil {
+			child.branch = int32(i)
+		}
+	}
+}
+
+// finished records the value of n when the search leaves it, if it is a move
+// from the root, so that later searches of n do not show in its statistics
+func (s *search[T]) finished(n *node[T]) {
+	root := n.from
+	if root == nil || root.from != nil || n.branch < 0 || int(n.branch) >= len(s.rootFinished) {
+		return
+	}
+	m := &s.rootFinished[n.branch]
+	m.Reached = n.searched
+	m.Refuted = n.searched && (root.isMax && n.val <= n.lo || !root.isMax && n.val >= n.hi)
+	m.Value = n.val
+}
+
+// rootMoves returns the statistics of the moves from root, in successor
+// order, as the search left each of them
+func (s *search[T]) rootMoves(root *node[T]) []RootMove {
+	if !root.expanded || root.narrowed {
+		return nil // Beam search drops moves without counting them
+	}
+	moves := make([]RootMove, len(s.branchNodes))
+	for order, child := range root.children {
+		if child.from != root {
+			continue
+		}
+		m := &moves[child.branch]
+		*m = s.rootFinished[child.branch]
+		m.Nodes = int(s.branchNodes[child.branch].Load())
+		m.Reached = m.Reached || m.Nodes > 0
+		m.Order = order
+		m.Best = child == root.bestMove
+	}
+	return moves
+}
@@ -0,0 +1,65 @@
+package minimax
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestRootStats tests that every node is counted toward a root move and that
+// the best move is told from the refuted ones.
+func TestRootStats(t *testing.T) {
+	h := WithHeuristic(func(s *pathState) int { return int(s.path*2654435761%1000) - 500 })
+	limits := WithLimits(Limits{MaxDepth: 6})
+	nim := nimState{stones: 12, aiTurn: true}
+	path := pathState{}
+	for name, res := range map[string]Result{
+		"path":     Make(&path, pathTerminal, pathUtility, pathSuccessors, true, h, limits, WithRootStats()).Result(),
+		"parallel": Make(&path, pathTerminal, pathUtility, pathSuccessors, false, h, limits, WithRootStats(), WithParallel(4)).Result(),
+		"nim":      Make(&nim, nimTerminal, nimUtility, nimSuccessors, true, WithRootStats()).Result(),
+	} {
+		if len(res.RootMoves) != 3 {
+			t.Fatalf("%s: expected 3 root moves, got %+v", name, res.RootMoves)
+		}
+		nodes, best := 1, 0 // The root itself
+		orders := make(map[int]bool)
+		for _, m := range res.RootMoves {
+			nodes += m.Nodes
+			orders[m.Order] = true
+			if !m.Reached || m.Nodes == 0 {
+				t.Errorf("%s: expected every move to be searched, got %+v", name, m)
+			}
+			if m.Best {
+				best++
+				if m.Refuted || m.Value != res.Value {
+					t.Errorf("%s: expected the best move to hold the root value %d, got %+v", name, res.Value, m)
+				}
+			}
+		}
+		if nodes != res.Nodes || best != 1 || len(orders) != 3 {
+			t.Errorf("%s: expected %d nodes, a best move and 3 orders, got %+v", name, res.Nodes, res.RootMoves)
+		}
+	}
+
+	// From 12 stones every move loses, so the moves after the first one
+	// searched are refuted by its bound or no better
+	res := Make(&nim, nimTerminal, nimUtility, nimSuccessors, true, WithRootStats()).Result()
+	for _, m := range res.RootMoves {
+		if m.Order > 0 && !m.Refuted && m.Value > res.Value {
+			t.Errorf("Expected later moves to be refuted or no better, got %+v", m)
+		}
+	}
+
+	stopped := Make(&path, pathTerminal, pathUtility, pathSuccessors, true, WithLimits(Limits{MaxNodes: 50}), WithRootStats()).Result()
+	if m := stopped.RootMoves; len(m) != 3 || m[2].Reached {
+		t.Errorf("Expected the node limit to stop the search before the last move, got %+v", m)
+	}
+	// Searches after the main one, such as those of WithOnlyMove, do not count
+	plain := Make(&path, pathTerminal, pathUtility, pathSuccessors, true, h, limits, WithRootStats()).Result()
+	checked := Make(&path, pathTerminal, pathUtility, pathSuccessors, true, h, limits, WithRootStats(), WithOnlyMove(1)).Result()
+	if !slices.Equal(checked.RootMoves, plain.RootMoves) {
+		t.Errorf("Expected the statistics of the main search %+v, got %+v", plain.RootMoves, checked.RootMoves)
+	}
+	if res := Make(&path, pathTerminal, pathUtility, pathSuccessors, true, limits).Result(); res.RootMoves != nil {
+		t.Errorf("Expected no statistics without WithRootStats, got %+v", res.RootMoves)
+	}
+}
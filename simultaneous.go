@@ -0,0 +1,249 @@
+package minimax
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Resolution tells how Simultaneous resolves the payoff matrix of a node
+type Resolution int
+
+const (
+	ResolvePure  Resolution = iota // Maxmin of pure strategies: the AI assumes the opponent answers its move
+	ResolveMixed                   // Value of the best mixed strategies, as in a matrix game
+)
+
+// Simultaneous searches games where both players move at once, such as
+// rock-paper-scissors. At every node it builds the payoff matrix of all joint
+// moves, with the AI's moves as rows and the opponent's as columns, and
+// resolves it into a single value before backing up. Positions where only one
+// player moves are expressed by giving the other a single move, such as a
+// pass, which reduces the matrix to a plain max or min.
+//
+// Values are on the scale of Result.Value: wins count down from the same
+// score as in Make, and heuristic values are clamped to ±MaxHeuristic.
+type Simultaneous[T, M comparable] struct {
+	IsTerminal func(*T) bool                 // Returns true if the state is terminal
+	Utility    func(*T) int                  // Returns -1, 0 or 1 for a loss, draw or win for the AI
+	Moves      func(*T) (ai, opponent []M)   // Moves of each player, both non-empty in non-terminal states
+	Play       func(s *T, ai, opponent M) *T // Returns the state after both moves
+	Heuristic  func(*T) int                  // Values states at MaxDepth; 0 if nil
+	Resolution Resolution                    // How payoff matrices are resolved
+	MaxDepth   int                           // Joint moves searched before Heuristic is used; 0 for no limit
+}
+
+// MixedMove is a move together with the probability of playing it
+type MixedMove[M comparable] struct {
+	Move M
+	Prob float64
+}
+
+// Strategy is a probability distribution over moves. Moves never played are left out.
+type Strategy[M comparable] []MixedMove[M]
+
+// Pick draws a move from the strategy
+func (st Strategy[M]) Pick(r *rand.Rand) M {
+	x := r.Float64()
+	for _, mm := range st {
+		if x -= mm.Prob; x < 0 {
+			return mm.Move
+		}
+	}
+	return st[len(st)-1].Move
+}
+
+// Solve returns the AI's strategy in state and the result of the search. With
+// ResolvePure the strategy is a single move. The strategy is empty if the
+// state is terminal.
+func (g Simultaneous[T, M]) Solve(state T) (Strategy[M], Result) {
+	start := time.Now()
+	res := Result{}
+	memo := make(map[nodeKey[T]]float64)
+
+	var strategy Strategy[M]
+	if g.IsTerminal(&state) {
+		res.Value = int(g.value(&state, 0, memo, &res))
+	} else {
+		ai, payoff := g.matrix(&state, 0, memo, &res)
+		res.Nodes++
+		switch g.Resolution {
+		case ResolveMixed:
+			v, x, _ := SolveMatrix(payoff)
+			res.Value = int(math.Round(v))
+			for i, p := range x {
+				if p > simplexEpsilon {
+					strategy = append(strategy, MixedMove[M]{ai[i], p})
+				}
+			}
+		default:
+			v, row := MaxMin(payoff)
+			res.Value = int(math.Round(v))
+			strategy = Strategy[M]{{ai[row], 1}}
+		}
+	}
+	res.Elapsed = time.Since(start)
+	return strategy, res
+}
+
+// value returns the value of s, reached after depth joint moves
+func (g Simultaneous[T, M]) value(s *T, depth int, memo map[nodeKey[T]]float64, res *Result) float64 {
+	key := nodeKey[T]{state: *s, depth: depth}
+	if v, ok := memo[key]; ok {
+		return v
+	}
+	res.Nodes++
+	res.Depth = max(res.Depth, depth)
+
+	var v float64
+	switch {
+	case g.IsTerminal(s):
+		switch u := g.Utility(s); {
+		case u > 0:
+			v = float64(score - depth)
+		case u < 0:
+			v = float64(depth - score)
+		}
+	case g.MaxDepth > 0 && depth >= g.MaxDepth:
+		res.Unknown = true
+		res.Stopped = StopDepth
+		if g.Heuristic != nil {
+			v = float64(max(-MaxHeuristic, min(MaxHeuristic, g.Heuristic(s))))
+		}
+	default:
+		_, payoff := g.matrix(s, depth, memo, res)
+		if g.Resolution == ResolveMixed {
+			v, _, _ = SolveMatrix(payoff)
+		} else {
+			v, _ = MaxMin(payoff)
+		}
+	}
+	memo[key] = v
+	return v
+}
+
+// matrix returns the AI's moves in s and the payoff matrix of the joint moves
+func (g Simultaneous[T, M]) matrix(s *T, depth int, memo map[nodeKey[T]]float64, res *Result) ([]M, [][]float64) {
+	ai, opp := g.Moves(s)
+	payoff := make([][]float64, len(ai))
+	for i, a := range ai {
+		payoff[i] = make([]float64, len(opp))
+		for j, o := range opp {
+			payoff[i][j] = g.value(g.Play(s, a, o), depth+1, memo, res)
+		}
+	}
+	return ai, payoff
+}
+
+// MaxMin returns the best value the row player can guarantee with a pure
+// strategy in the zero-sum matrix game payoff, and the row achieving it
+func MaxMin(payoff [][]float64) (float64, int) {
+	best, bestRow := math.Inf(-1), 0
+	for i, row := range payoff {
+		worst := math.Inf(1)
+		for _, v := range row {
+			worst = min(worst, v)
+		}
+		if worst > best {
+			best, bestRow = worst, i
+		}
+	}
+	return best, bestRow
+}
+
+// simplexEpsilon is the tolerance of SolveMatrix
+const simplexEpsilon = 1e-9
+
+// SolveMatrix solves the zero-sum matrix game payoff, where the row player
+// maximizes, with the simplex method. It returns the value of the game and
+// optimal mixed strategies for the row and column players.
+func SolveMatrix(payoff [][]float64) (float64, []float64, []float64) {
+	m, n := len(payoff), len(payoff[0])
+
+	// Make every payoff at least 1, so that the value is positive
+	lowest := math.Inf(1)
+	for _, row := range payoff {
+		for _, v := range row {
+			lowest = min(lowest, v)
+		}
+	}
+	shift := 1 - lowest
+
+	// Column player's program: maximize Σq subject to (A+shift)q ≤ 1, q ≥ 0,
+	// with a slack variable per row. The row player's strategy is its dual.
+	width := n + m + 1
+	t := make([][]float64, m+1)
+	basis := make([]int, m)
+	for i, row := range payoff {
+		t[i] = make([]float64, width)
+		for j, v := range row {
+			t[i][j] = v + shift
+		}
+		t[i][n+i] = 1
+		t[i][width-1] = 1
+		basis[i] = n + i
+	}
+	obj := make([]float64, width)
+	for j := range n {
+		obj[j] = -1
+	}
+	t[m] = obj
+
+	for {
+		// Bland's rule: the first improving column and the leaving row with
+		// the smallest basic variable among ties, which never cycles
+		col := -1
+		for j := range width - 1 {
+			if obj[j] < -simplexEpsilon {
+				col = j
+				break
+			}
+		}
+		if col < 0 {
+			break
+		}
+		row := -1
+		var ratio float64
+		for i := range m {
+			if t[i][col] <= simplexEpsilon {
+				continue
+			}
+			r := t[i][width-1] / t[i][col]
+			if row < 0 || r < ratio-simplexEpsilon || r <= ratio+simplexEpsilon && basis[i] < basis[row] {
+				row, ratio = i, r
+			}
+		}
+		pivot(t, row, col)
+		basis[row] = col
+	}
+
+	v := 1 / obj[width-1]
+	x := make([]float64, m)
+	for i := range m {
+		x[i] = obj[n+i] * v
+	}
+	y := make([]float64, n)
+	for i, b := range basis {
+		if b < n {
+			y[b] = t[i][width-1] * v
+		}
+	}
+	return v - shift, x, y
+}
+
+// pivot makes column col of the tableau t a unit vector with its 1 in row
+func pivot(t [][]float64, row, col int) {
+	p := t[row][col]
+	for j := range t[row] {
+		t[row][j] /= p
+	}
+	for i := range t {
+		if i == row || t[i][col] == 0 {
+			continue
+		}
+		f := t[i][col]
+		for j := range t[i] {
+			t[i][j] -= f * t[row][j]
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package minimax
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// rpsState is a game of rock-paper-scissors, played for a number of rounds
+// and won by whoever wins the last round that is not a draw
+type rpsState struct {
+	rounds int // Rounds left
+	last   int // Result of the last decided round: 1 if the AI won it, -1 if it lost, 0 if none
+}
+
+func rpsGame(resolution Resolution) Simultaneous[rpsState, int] {
+	return Simultaneous[rpsState, int]{
+		IsTerminal: func(s *rpsState) bool { return s.rounds == 0 },
+		Utility:    func(s *rpsState) int { return s.last },
+		Moves: func(s *rpsState) ([]int, []int) {
+			return []int{0, 1, 2}, []int{0, 1, 2}
+		},
+		Play: func(s *rpsState, ai, opp int) *rpsState {
+			next := rpsState{rounds: s.rounds - 1, last: s.last}
+			switch (ai - opp + 3) % 3 {
+			case 1:
+				next.last = 1
+			case 2:
+				next.last = -1
+			}
+			return &next
+		},
+		Resolution: resolution,
+	}
+}
+
+// TestSimultaneousMixed tests that rock-paper-scissors is solved with the uniform strategy
+func TestSimultaneousMixed(t *testing.T) {
+	strategy, res := rpsGame(ResolveMixed).Solve(rpsState{rounds: 2})
+	if len(strategy) != 3 {
+		t.Fatalf("Expected every move to be played, got %v", strategy)
+	}
+	for _, mm := range strategy {
+		if math.Abs(mm.Prob-1.0/3) > 1e-6 {
+			t.Errorf("Expected probability 1/3 for move %d, got %f", mm.Move, mm.Prob)
+		}
+	}
+	if res.Value != 0 || res.Unknown || res.Depth != 2 {
+		t.Errorf("Unexpected result %+v", res)
+	}
+	if m := strategy.Pick(rand.New(rand.NewSource(1))); m < 0 || m > 2 {
+		t.Errorf("Picked an unknown move %d", m)
+	}
+}
+
+// TestSimultaneousPure tests that pure strategies lose rock-paper-scissors
+func TestSimultaneousPure(t *testing.T) {
+	strategy, res := rpsGame(ResolvePure).Solve(rpsState{rounds: 1})
+	if len(strategy) != 1 || strategy[0].Prob != 1 {
+		t.Errorf("Expected a single move, got %v", strategy)
+	}
+	if res.Value != 1-score {
+		t.Errorf("Expected a loss, got %d", res.Value)
+	}
+}
+
+// TestSimultaneousDepth tests that the heuristic is used at MaxDepth
+func TestSimultaneousDepth(t *testing.T) {
+	g := rpsGame(ResolveMixed)
+	g.MaxDepth = 1
+	g.Heuristic = func(s *rpsState) int { return 100 * s.last }
+	_, res := g.Solve(rpsState{rounds: 3})
+	if res.Value != 0 || !res.Unknown || res.Stopped != StopDepth || res.Depth != 1 {
+		t.Errorf("Unexpected result %+v", res)
+	}
+}
+
+// TestSolveMatrix tests the simplex solver on a game without a saddle point
+func TestSolveMatrix(t *testing.T) {
+	v, x, y := SolveMatrix([][]float64{{2, -1}, {-1, 1}})
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+	if !near(v, 0.2) || !near(x[0], 0.4) || !near(x[1], 0.6) || !near(y[0], 0.4) || !near(y[1], 0.6) {
+		t.Errorf("Expected value 0.2 with strategies (0.4, 0.6), got %f %v %v", v, x, y)
+	}
+
+	// A saddle point makes pure and mixed values equal
+	payoff := [][]float64{{3, 1, 4}, {2, 0, -1}}
+	v, x, _ = SolveMatrix(payoff)
+	pure, row := MaxMin(payoff)
+	if !near(v, 1) || pure != 1 || row != 0 || !near(x[0], 1) {
+		t.Errorf("Expected the saddle point 1 in row 0, got %f %v, %f %d", v, x, pure, row)
+	}
+}
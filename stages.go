@@ -0,0 +1,51 @@
+package minimax
+
+// Stage is a step of a staged evaluation, see WithStages
+type Stage[T comparable] struct {
+	Eval   func(*T) int // Adds to the value of the previous stages
+	Margin int          // Most the stages after this one can add or remove, in total
+}
+
+// WithStages scores states cut off by the depth limit with the sum of the
+// stages, run in order, typically from the cheapest (material) to the most
+// expensive (positional). After each stage, if the value so far is outside the
+// node's window by more than the stage's margin, the later stages cannot bring
+// it back, so they are skipped and the bound is returned instead. When
+// evaluation dominates the search, most nodes then only pay for the first stage.
+//
+// Margins must really bound the later stages, or the search may return wrong
+// values. WithStages implies WithCutoff(CutoffHeuristic), and the full sum is
+// used wherever a heuristic is needed without a window, as by WithRazoring.
+// It replaces any earlier WithHeuristic or WithEvaluator.
+func WithStages[T comparable](stages ...Stage[T]) Option {
+	return func(o *options) {
+		o.stages, o.noisy = stages, nil
+		o.heuristic = func(s *T) int {
+			v := 0
+			for _, st := range stages {
+				v += st.Eval(s)
+			}
+			return v
+		}
+		o.cutoff = CutoffHeuristic
+	}
+}
+
+// staged evaluates n stage by stage, stopping as soon as its value is known
+// to fall outside its window
+func (s *search[T]) staged(n *node[T]) int {
+	v := 0
+	for i, st := range s.stages {
+		v += st.Eval(n.elem)
+		if i == len(s.stages)-1 {
+			break
+		}
+		switch {
+		case v+st.Margin <= n.alpha:
+			return v + st.Margin // Upper bound, fails low
+		case v-st.Margin >= n.beta:
+			return v - st.Margin // Lower bound, fails high
+		}
+	}
+	return v
+}
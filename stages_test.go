@@ -0,0 +1,51 @@
+package minimax
+
+import "testing"
+
+// TestStages tests that later stages are skipped when earlier ones decide, without changing the result.
+func TestStages(t *testing.T) {
+	state := pathState{depth: pathDepth}
+	limit := WithLimits(Limits{MaxDepth: 6})
+	material := func(s *pathState) int { return 1000 * (int(s.path%5) - 2) }
+	calls := 0
+	positional := func(s *pathState) int {
+		calls++
+		return 10 * (int(s.path/5%7) - 3)
+	}
+
+	full := Make(&state, pathTerminal, pathUtility, pathSuccessors, true, limit,
+		WithHeuristic(func(s *pathState) int { return material(s) + positional(s) }))
+	full.Solve(pathState{})
+	fullCalls := calls
+
+	calls = 0
+	staged := Make(&state, pathTerminal, pathUtility, pathSuccessors, true, limit,
+		WithStages(Stage[pathState]{Eval: material, Margin: 30}, Stage[pathState]{Eval: positional}))
+	staged.Solve(pathState{})
+
+	if staged.Result().Value != full.Result().Value {
+		t.Errorf("Expected value %d, got %d", full.Result().Value, staged.Result().Value)
+	}
+	if calls >= fullCalls {
+		t.Errorf("Expected fewer than %d positional evaluations, got %d", fullCalls, calls)
+	}
+}
+
+// TestStagesReplaced tests that WithStages, WithHeuristic and WithEvaluator replace each other, whichever comes last.
+func TestStagesReplaced(t *testing.T) {
+	state := pathState{}
+	limit := WithLimits(Limits{MaxDepth: 2})
+	staged := WithStages(Stage[pathState]{Eval: func(*pathState) int { return 7 }})
+	heuristic := WithHeuristic(func(*pathState) int { return 3 })
+
+	if v := Make(&state, pathTerminal, pathUtility, pathSuccessors, true, limit, staged, heuristic).Result().Value; v != 3 {
+		t.Errorf("Expected WithHeuristic to replace the stages, got %d", v)
+	}
+	if v := Make(&state, pathTerminal, pathUtility, pathSuccessors, true, limit, heuristic, staged).Result().Value; v != 7 {
+		t.Errorf("Expected WithStages to replace the heuristic, got %d", v)
+	}
+	evaluator := WithEvaluator[pathState](evalFunc[pathState](func(*pathState) int { return 5 }))
+	if v := Make(&state, pathTerminal, pathUtility, pathSuccessors, true, limit, staged, evaluator).Result().Value; v != 5 {
+		t.Errorf("Expected WithEvaluator to replace the stages, got %d", v)
+	}
+}
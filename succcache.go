@@ -0,0 +1,115 @@
+package minimax
+
+import (
+	"container/list"
+	"slices"
+	"sync"
+)
+
+// SuccessorCache remembers the successors generated for recent states, for
+// games where generating moves is expensive, such as those whose legality
+// checks dominate the search. A state reached again, whether through a
+// transposition at another depth, a later iteration of SolveClock or another
+// search, then gets its successors without calling the successors function.
+//
+// The cache holds at most a fixed number of states and forgets the least
+// recently used first. It can be shared by several searches, including
+// parallel ones, as long as they play the same game.
+type SuccessorCache[T comparable] struct {
+	mu       sync.Mutex
+	capacity int
+	lru      *list.List // Entries, most recently used first
+	entries  map[T]*list.Element
+	hits     int
+	misses   int
+}
+
+// successorEntry is an element of SuccessorCache.lru
+type successorEntry[T comparable] struct {
+	state T
+	succ  []*T
+}
+
+// NewSuccessorCache returns an empty cache holding up to capacity states, at least 1
+func NewSuccessorCache[T comparable](capacity int) *SuccessorCache[T] {
+	return &SuccessorCache[T]{
+		capacity: max(capacity, 1),
+		lru:      list.New(),
+		entries:  make(map[T]*list.Element),
+	}
+}
+
+// WithSuccessorCache looks successors up in c before generating them, and
+// stores those it generates. The cached states are handed to every search
+// that needs them, so states must not be modified once generated.
+func WithSuccessorCache[T comparable](c *SuccessorCache[T]) Option {
+	return func(o *options) {
+		o.successorCache = c
+	}
+}
+
+// Len returns the number of states cached
+func (c *SuccessorCache[T]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Stats returns how many lookups found their state in the cache and how many did not
+func (c *SuccessorCache[T]) Stats() (hits, misses int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
+
+// Clear forgets every cached state and resets the statistics
+func (c *SuccessorCache[T]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Init()
+	clear(c.entries)
+	c.hits, c.misses = 0, 0
+}
+
+// get returns the successors cached for state
+func (c *SuccessorCache[T]) get(state T) ([]*T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[state]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.lru.MoveToFront(e)
+	return e.Value.(*successorEntry[T]).succ, true
+}
+
+// put caches the successors of state, evicting the least recently used state if full
+func (c *SuccessorCache[T]) put(state T, succ []*T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[state]; ok {
+		c.lru.MoveToFront(e)
+		return // Generated concurrently by another worker
+	}
+	if c.lru.Len() >= c.capacity {
+		oldest := c.lru.Back()
+		delete(c.entries, oldest.Value.(*successorEntry[T]).state)
+		c.lru.Remove(oldest)
+	}
+	c.entries[state] = c.lru.PushFront(&successorEntry[T]{state, succ})
+}
+
+// cachedSuccessors returns the successors of state from the cache, generating
+// and caching them if needed
+func (cf *config[T]) cachedSuccessors(state *T) []*T {
+	if succ, ok := cf.successorCache.get(*state); ok {
+		return succ
+	}
+	generated, release := cf.generateFresh(state)
+	succ := slices.Clone(generated)
+	release()
+	cf.successorCache.put(*cf.copyOf(state), succ)
+	return succ
+}
@@ -0,0 +1,42 @@
+package minimax
+
+import "testing"
+
+// TestSuccessorCache tests that cached successors are not generated again, within and across searches.
+func TestSuccessorCache(t *testing.T) {
+	calls := 0
+	successors := func(s *nimState) []*nimState {
+		calls++
+		return nimSuccessors(s)
+	}
+	state := nimState{stones: 12, aiTurn: true}
+
+	Make(&state, nimTerminal, nimUtility, successors, true)
+	plain := calls
+
+	calls = 0
+	cache := NewSuccessorCache[nimState](100)
+	mm := Make(&state, nimTerminal, nimUtility, successors, true, WithSuccessorCache(cache))
+	if calls >= plain || calls != cache.Len() {
+		t.Errorf("Expected fewer than %d calls, one per cached state, got %d for %d states", plain, calls, cache.Len())
+	}
+	if hits, _ := cache.Stats(); hits == 0 {
+		t.Error("Expected transpositions to hit the cache")
+	}
+
+	calls = 0
+	again := Make(&state, nimTerminal, nimUtility, successors, true, WithSuccessorCache(cache))
+	if calls != 0 || *again.Solve(state) != *mm.Solve(state) {
+		t.Errorf("Expected a second search to reuse every successor, got %d calls", calls)
+	}
+
+	small := NewSuccessorCache[nimState](3)
+	Make(&state, nimTerminal, nimUtility, successors, true, WithSuccessorCache(small))
+	if small.Len() != 3 {
+		t.Errorf("Expected the cache to be bounded to 3 states, got %d", small.Len())
+	}
+	small.Clear()
+	if hits, misses := small.Stats(); small.Len() != 0 || hits != 0 || misses != 0 {
+		t.Error("Expected Clear to empty the cache")
+	}
+}
@@ -0,0 +1,134 @@
+package minimax
+
+import (
+	"maps"
+	"time"
+)
+
+// Clock is the state of a chess-style clock for the side to move
+type Clock struct {
+	Remaining time.Duration // Time left on the clock
+	Increment time.Duration // Time added after each move
+	MovesToGo int           // Moves until the next time control, 0 if the rest of the game must fit in Remaining
+}
+
+// TimeManager decides how long to think about a move. The zero value uses
+// sensible defaults.
+type TimeManager struct {
+	MovesToGo    int           // Moves the remaining time is assumed to cover when the clock does not say; 30 if zero
+	Reserve      time.Duration // Time never allocated, kept against emergencies; 5% of the remaining time if zero
+	MaxExtension float64       // How far an unstable search may run past its allotment, as a factor; 3 if zero
+	Instability  int           // Score change between iterations that calls for more time; MaxHeuristic/100 if zero
+}
+
+// Allocate returns the time to aim for on a move (soft) and the time that must
+// not be exceeded (hard). The hard limit never eats into the reserve.
+func (tm TimeManager) Allocate(c Clock) (soft, hard time.Duration) {
+	movesToGo := c.MovesToGo
+	if movesToGo <= 0 {
+		movesToGo = tm.MovesToGo
+	}
+	if movesToGo <= 0 {
+		movesToGo = 30
+	}
+	reserve := tm.Reserve
+	if reserve <= 0 {
+		reserve = c.Remaining / 20
+	}
+	extension := tm.MaxExtension
+	if extension < 1 {
+		extension = 3
+	}
+
+	usable := max(c.Remaining-reserve, 0)
+	soft = min(usable/time.Duration(movesToGo)+c.Increment*3/4, usable)
+	hard = min(time.Duration(float64(soft)*extension), usable)
+	return soft, hard
+}
+
+// instability returns the score change considered unstable
+func (tm TimeManager) instability() int {
+	if tm.Instability > 0 {
+		return tm.Instability
+	}
+	return MaxHeuristic / 100
+}
+
+// SolveClock finds a move for state within the time allotted by tm for the
+// given clock, using iterative deepening: it searches one ply deeper at a time
+// and plays the best move of the deepest completed iteration. Each iteration
+// searches the best moves of the previous one first. No iteration is
+// started once half the soft time has passed, as it would likely not finish.
+// When the best move or the score changes between iterations, the soft time is
+// doubled, up to the hard limit. The limits passed to Make still apply, with
+// MaxDepth capping the iterations.
+//
+// Result reports the last iteration, with the nodes and time of all of them.
+func (m Minimax[T]) SolveClock(state T, clock Clock, tm TimeManager) *T {
+	if m.config.isTerminal(&state) {
+		return nil
+	}
+
+	start := time.Now()
+	soft, hard := tm.Allocate(clock)
+	var best *T
+	var last Result
+	var nodes int
+	var cache map[T]*T
+
+	for depth := 1; m.config.limits.MaxDepth == 0 || depth <= m.config.limits.MaxDepth; depth++ {
+		left := hard - time.Since(start)
+		if left <= 0 {
+			break
+		}
+
+		cf := m.config
+		cf.limits.MaxDepth = depth
+		cf.autoProbes = 0
+		cf.hints = cache
+		if cf.limits.MaxTime == 0 || cf.limits.MaxTime > left {
+			cf.limits.MaxTime = left
+		}
+		it := build(&state, cf, nil)
+		res := it.Result()
+		nodes += res.Nodes
+		move := it.moveMap[state]
+
+		if res.Stopped != StopNone && res.Stopped != StopDepth {
+			// Aborted, its partial result only helps if nothing else was found
+			if best == nil && move != nil {
+				best, last, cache = move, res, it.moveMap
+			}
+			break
+		}
+
+		if move == nil {
+			break
+		}
+		if best != nil && (*move != *best || abs(res.Value-last.Value) >= tm.instability()) {
+			soft = min(2*soft, hard)
+		}
+		best, last, cache = move, res, it.moveMap
+		if res.Stopped == StopNone || time.Since(start) >= soft/2 {
+			break
+		}
+	}
+
+	if cache != nil {
+		maps.Copy(m.moveMap, cache)
+	}
+	last.Nodes = nodes
+	last.Elapsed = time.Since(start)
+	*m.result = last
+	if best != nil {
+		m.cache.remember(state, last.Value)
+	}
+	return best
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
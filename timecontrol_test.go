@@ -0,0 +1,61 @@
+package minimax
+
+import (
+	"testing"
+	"time"
+)
+
+// TestAllocate tests the time allotted for a move.
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		name       string
+		tm         TimeManager
+		clock      Clock
+		soft, hard time.Duration
+	}{
+		{"sudden death", TimeManager{}, Clock{Remaining: 10 * time.Minute}, 19 * time.Second, 57 * time.Second},
+		{"increment", TimeManager{Reserve: time.Second}, Clock{Remaining: 31 * time.Second, Increment: 4 * time.Second},
+			4 * time.Second, 12 * time.Second},
+		{"moves to go", TimeManager{Reserve: time.Second, MaxExtension: 2}, Clock{Remaining: 11 * time.Second, MovesToGo: 5},
+			2 * time.Second, 4 * time.Second},
+		{"short of time", TimeManager{Reserve: time.Second}, Clock{Remaining: 3 * time.Second, Increment: 4 * time.Second},
+			2 * time.Second, 2 * time.Second},
+		{"flagged", TimeManager{}, Clock{}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		soft, hard := tt.tm.Allocate(tt.clock)
+		if soft != tt.soft || hard != tt.hard {
+			t.Errorf("%s: expected %v/%v, got %v/%v", tt.name, tt.soft, tt.hard, soft, hard)
+		}
+	}
+}
+
+// TestSolveClock tests iterative deepening under a clock.
+func TestSolveClock(t *testing.T) {
+	// A small game is solved outright
+	nim := nimState{stones: 7, aiTurn: true}
+	mm := Make(&nim, nimTerminal, nimUtility, nimSuccessors, true)
+	move := mm.SolveClock(nim, Clock{Remaining: time.Minute}, TimeManager{})
+	if move == nil || *move != *mm.Solve(nim) || mm.Result().Stopped != StopNone {
+		t.Errorf("Expected the solved move, got %v (%+v)", move, mm.Result())
+	}
+
+	// A large one is searched as deep as the clock allows
+	state := pathState{depth: pathDepth}
+	pm := Make(&state, pathTerminal, pathUtility, pathSuccessors, true)
+	start := time.Now()
+	pmove := pm.SolveClock(pathState{}, Clock{Remaining: 2 * time.Second}, TimeManager{})
+	elapsed := time.Since(start)
+
+	if pmove == nil {
+		t.Fatal("Expected a move")
+	}
+	_, hard := TimeManager{}.Allocate(Clock{Remaining: 2 * time.Second})
+	if elapsed > hard+50*time.Millisecond {
+		t.Errorf("Expected the search to end within %v, took %v", hard, elapsed)
+	}
+	if res := pm.Result(); res.Depth < 2 || res.Stopped != StopDepth {
+		t.Errorf("Expected several completed iterations, got %+v", res)
+	}
+}
@@ -0,0 +1,141 @@
+package minimax
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"text/tabwriter"
+)
+
+// Tournament runs round-robin tournaments between engine configurations
+type Tournament[T comparable] struct {
+	Game         Game[T]     // Game being played
+	Start        Position[T] // Position every game starts from
+	Entrants     []Entrant   // Engine configurations taking part
+	GamesPerPair int         // Games per pairing, half with each side; 2 if not positive
+	OpeningPlies int         // Random moves played from Start before the engines take over
+	MaxPlies     int         // Games reaching this many moves are drawn, if positive
+	Rand         *rand.Rand  // Source of random openings, seeded with 1 if nil
+}
+
+// CrossTable holds the results of a tournament. Row i, column j describes the
+// games of entrant i against entrant j.
+type CrossTable struct {
+	Names  []string    // Entrant names
+	Points [][]float64 // Points scored by row against column: 1 per win, 0.5 per draw
+	Games  [][]int     // Games played by row against column
+}
+
+// Total returns the points scored by entrant i against everyone
+func (c CrossTable) Total(i int) float64 {
+	total := 0.0
+	for _, p := range c.Points[i] {
+		total += p
+	}
+	return total
+}
+
+// WriteTo writes the cross-table to w, with each entrant's total and its
+// share of the points available to it
+func (c CrossTable) WriteTo(w io.Writer) (int64, error) {
+	var sb strings.Builder
+	tw := tabwriter.NewWriter(&sb, 0, 4, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprint(tw, "\t")
+	for j := range c.Names {
+		fmt.Fprintf(tw, "%d\t", j+1)
+	}
+	fmt.Fprintln(tw, "total\tscore\t")
+
+	for i, name := range c.Names {
+		fmt.Fprintf(tw, "%d %s\t", i+1, name)
+		games := 0
+		for j := range c.Names {
+			if i == j {
+				fmt.Fprint(tw, "-\t")
+				continue
+			}
+			fmt.Fprintf(tw, "%g/%d\t", c.Points[i][j], c.Games[i][j])
+			games += c.Games[i][j]
+		}
+		share := 0.0
+		if games > 0 {
+			share = 100 * c.Total(i) / float64(games)
+		}
+		fmt.Fprintf(tw, "%g\t%.1f%%\t\n", c.Total(i), share)
+	}
+	if err := tw.Flush(); err != nil {
+		return 0, err
+	}
+
+	n, err := io.WriteString(w, sb.String())
+	return int64(n), err
+}
+
+// RoundRobin plays every entrant against every other one and returns the
+// cross-table. Each pairing plays GamesPerPair games in pairs sharing the same
+// random opening, once with each entrant on the max side, so that neither the
+// opening nor the side to move favours anyone.
+func (t Tournament[T]) RoundRobin() CrossTable {
+	n := len(t.Entrants)
+	table := CrossTable{
+		Names:  make([]string, n),
+		Points: make([][]float64, n),
+		Games:  make([][]int, n),
+	}
+	for i, e := range t.Entrants {
+		table.Names[i] = e.Name
+		table.Points[i] = make([]float64, n)
+		table.Games[i] = make([]int, n)
+	}
+
+	games := t.GamesPerPair
+	if games <= 0 {
+		games = 2
+	}
+	r := t.Rand
+	if r == nil {
+		r = rand.New(rand.NewSource(1))
+	}
+
+	for i := range n {
+		for j := i + 1; j < n; j++ {
+			var opening Position[T]
+			for g := range games {
+				if g%2 == 0 {
+					opening = t.opening(r)
+				}
+				maxSide, minSide := i, j
+				if g%2 == 1 {
+					maxSide, minSide = j, i
+				}
+
+				res := PlayGame(t.Game, opening, t.Entrants[maxSide], t.Entrants[minSide], t.MaxPlies)
+				table.record(maxSide, minSide, res.Outcome)
+			}
+		}
+	}
+	return table
+}
+
+// opening plays OpeningPlies random moves from Start
+func (t Tournament[T]) opening(r *rand.Rand) Position[T] {
+	final, plies, _ := Playout(t.Game, t.Start.State, nil, r, t.OpeningPlies)
+	return Position[T]{State: final, IsMax: t.Start.IsMax == (plies%2 == 0)}
+}
+
+// record adds the outcome of a game between the max side and the min side
+func (c CrossTable) record(maxSide, minSide, outcome int) {
+	c.Games[maxSide][minSide]++
+	c.Games[minSide][maxSide]++
+	switch outcome {
+	case 1:
+		c.Points[maxSide][minSide]++
+	case -1:
+		c.Points[minSide][maxSide]++
+	default:
+		c.Points[maxSide][minSide] += 0.5
+		c.Points[minSide][maxSide] += 0.5
+	}
+}
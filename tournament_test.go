@@ -0,0 +1,57 @@
+package minimax
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestPlayGame tests that a perfect player wins a won position against a weak one.
+func TestPlayGame(t *testing.T) {
+	game := Game[nimState]{IsTerminal: nimTerminal, Utility: nimUtility, Successors: nimSuccessors}
+	start := Position[nimState]{State: nimState{stones: 10, aiTurn: true}, IsMax: true}
+	perfect := Entrant{Name: "perfect"}
+	weak := Entrant{Name: "weak", Options: []Option{WithLimits(Limits{MaxDepth: 1})}}
+
+	res := PlayGame(game, start, perfect, weak, 0)
+	if !res.Finished || res.Outcome != 1 {
+		t.Errorf("Expected the max side to win, got %+v", res)
+	}
+}
+
+// TestRoundRobin tests the cross-table of a small tournament.
+func TestRoundRobin(t *testing.T) {
+	tour := Tournament[nimState]{
+		Game:  Game[nimState]{IsTerminal: nimTerminal, Utility: nimUtility, Successors: nimSuccessors},
+		Start: Position[nimState]{State: nimState{stones: 15, aiTurn: true}, IsMax: true},
+		Entrants: []Entrant{
+			{Name: "perfect"},
+			{Name: "shallow", Options: []Option{WithLimits(Limits{MaxDepth: 1})}},
+			{Name: "medium", Options: []Option{WithLimits(Limits{MaxDepth: 4})}},
+		},
+		GamesPerPair: 6,
+		OpeningPlies: 2,
+	}
+	table := tour.RoundRobin()
+
+	for i := range table.Names {
+		for j := range table.Names {
+			if i != j && table.Games[i][j] != 6 {
+				t.Errorf("Expected 6 games between %d and %d, got %d", i, j, table.Games[i][j])
+			}
+			if i != j && table.Points[i][j]+table.Points[j][i] != 6 {
+				t.Errorf("Expected 6 points shared between %d and %d", i, j)
+			}
+		}
+	}
+	if table.Total(0) < table.Total(1) {
+		t.Errorf("Expected the perfect player to beat the shallow one, got %v", table.Points)
+	}
+
+	var sb strings.Builder
+	if _, err := table.WriteTo(&sb); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(sb.String(), "1 perfect") || !strings.Contains(sb.String(), "total") {
+		t.Errorf("Unexpected cross-table:\n%s", sb.String())
+	}
+}
@@ -0,0 +1,187 @@
+package minimax
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// EventKind identifies a step of a search
+type EventKind string
+
+const (
+	EventEnter  EventKind = "enter"  // A node is visited
+	EventExpand EventKind = "expand" // A node's children are generated
+	EventCutoff EventKind = "cutoff" // The remaining children of a node are pruned
+	EventExit   EventKind = "exit"   // A node's value is final
+	EventDone   EventKind = "done"   // The search is over
+)
+
+// Event is a single step of a search. Nodes are identified by the indices of
+// the children leading to them from the root, so the root has an empty path.
+type Event struct {
+	Kind     EventKind `json:"kind"`
+	Path     []int     `json:"path"`
+	Alpha    int       `json:"alpha"`
+	Beta     int       `json:"beta"`
+	Value    int       `json:"value"`
+	Children int       `json:"children,omitempty"` // Number of children, for EventExpand
+	Child    int       `json:"child,omitempty"`    // Child that caused the cutoff, for EventCutoff
+	State    string    `json:"state,omitempty"`    // State of the node, if WithFormat was given; not compared on replay
+}
+
+// equal reports whether two events describe the same step
+func (e Event) equal(o Event) bool {
+	return e.Kind == o.Kind && slices.Equal(e.Path, o.Path) &&
+		e.Alpha == o.Alpha && e.Beta == o.Beta && e.Value == o.Value &&
+		e.Children == o.Children && e.Child == o.Child
+}
+
+// String returns a compact description of the event
+func (e Event) String() string {
+	s := fmt.Sprintf("%s %v [%d,%d] value=%d children=%d child=%d",
+		e.Kind, e.Path, e.Alpha, e.Beta, e.Value, e.Children, e.Child)
+	if e.State != "" {
+		s += fmt.Sprintf(" state=%q", e.State)
+	}
+	return s
+}
+
+// DivergenceError reports the first event at which a replayed search differs from its trace
+type DivergenceError struct {
+	Seq  int    // Position of the event in the trace, starting at 1
+	Want *Event // Recorded event, nil if the trace ended early
+	Got  Event  // Event produced by the search
+}
+
+func (e *DivergenceError) Error() string {
+	if e.Want == nil {
+		return fmt.Sprintf("minimax: replay diverged at event %d: trace ended, got %v", e.Seq, e.Got)
+	}
+	return fmt.Sprintf("minimax: replay diverged at event %d: want %v, got %v", e.Seq, *e.Want, e.Got)
+}
+
+// WithTrace records every node expansion and cutoff decision to w as JSON lines,
+// one Event per line. If Solve has to search again, the new search is appended.
+func WithTrace(w io.Writer) Option {
+	return func(o *options) {
+		o.trace = w
+	}
+}
+
+// WithReplay checks the search step by step against a trace previously recorded
+// with WithTrace. The first difference is reported in Result.Err as a
+// *DivergenceError; it usually means a game function is not deterministic.
+func WithReplay(r io.Reader) Option {
+	return func(o *options) {
+		o.replay = r
+	}
+}
+
+// WithObserver calls fn for every step of the search, along with the state of
+// the node it concerns. The search waits for fn to return, so it can be used to
+// pause and step through a search. Like tracing, it forces a sequential search.
+// The state must not be modified.
+func WithObserver[T comparable](fn func(Event, *T)) Option {
+	return func(o *options) {
+		o.observer = fn
+	}
+}
+
+// tracer writes and replays traces. It outlives single searches so that the
+// searches rerun by Solve continue the same trace.
+type tracer struct {
+	enc *json.Encoder // Nil if not recording
+	dec *json.Decoder // Nil if not replaying
+	seq int           // Events seen so far
+	err error         // First error, recording and replaying stop after it
+}
+
+// newTracer returns a tracer for the given streams, or nil if both are nil
+func newTracer(w io.Writer, r io.Reader) *tracer {
+	if w == nil && r == nil {
+		return nil
+	}
+
+	t := &tracer{}
+	if w != nil {
+		t.enc = json.NewEncoder(w)
+	}
+	if r != nil {
+		t.dec = json.NewDecoder(r)
+	}
+	return t
+}
+
+// record writes ev to the trace and compares it with the replayed trace
+func (t *tracer) record(ev Event) {
+	t.seq++
+	if t.err != nil {
+		return
+	}
+
+	if t.enc != nil {
+		if err := t.enc.Encode(ev); err != nil {
+			t.err = fmt.Errorf("minimax: writing trace: %w", err)
+			return
+		}
+	}
+
+	if t.dec != nil {
+		var want Event
+		switch err := t.dec.Decode(&want); {
+		case errors.Is(err, io.EOF):
+			t.err = &DivergenceError{Seq: t.seq, Got: ev}
+		case err != nil:
+			t.err = fmt.Errorf("minimax: reading trace: %w", err)
+		case !want.equal(ev):
+			t.err = &DivergenceError{Seq: t.seq, Want: &want, Got: ev}
+		}
+	}
+}
+
+// error returns the first error met while tracing, if any
+func (t *tracer) error() error {
+	if t == nil {
+		return nil
+	}
+	return t.err
+}
+
+// tracing reports whether search steps are recorded, replayed or observed
+func (cf *config[T]) tracing() bool {
+	return cf.tracer != nil || cf.observer != nil
+}
+
+// emit records a step of the search at node n, if tracing, replaying or observing.
+// arg is the number of children for EventExpand and the child index for EventCutoff.
+func (s *search[T]) emit(kind EventKind, n *node[T], arg int) {
+	if !s.tracing() {
+		return
+	}
+
+	ev := Event{
+		Kind:  kind,
+		Path:  slices.Clone(s.path),
+		Alpha: n.alpha,
+		Beta:  n.beta,
+		Value: n.val,
+	}
+	switch kind {
+	case EventExpand:
+		ev.Children = arg
+	case EventCutoff:
+		ev.Child = arg
+	}
+	if s.format != nil {
+		ev.State = s.format(n.elem)
+	}
+	if s.tracer != nil {
+		s.tracer.record(ev)
+	}
+	if s.observer != nil {
+		s.observer(ev, n.elem)
+	}
+}
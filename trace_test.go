@@ -0,0 +1,91 @@
+package minimax
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestTraceReplay tests that a recorded trace replays without divergence.
+func TestTraceReplay(t *testing.T) {
+	state := nimState{stones: 7, aiTurn: true}
+
+	var trace bytes.Buffer
+	mm := Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithTrace(&trace))
+	if err := mm.Result().Err; err != nil {
+		t.Fatalf("Unexpected error while tracing: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(trace.String()), "\n")
+	if len(lines) < mm.Result().Nodes*2 {
+		t.Errorf("Expected at least an enter and an exit per node, got %d events for %d nodes",
+			len(lines), mm.Result().Nodes)
+	}
+	if !strings.Contains(lines[len(lines)-1], `"done"`) {
+		t.Errorf("Expected the trace to end with a done event, got %s", lines[len(lines)-1])
+	}
+
+	replay := Make(&state, nimTerminal, nimUtility, nimSuccessors, true,
+		WithReplay(bytes.NewReader(trace.Bytes())))
+	if err := replay.Result().Err; err != nil {
+		t.Errorf("Expected a faithful replay, got %v", err)
+	}
+}
+
+// TestTraceDivergence tests that a replay reports where a non-deterministic game diverges.
+func TestTraceDivergence(t *testing.T) {
+	state := nimState{stones: 7, aiTurn: true}
+
+	var trace bytes.Buffer
+	Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithTrace(&trace))
+
+	// Same game, but moves are generated in reverse order
+	reversed := func(s *nimState) []*nimState {
+		succ := nimSuccessors(s)
+		for i, j := 0, len(succ)-1; i < j; i, j = i+1, j-1 {
+			succ[i], succ[j] = succ[j], succ[i]
+		}
+		return succ
+	}
+	replay := Make(&state, nimTerminal, nimUtility, reversed, true,
+		WithReplay(bytes.NewReader(trace.Bytes())))
+
+	var div *DivergenceError
+	if !errors.As(replay.Result().Err, &div) {
+		t.Fatalf("Expected a DivergenceError, got %v", replay.Result().Err)
+	}
+	if div.Seq <= 1 || div.Want == nil {
+		t.Errorf("Expected divergence after the first event, got %v", div)
+	}
+}
+
+// TestObserver tests that the observer sees the same steps as the trace, with their states.
+func TestObserver(t *testing.T) {
+	state := nimState{stones: 7, aiTurn: true}
+
+	var trace bytes.Buffer
+	var events []Event
+	var states []nimState
+	mm := Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithTrace(&trace), WithParallel(4),
+		WithObserver(func(ev Event, s *nimState) {
+			events = append(events, ev)
+			states = append(states, *s)
+		}))
+
+	lines := strings.Split(strings.TrimSpace(trace.String()), "\n")
+	if len(events) != len(lines) {
+		t.Fatalf("Expected %d observed events, got %d", len(lines), len(events))
+	}
+	if events[0].Kind != EventEnter || states[0] != state {
+		t.Errorf("Expected the first event to enter the root, got %v at %+v", events[0], states[0])
+	}
+	if last := events[len(events)-1]; last.Kind != EventDone || last.Value != mm.Result().Value {
+		t.Errorf("Expected the last event to report the result, got %v", last)
+	}
+
+	move := mm.Solve(state)
+	if cached, ok := mm.Lookup(state); !ok || cached != move {
+		t.Errorf("Expected Lookup to return the solved move, got %v", cached)
+	}
+}
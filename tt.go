@@ -0,0 +1,309 @@
+package minimax
+
+import (
+	"fmt"
+	"math/bits"
+	"sync/atomic"
+)
+
+// Bound tells how the value of a table entry relates to the true value
+type Bound uint8
+
+const (
+	BoundNone  Bound = iota // Empty entry
+	BoundExact              // The value is exact
+	BoundLower              // The true value is at least the value
+	BoundUpper              // The true value is at most the value
+)
+
+// FullDraft is the draft of values that do not depend on a depth limit
+const FullDraft = 255
+
+// NoMove is the move index of entries without a best move
+const NoMove = 255
+
+// Entry is what a Table remembers about a state
+type Entry struct {
+	Value int   // Value of the state for the AI, on the scale of Result.Value
+	Draft int   // Plies searched below the state, FullDraft if the value is proven
+	Bound Bound // How Value relates to the true value
+	Move  int   // Index of the best move in successor order, NoMove if unknown
+}
+
+// Layout of a packed entry, from the least significant bit
+const (
+	valueBits = 16 // Value, as an int16
+	draftBits = 8  // Draft
+	boundBits = 2  // Bound
+	moveBits  = 8  // Move index
+	genBits   = 6  // Generation the entry was stored in
+	checkBits = 24 // Top bits of the hash, to tell apart states sharing a slot
+
+	draftShift = valueBits
+	boundShift = draftShift + draftBits
+	moveShift  = boundShift + boundBits
+	genShift   = moveShift + moveBits
+	checkShift = genShift + genBits
+)
+
+// Table is a transposition table kept across searches, which remembers the
+// values and best moves of states by a 64-bit hash of them, such as one made
+// with the zobrist package. Each entry is packed into a single 64-bit word,
+// so a table holds several times more entries than a map of structs in the
+// same memory, and workers read and write entries atomically without locks.
+//
+// States are told apart by the slot their hash maps to and 24 more bits of
+// it, so with good hashes collisions are rare but possible. When two states
+// compete for a slot, the one searched deeper is kept, unless it was stored
+// in an earlier generation: calling NextGeneration between the moves of a
+// game lets the positions of the current move replace stale ones, which keeps
+// the table useful over long sessions without clearing it.
+type Table struct {
+	entries    []atomic.Uint64
+	mask       uint64
+	generation atomic.Uint64 // Current generation, wrapping around at 1<<genBits
+	verifier   *verifier     // Full states of sampled entries, nil unless verifying
+}
+
+// NewTable returns a table using about size bytes, rounded down to a power of
+// two entries of 8 bytes, with at least one entry
+func NewTable(size int) *Table {
+	n := uint64(1) << (bits.Len64(uint64(max(size/8, 1))) - 1)
+	return &Table{entries: make([]atomic.Uint64, n), mask: n - 1}
+}
+
+// WithTable probes and fills tt while searching, identifying states by hash.
+// A state found in the table with a deep enough draft is not searched again,
+// and the best move recorded for it is searched first otherwise. The root is
+// always searched, so that Solve has a move to return.
+//
+// The table is only sound if the game functions, heuristic, cutoff policy and
+// side the AI plays stay the same for as long as it is used; clear it otherwise.
+func WithTable[T comparable](tt *Table, hash func(*T) uint64) Option {
+	return func(o *options) {
+		o.tt = tt
+		o.hash = hash
+	}
+}
+
+// Len returns the number of entries the table can hold
+func (t *Table) Len() int {
+	return len(t.entries)
+}
+
+// Used returns the number of entries in use
+func (t *Table) Used() int {
+	used := 0
+	for i := range t.entries {
+		if unpack(t.entries[i].Load()).Bound != BoundNone {
+			used++
+		}
+	}
+	return used
+}
+
+// NextGeneration starts a new generation, making every entry stored so far
+// stale. It is typically called once per move played.
+func (t *Table) NextGeneration() {
+	t.generation.Add(1)
+}
+
+// Generation returns the current generation, which wraps around to 0 after 63
+func (t *Table) Generation() int {
+	return int(t.generation.Load() & (1<<genBits - 1))
+}
+
+// Clear empties the table
+func (t *Table) Clear() {
+	for i := range t.entries {
+		t.entries[i].Store(0)
+	}
+}
+
+// Probe returns the entry stored for hash
+func (t *Table) Probe(hash uint64) (Entry, bool) {
+	w := t.entries[hash&t.mask].Load()
+	if w>>checkShift != hash>>(64-checkBits) {
+		return Entry{}, false
+	}
+	e := unpack(w)
+	return e, e.Bound != BoundNone
+}
+
+// Store records e for hash in the current generation, unless the slot holds
+// another state of the same generation searched deeper
+func (t *Table) Store(hash uint64, e Entry) {
+	t.store(hash, e)
+}
+
+// store is Store, reporting whether e was stored
+func (t *Table) store(hash uint64, e Entry) bool {
+	slot := &t.entries[hash&t.mask]
+	old := slot.Load()
+	gen := t.generation.Load()
+	if old>>checkShift != hash>>(64-checkBits) && stamp(old) == gen&(1<<genBits-1) &&
+		unpack(old).Draft > e.Draft {
+		return false
+	}
+	slot.Store(pack(hash, e, gen))
+	return true
+}
+
+// stamp returns the generation a packed entry was stored in
+func stamp(w uint64) uint64 {
+	return w >> genShift & (1<<genBits - 1)
+}
+
+// pack encodes an entry for hash, stored in generation gen, in a single word
+func pack(hash uint64, e Entry, gen uint64) uint64 {
+	return uint64(uint16(int16(e.Value))) |
+		uint64(min(max(e.Draft, 0), FullDraft))<<draftShift |
+		uint64(e.Bound)<<boundShift |
+		uint64(min(max(e.Move, 0), NoMove))<<moveShift |
+		gen&(1<<genBits-1)<<genShift |
+		hash>>(64-checkBits)<<checkShift
+}
+
+// unpack decodes a word made by pack
+func unpack(w uint64) Entry {
+	return Entry{
+		Value: int(int16(uint16(w))),
+		Draft: int(w >> draftShift & (1<<draftBits - 1)),
+		Bound: Bound(w >> boundShift & (1<<boundBits - 1)),
+		Move:  int(w >> moveShift & (1<<moveBits - 1)),
+	}
+}
+
+// draft returns the plies that remain to be searched below n
+func (s *search[T]) draft(n *node[T]) int {
+	if s.limits.MaxDepth == 0 {
+		return FullDraft
+	}
+	return max(s.limits.MaxDepth-n.depth, 0)
+}
+
+// toTable converts the value of n to one that does not depend on its depth:
+// wins and losses count their distance from n rather than from the root
+func toTable[T comparable](n *node[T], v int) int {
+	switch {
+	case v > MaxHeuristic:
+		return v + n.depth
+	case v < -MaxHeuristic:
+		return v - n.depth
+	default:
+		return v
+	}
+}
+
+// fromTable is the inverse of toTable
+func fromTable[T comparable](n *node[T], v int) int {
+	switch {
+	case v > MaxHeuristic:
+		return v - n.depth
+	case v < -MaxHeuristic:
+		return v + n.depth
+	default:
+		return v
+	}
+}
+
+// probe looks n up in the table, returning its value if the entry is deep
+// enough and its bound settles the window [alpha, beta]
+func (s *search[T]) probe(n *node[T], alpha, beta int) (nodeValue, bool) {
+	if s.tt == nil || n.from == nil {
+		return nodeValue{}, false
+	}
+	e, ok := s.tt.probeState(s.hash(n.elem), *n.elem)
+	if !ok {
+		return nodeValue{}, false
+	}
+	unknown := e.Draft != FullDraft
+	if unknown && e.Draft < s.draft(n) {
+		return nodeValue{}, false
+	}
+
+	v := fromTable(n, e.Value)
+	switch {
+	case e.Bound == BoundExact,
+		e.Bound == BoundLower && v >= beta,
+		e.Bound == BoundUpper && v <= alpha:
+		return nodeValue{v, unknown}, true
+	}
+	return nodeValue{}, false
+}
+
+// tableMove returns the child of n recorded as its best move, or nil.
+// n.children must still be in successor order.
+func (s *search[T]) tableMove(n *node[T]) *T {
+	if s.tt == nil {
+		return nil
+	}
+	e, ok := s.tt.Probe(s.hash(n.elem))
+	if !ok || e.Move == NoMove || e.Move >= len(n.children) {
+		return nil
+	}
+	return n.children[e.Move].elem
+}
+
+// record stores the value and best move of n, searched within [alpha, beta]
+func (s *search[T]) record(n *node[T], alpha, beta int) {
+	if s.tt == nil || n.cyclic || s.halted() {
+		return
+	}
+
+	e := Entry{Value: toTable(n, n.val), Draft: s.draft(n), Bound: BoundExact, Move: NoMove}
+	if !n.unknown {
+		e.Draft = FullDraft
+	}
+	switch {
+	case n.val <= alpha:
+		e.Bound = BoundUpper
+	case n.val >= beta:
+		e.Bound = BoundLower
+	}
+	if n.bestMove != nil {
+		for i, child := range n.successors {
+			if child == n.bestMove {
+				e.Move = i
+				break
+			}
+		}
+	}
+	s.tt.storeState(s.hash(n.elem), *n.elem, e)
+}
+
+// Merge copies the entries of other into t, such as tables filled by searches
+// of different parts of a game on different machines. Where both tables hold
+// the same state, the entry searched deeper is kept, an exact value beating
+// a bound of the same draft; where they hold different states in the same
+// slot, the deeper one is kept, t's on ties. Merged entries join t's current
+// generation. other must hold at least as many entries as t, since a smaller
+// table does not remember which of t's slots its entries belong to. Neither
+// table may be searched with during the merge.
+func (t *Table) Merge(other *Table) error {
+	if len(other.entries) < len(t.entries) {
+		return fmt.Errorf("minimax: cannot merge a table of %d entries into one of %d", len(other.entries), len(t.entries))
+	}
+	gen := t.generation.Load()
+	for i := range other.entries {
+		w := other.entries[i].Load()
+		e := unpack(w)
+		if e.Bound == BoundNone {
+			continue
+		}
+		slot := &t.entries[uint64(i)&t.mask]
+		old := slot.Load()
+		o := unpack(old)
+		switch {
+		case o.Bound == BoundNone:
+		case old>>checkShift == w>>checkShift:
+			if o.Draft > e.Draft || o.Draft == e.Draft && (o.Bound == BoundExact || e.Bound != BoundExact) {
+				continue
+			}
+		case o.Draft >= e.Draft:
+			continue
+		}
+		slot.Store(pack(w>>checkShift<<(64-checkBits), e, gen)) // pack only needs the check bits of the hash
+	}
+	return nil
+}
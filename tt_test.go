@@ -0,0 +1,97 @@
+package minimax
+
+import "testing"
+
+// nimHash spreads nim states over 64 bits
+func nimHash(s *nimState) uint64 {
+	h := uint64(s.stones) << 1
+	if s.aiTurn {
+		h |= 1
+	}
+	return (h + 1) * 0x9e3779b97f4a7c15
+}
+
+// TestTableEntries tests that entries survive packing and hash checks.
+func TestTableEntries(t *testing.T) {
+	tt := NewTable(1 << 10)
+	if tt.Len() != 128 {
+		t.Fatalf("Expected 128 entries, got %d", tt.Len())
+	}
+
+	want := Entry{Value: -29990, Draft: 7, Bound: BoundUpper, Move: 3}
+	tt.Store(0xabcdef0000000005, want)
+	if got, ok := tt.Probe(0xabcdef0000000005); !ok || got != want {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+	if _, ok := tt.Probe(0x1234560000000005); ok {
+		t.Error("Expected a different state in the same slot to miss")
+	}
+
+	// A shallower entry of another state does not replace a deeper one
+	tt.Store(0x1234560000000005, Entry{Value: 1, Draft: 2, Bound: BoundExact, Move: NoMove})
+	if _, ok := tt.Probe(0xabcdef0000000005); !ok || tt.Used() != 1 {
+		t.Error("Expected the deeper entry to be kept")
+	}
+
+	// Unless it is stale
+	tt.NextGeneration()
+	tt.Store(0x1234560000000005, Entry{Value: 1, Draft: 2, Bound: BoundExact, Move: NoMove})
+	if _, ok := tt.Probe(0x1234560000000005); !ok || tt.Generation() != 1 {
+		t.Error("Expected the stale entry to be replaced")
+	}
+
+	tt.Clear()
+	if tt.Used() != 0 {
+		t.Error("Expected an empty table")
+	}
+}
+
+// TestTableSearch tests that a table kept between searches saves nodes without changing results.
+func TestTableSearch(t *testing.T) {
+	tt := NewTable(1 << 16)
+	for _, stones := range []int{21, 20, 18} {
+		state := nimState{stones: stones, aiTurn: true}
+		plain := Make(&state, nimTerminal, nimUtility, nimSuccessors, true)
+		cached := Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithTable(tt, nimHash))
+
+		if cached.Result().Value != plain.Result().Value || *cached.Solve(state) != *plain.Solve(state) {
+			t.Errorf("%d stones: expected %+v, got %+v", stones, plain.Result(), cached.Result())
+		}
+		if stones != 21 && cached.Result().Nodes >= plain.Result().Nodes {
+			t.Errorf("%d stones: expected fewer than %d nodes, got %d", stones, plain.Result().Nodes, cached.Result().Nodes)
+		}
+	}
+}
+
+// TestTableMerge tests that merging keeps the better entry of each state.
+func TestTableMerge(t *testing.T) {
+	const a, b, c, d = 0xabcdef0000000005, 0x1234560000000007, 0x9999990000000006, 0x7777770000000007
+	tt, other := NewTable(1<<10), NewTable(1<<11)
+	tt.Store(a, Entry{Value: 1, Draft: 4, Bound: BoundLower, Move: 0})
+	other.Store(a, Entry{Value: 2, Draft: 4, Bound: BoundExact, Move: 1}) // Exact beats a bound of the same draft
+	tt.Store(c, Entry{Value: 3, Draft: 6, Bound: BoundExact, Move: 2})
+	other.Store(c, Entry{Value: 4, Draft: 5, Bound: BoundExact, Move: 2}) // Shallower, dropped
+	tt.Store(d, Entry{Value: 5, Draft: 3, Bound: BoundExact, Move: 0})
+	other.Store(b|0x400, Entry{Value: 6, Draft: 9, Bound: BoundUpper, Move: 0})
+
+	if err := tt.Merge(other); err != nil {
+		t.Fatal(err)
+	}
+	if e, ok := tt.Probe(a); !ok || e.Value != 2 {
+		t.Errorf("Expected the exact entry, got %+v", e)
+	}
+	if e, ok := tt.Probe(c); !ok || e.Value != 3 {
+		t.Errorf("Expected the deeper entry, got %+v", e)
+	}
+	// Slot 0x407 of other is slot 7 of tt, where the deeper state replaces d
+	if e, ok := tt.Probe(b); !ok || e.Value != 6 {
+		t.Errorf("Expected the deeper state of the slot, got %+v", e)
+	}
+	if _, ok := tt.Probe(d); ok {
+		t.Error("Expected the shallower state of the slot to be replaced")
+	}
+
+	if err := other.Merge(NewTable(1 << 10)); err == nil {
+		t.Error("Expected merging a smaller table to fail")
+	}
+}
@@ -0,0 +1,181 @@
+// Package tui turns any game into an interactive terminal app, where a human
+// plays against the engine. A game plugs in by telling how to draw a state
+// and how to name moves; the harness draws the board, reads the human's
+// moves, and shows the engine's evaluation live while it thinks:
+//
+//	h := tui.Harness[State]{
+//		Game:     game,
+//		Render:   func(s *State) string { return s.Board.String() },
+//		MoveName: name,
+//		Options:  []minimax.Option{minimax.WithLimits(minimax.Limits{MaxTime: time.Second})},
+//	}
+//	h.Run(minimax.Position[State]{State: start, IsMax: false}, os.Stdin, os.Stdout)
+//
+// The engine plays the AI's side of the game and the human the other. At the
+// prompt, the human types a move, or one of the commands moves, hint, help and quit.
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+const help = `type a move, or:
+  moves   list the legal moves
+  hint    ask the engine for your best move
+  help    show this help
+  quit    leave the game`
+
+// ErrQuit is returned by Run when the human quits before the end of the game
+var ErrQuit = errors.New("tui: quit")
+
+// Harness plays a game between the engine and a human in a terminal
+type Harness[T comparable] struct {
+	Game     minimax.Game[T]
+	Render   func(*T) string                      // Draws a state, over one or more lines
+	MoveName func(from, to *T) string             // Names the move from one state to a successor, as the human types it
+	Parse    func(s *T, input string) (*T, error) // Plays the human's move, may be nil to pick the successor named input
+	Options  []minimax.Option                     // Options of the engine's searches, such as limits and a heuristic
+	Interval time.Duration                        // Time between evaluation updates while the engine thinks; 500ms if zero
+}
+
+// Run plays a game from start until it ends or the human quits, reading the
+// human's input from in and writing the game to out. It returns the final
+// state, and ErrQuit if the human quit or in ended before the game did.
+func (h Harness[T]) Run(start minimax.Position[T], in io.Reader, out io.Writer) (T, error) {
+	sc := bufio.NewScanner(in)
+	state, isMax := start.State, start.IsMax
+
+	for {
+		fmt.Fprintln(out)
+		fmt.Fprint(out, h.Render(&state))
+		if h.Game.IsTerminal(&state) || len(h.Game.Successors(&state)) == 0 {
+			fmt.Fprintln(out, outcome(h.Game.Utility(&state)))
+			return state, nil
+		}
+
+		var next *T
+		if isMax {
+			next = h.think(&state, out)
+		} else {
+			var err error
+			if next, err = h.ask(&state, sc, out); err != nil {
+				return state, err
+			}
+		}
+		state = *next
+		if h.Game.ToMove != nil {
+			isMax = h.Game.ToMove(&state)
+		} else {
+			isMax = !isMax
+		}
+	}
+}
+
+// think lets the engine pick its move in state, showing its evaluation as it goes
+func (h Harness[T]) think(state *T, out io.Writer) *T {
+	interval := h.Interval
+	if interval <= 0 {
+		interval = 500 * time.Millisecond
+	}
+	progress := func(p minimax.Progress[T]) {
+		if !p.Done && p.BestMove != nil {
+			fmt.Fprintf(out, "  thinking: depth %d, %s, best %s, %d nodes\n",
+				p.Depth, score(p.Score), h.MoveName(state, p.BestMove), p.Nodes)
+		}
+	}
+	opts := append(slices.Clip(h.Options), minimax.WithProgress(interval, progress))
+	mm := h.Game.Make(state, true, opts...)
+	move := mm.Solve(*state)
+	if move == nil {
+		move = h.Game.Successors(state)[0] // Stopped before evaluating any move
+	}
+	res := mm.Result()
+	fmt.Fprintf(out, "engine plays %s (%s, depth %d, %d nodes, %v)\n",
+		h.MoveName(state, move), score(res.Value), res.Depth, res.Nodes, res.Elapsed.Round(time.Millisecond))
+	return move
+}
+
+// ask reads the human's move in state, running commands until one is entered
+func (h Harness[T]) ask(state *T, sc *bufio.Scanner, out io.Writer) (*T, error) {
+	for {
+		fmt.Fprint(out, "your move> ")
+		if !sc.Scan() {
+			return nil, ErrQuit
+		}
+		input := strings.TrimSpace(sc.Text())
+		switch input {
+		case "":
+			continue
+		case "quit", "q":
+			return nil, ErrQuit
+		case "help", "h":
+			fmt.Fprintln(out, help)
+			continue
+		case "moves":
+			var names []string
+			for _, succ := range h.Game.Successors(state) {
+				names = append(names, h.MoveName(state, succ))
+			}
+			fmt.Fprintln(out, strings.Join(names, " "))
+			continue
+		case "hint":
+			mm := h.Game.Make(state, false, h.Options...)
+			if move := mm.Solve(*state); move != nil {
+				fmt.Fprintf(out, "hint: %s (%s)\n", h.MoveName(state, move), score(mm.Result().Value))
+			}
+			continue
+		}
+
+		next, err := h.play(state, input)
+		if err != nil {
+			fmt.Fprintln(out, "error:", err)
+			continue
+		}
+		return next, nil
+	}
+}
+
+// play returns the state after the human's move input
+func (h Harness[T]) play(state *T, input string) (*T, error) {
+	if h.Parse != nil {
+		return h.Parse(state, input)
+	}
+	for _, succ := range h.Game.Successors(state) {
+		if strings.EqualFold(h.MoveName(state, succ), input) {
+			return succ, nil
+		}
+	}
+	return nil, fmt.Errorf("illegal move %q, type moves for a list", input)
+}
+
+// score describes a value for the engine
+func score(v int) string {
+	switch {
+	case v > minimax.MaxHeuristic:
+		return "won for the engine"
+	case v < -minimax.MaxHeuristic:
+		return "lost for the engine"
+	default:
+		return fmt.Sprintf("score %+d", v)
+	}
+}
+
+// outcome announces the end of the game given the utility for the engine
+func outcome(u int) string {
+	switch {
+	case u > 0:
+		return "the engine wins"
+	case u < 0:
+		return "you win"
+	default:
+		return "draw"
+	}
+}
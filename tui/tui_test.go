@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/abtsousa/minimax-go"
+	"github.com/abtsousa/minimax-go/gridgame"
+)
+
+func ticTacToe() (Harness[gridgame.State], minimax.Position[gridgame.State]) {
+	rules := gridgame.Rules{Width: 3, Height: 3, K: 3, AI: gridgame.Second}
+	h := Harness[gridgame.State]{
+		Game:   rules.Game(),
+		Render: func(s *gridgame.State) string { return s.Board.String() },
+		MoveName: func(from, to *gridgame.State) string {
+			return from.Board.CellName(from.Board.Placed(to.Board))
+		},
+	}
+	return h, rules.Start()
+}
+
+// TestRun tests a scripted game, where the engine blocks the human's threat.
+func TestRun(t *testing.T) {
+	h, start := ticTacToe()
+	var out strings.Builder
+	_, err := h.Run(start, strings.NewReader("help\nmoves\nz9\nb2\na1\nhint\nquit\n"), &out)
+	if !errors.Is(err, ErrQuit) {
+		t.Errorf("Expected the human to quit, got %v", err)
+	}
+
+	got := out.String()
+	for _, want := range []string{
+		"hint    ask the engine",
+		"a1 b1 c1 a2 b2 c2 a3 b3 c3",
+		`error: illegal move "z9"`,
+		"engine plays a1 (score +0",
+		"error: illegal move \"a1\"",
+		"hint: ",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+// TestRunToEnd tests that the game ends with the engine's win when the human blunders.
+func TestRunToEnd(t *testing.T) {
+	h, start := ticTacToe()
+	h.Parse = func(s *gridgame.State, input string) (*gridgame.State, error) {
+		i, ok := s.Board.ParseCell(input)
+		if !ok || s.Board.At(i) != gridgame.Empty {
+			return nil, errors.New("no such cell")
+		}
+		return &gridgame.State{Board: s.Board.Set(i, s.Turn), Turn: s.Turn.Opponent()}, nil
+	}
+	var out strings.Builder
+	final, err := h.Run(start, strings.NewReader("a1\nb1\nc3\nq\n"), &out)
+	if err != nil {
+		t.Fatalf("Expected the game to end, got %v:\n%s", err, out.String())
+	}
+	if !strings.HasSuffix(out.String(), "the engine wins\n") || final.Board.Count(gridgame.Second) != 3 {
+		t.Errorf("Expected the engine to win, got:\n%s", out.String())
+	}
+}
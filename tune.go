@@ -0,0 +1,161 @@
+package minimax
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// Gene is a discrete engine setting to tune, such as a cutoff policy, a depth
+// limit or whether an option is on at all
+type Gene struct {
+	Name    string
+	Choices []Entrant // Alternatives, each with a name and the options it adds
+}
+
+// Genome picks one choice per gene, by index
+type Genome []int
+
+// Tuner searches for the best combination of discrete settings with a genetic
+// algorithm. Settings like these have no gradient to follow, so each
+// generation is instead scored by a round-robin tournament between its
+// members, and the next one is bred from the winners.
+type Tuner[T comparable] struct {
+	Tournament  Tournament[T] // Games played to score a generation; its Entrants are ignored
+	Genes       []Gene        // Settings to tune, each with at least one choice
+	Base        []Option      // Options shared by every configuration, applied before the genes
+	Population  int           // Configurations per generation; 8 if not positive
+	Generations int           // Generations bred; 10 if not positive
+	Elite       int           // Best configurations kept unchanged in the next generation; 1 if not positive
+	Mutation    float64       // Chance of each gene being picked at random when breeding; 0.1 if not positive
+	Rand        *rand.Rand    // Source of randomness, also used for openings; seeded with 1 if nil
+}
+
+// Tuning is the outcome of a Tuner run
+type Tuning struct {
+	Best    Genome    // Best configuration of the last generation
+	Entrant Entrant   // Best as an entrant, ready to play or search with
+	Score   float64   // Share of the points Best scored in the last generation, from 0 to 1
+	History []float64 // Share of the points scored by the best configuration of each generation
+}
+
+// Entrant builds the configuration chosen by g, named after its choices
+func (t Tuner[T]) Entrant(g Genome) Entrant {
+	names := make([]string, len(t.Genes))
+	opts := slices.Clone(t.Base)
+	for i, gene := range t.Genes {
+		choice := gene.Choices[g[i]]
+		names[i] = gene.Name + "=" + choice.Name
+		opts = append(opts, choice.Options...)
+	}
+	return Entrant{Name: strings.Join(names, ","), Options: opts}
+}
+
+// Run evolves the population and returns the best configuration found
+func (t Tuner[T]) Run() Tuning {
+	size := cmp.Or(max(t.Population, 0), 8)
+	generations := cmp.Or(max(t.Generations, 0), 10)
+	elite := min(cmp.Or(max(t.Elite, 0), 1), size)
+	mutation := t.Mutation
+	if mutation <= 0 {
+		mutation = 0.1
+	}
+	r := t.Rand
+	if r == nil {
+		r = rand.New(rand.NewSource(1))
+	}
+
+	population := make([]Genome, size)
+	for i := range population {
+		population[i] = t.random(r)
+	}
+
+	var tuning Tuning
+	for gen := range generations {
+		ranked, scores := t.rank(population, r)
+		tuning.Best = population[ranked[0]]
+		tuning.Score = scores[ranked[0]]
+		tuning.History = append(tuning.History, tuning.Score)
+		if gen == generations-1 {
+			break
+		}
+
+		next := make([]Genome, 0, size)
+		for _, i := range ranked[:elite] {
+			next = append(next, population[i])
+		}
+		for len(next) < size {
+			a := population[select2(ranked, r)]
+			b := population[select2(ranked, r)]
+			next = append(next, t.breed(a, b, mutation, r))
+		}
+		population = next
+	}
+
+	tuning.Entrant = t.Entrant(tuning.Best)
+	return tuning
+}
+
+// rank plays a tournament between the members of population and returns
+// their indices from best to worst, along with the share of the points each scored
+func (t Tuner[T]) rank(population []Genome, r *rand.Rand) ([]int, []float64) {
+	tour := t.Tournament
+	tour.Rand = r
+	tour.Entrants = make([]Entrant, len(population))
+	for i, g := range population {
+		tour.Entrants[i] = t.Entrant(g)
+	}
+	table := tour.RoundRobin()
+
+	scores := make([]float64, len(population))
+	for i := range population {
+		games := 0
+		for _, n := range table.Games[i] {
+			games += n
+		}
+		if games > 0 {
+			scores[i] = table.Total(i) / float64(games)
+		}
+	}
+
+	ranked := make([]int, len(population))
+	for i := range ranked {
+		ranked[i] = i
+	}
+	slices.SortStableFunc(ranked, func(a, b int) int {
+		return cmp.Compare(scores[b], scores[a])
+	})
+	return ranked, scores
+}
+
+// random returns a genome with every gene picked at random
+func (t Tuner[T]) random(r *rand.Rand) Genome {
+	g := make(Genome, len(t.Genes))
+	for i, gene := range t.Genes {
+		g[i] = r.Intn(len(gene.Choices))
+	}
+	return g
+}
+
+// breed crosses a and b gene by gene, then mutates the child
+func (t Tuner[T]) breed(a, b Genome, mutation float64, r *rand.Rand) Genome {
+	child := make(Genome, len(t.Genes))
+	for i, gene := range t.Genes {
+		switch {
+		case r.Float64() < mutation:
+			child[i] = r.Intn(len(gene.Choices))
+		case r.Intn(2) == 0:
+			child[i] = a[i]
+		default:
+			child[i] = b[i]
+		}
+	}
+	return child
+}
+
+// select2 picks a parent by binary tournament selection: the better ranked of
+// two members drawn at random
+func select2(ranked []int, r *rand.Rand) int {
+	return ranked[min(r.Intn(len(ranked)), r.Intn(len(ranked)))]
+}
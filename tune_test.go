@@ -0,0 +1,39 @@
+package minimax
+
+import "testing"
+
+// TestTuner tests that the genetic tuner settles on a strong configuration.
+func TestTuner(t *testing.T) {
+	tuner := Tuner[nimState]{
+		Tournament: Tournament[nimState]{
+			Game:         Game[nimState]{IsTerminal: nimTerminal, Utility: nimUtility, Successors: nimSuccessors},
+			Start:        Position[nimState]{State: nimState{stones: 15, aiTurn: true}, IsMax: true},
+			GamesPerPair: 2,
+			OpeningPlies: 2,
+		},
+		Genes: []Gene{
+			{Name: "depth", Choices: []Entrant{
+				{Name: "1", Options: []Option{WithLimits(Limits{MaxDepth: 1})}},
+				{Name: "2", Options: []Option{WithLimits(Limits{MaxDepth: 2})}},
+				{Name: "full"},
+			}},
+			{Name: "cutoff", Choices: []Entrant{
+				{Name: "draw", Options: []Option{WithCutoff(CutoffDraw)}},
+				{Name: "pessimistic", Options: []Option{WithCutoff(CutoffPessimistic)}},
+			}},
+		},
+		Population:  6,
+		Generations: 4,
+	}
+	tuning := tuner.Run()
+
+	if len(tuning.History) != 4 {
+		t.Errorf("Expected a score per generation, got %v", tuning.History)
+	}
+	if tuning.Best[0] != 2 {
+		t.Errorf("Expected the full-depth search to win, got %s", tuning.Entrant.Name)
+	}
+	if tuning.Score < 0.5 {
+		t.Errorf("Expected the best configuration to score at least half the points, got %v", tuning.Score)
+	}
+}
@@ -0,0 +1,146 @@
+package minimax
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ValidationError describes a problem Validate found with a game definition
+type ValidationError[T comparable] struct {
+	State   T      // State that exposed the problem
+	Problem string // What is wrong
+
+	text string // State written with Game.Format, empty to write it with %v
+}
+
+func (e *ValidationError[T]) Error() string {
+	if e.text != "" {
+		return fmt.Sprintf("minimax: invalid game at state %s: %s", e.text, e.Problem)
+	}
+	return fmt.Sprintf("minimax: invalid game at state %v: %s", e.State, e.Problem)
+}
+
+// Validate sanity-checks the functions of g on each sample state and on its
+// successors, returning every problem found joined into one error (nil if
+// none). Problems are reported as *ValidationError values. It checks that:
+//   - none of the functions panic
+//   - terminal states have no successors
+//   - utilities of terminal states, and of non-terminal states without
+//     successors, which are scored by their utility, are within
+//     [MinUtility, MaxUtility]
+//   - successors are not nil, not the parent state, and not the same pointer twice
+//   - generating successors leaves the parent unchanged and is deterministic
+//   - the player to move alternates, if ToMove is set
+//
+// Most "the AI is broken" reports turn out to be broken game definitions, so
+// it is worth running on a handful of states from each phase of the game.
+func Validate[T comparable](g Game[T], samples []T) error {
+	lo, hi := g.MinUtility, g.MaxUtility
+	if lo == 0 && hi == 0 {
+		lo, hi = -1, 1
+	}
+
+	var errs []error
+	report := func(state T, format string, args ...any) {
+		e := &ValidationError[T]{State: state, Problem: fmt.Sprintf(format, args...)}
+		if g.Format != nil {
+			e.text = g.Format(&state)
+		}
+		errs = append(errs, e)
+	}
+
+	for _, sample := range samples {
+		state := sample
+		terminal, err := safely(func() bool { return g.IsTerminal(&state) })
+		if err != nil {
+			report(sample, "IsTerminal panicked: %v", err)
+			continue
+		}
+
+		succ, err := safely(func() []*T { return g.Successors(&state) })
+		if err != nil {
+			report(sample, "Successors panicked: %v", err)
+			continue
+		}
+		if state != sample {
+			report(sample, "Successors modified the state it was given")
+			state = sample
+		}
+
+		if terminal {
+			if len(succ) > 0 {
+				report(sample, "terminal state has %d successors", len(succ))
+			}
+			checkUtility(g, sample, lo, hi, report)
+			continue
+		}
+		if len(succ) == 0 {
+			checkUtility(g, sample, lo, hi, report) // Scored as terminal, like a player left without moves
+			continue
+		}
+
+		again, _ := safely(func() []*T { return g.Successors(&state) })
+		if !sameStates(succ, again) {
+			report(sample, "Successors returned different states on a second call")
+		}
+
+		seen := make(map[*T]bool, len(succ))
+		for i, child := range succ {
+			switch {
+			case child == nil:
+				report(sample, "successor %d is nil", i)
+				continue
+			case child == &state || *child == sample:
+				report(sample, "successor %d is the state itself", i)
+			case seen[child]:
+				report(sample, "successor %d is a pointer already returned for another successor", i)
+			}
+			seen[child] = true
+
+			if g.ToMove != nil && g.ToMove(child) == g.ToMove(&state) {
+				report(sample, "successor %d has the same player to move", i)
+			}
+			if childTerminal, err := safely(func() bool { return g.IsTerminal(child) }); err != nil {
+				report(*child, "IsTerminal panicked: %v", err)
+			} else if childTerminal {
+				checkUtility(g, *child, lo, hi, report)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkUtility reports a state scored by its utility whose utility is out of range
+func checkUtility[T comparable](g Game[T], state T, lo, hi int, report func(T, string, ...any)) {
+	u, err := safely(func() int { return g.Utility(&state) })
+	switch {
+	case err != nil:
+		report(state, "Utility panicked: %v", err)
+	case u < lo || u > hi:
+		report(state, "utility %d is outside [%d, %d]", u, lo, hi)
+	}
+}
+
+// sameStates reports whether two successor lists hold equal states in the same order
+func sameStates[T comparable](a, b []*T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if (a[i] == nil) != (b[i] == nil) || (a[i] != nil && *a[i] != *b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// safely calls f, turning a panic into an error
+func safely[R any](f func() R) (r R, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("%v", p)
+		}
+	}()
+	return f(), nil
+}
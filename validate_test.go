@@ -0,0 +1,75 @@
+package minimax
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestValidateGoodGame tests that a correct game passes validation.
+func TestValidateGoodGame(t *testing.T) {
+	game := Game[nimState]{
+		IsTerminal: nimTerminal,
+		Utility:    nimUtility,
+		Successors: nimSuccessors,
+		ToMove:     func(s *nimState) bool { return s.aiTurn },
+	}
+	samples := []nimState{{stones: 0, aiTurn: true}, {stones: 1, aiTurn: false}, {stones: 9, aiTurn: true}}
+
+	if err := Validate(game, samples); err != nil {
+		t.Errorf("Expected no problems, got %v", err)
+	}
+
+	// A player left without moves is scored by the utility, which must be in range
+	stuck := game
+	stuck.Successors = func(s *nimState) []*nimState {
+		if s.stones == 5 {
+			return nil
+		}
+		return nimSuccessors(s)
+	}
+	if err := Validate(stuck, []nimState{{stones: 5, aiTurn: true}}); err != nil {
+		t.Errorf("Expected a state without moves to pass, got %v", err)
+	}
+	stuck.Utility = func(*nimState) int { return 7 }
+	if err := Validate(stuck, []nimState{{stones: 5, aiTurn: true}}); err == nil || !strings.Contains(err.Error(), "outside [-1, 1]") {
+		t.Errorf("Expected the utility of a state without moves to be checked, got %v", err)
+	}
+}
+
+// TestValidateBrokenGame tests that common mistakes in game definitions are reported.
+func TestValidateBrokenGame(t *testing.T) {
+	game := Game[nimState]{
+		IsTerminal: nimTerminal,
+		Utility: func(s *nimState) int {
+			return 100 * nimUtility(s) // Out of range
+		},
+		Successors: func(s *nimState) []*nimState {
+			succ := nimSuccessors(s)
+			if s.stones == 0 {
+				succ = append(succ, s) // Terminal state with a successor
+			}
+			if len(succ) > 1 {
+				succ[1] = succ[0] // Same pointer twice
+			}
+			return succ
+		},
+		ToMove: func(s *nimState) bool { return true }, // Never alternates
+	}
+	err := Validate(game, []nimState{{stones: 0, aiTurn: true}, {stones: 5, aiTurn: true}})
+	if err == nil {
+		t.Fatal("Expected problems to be found")
+	}
+
+	for _, want := range []string{"terminal state has 1 successors", "outside [-1, 1]",
+		"pointer already returned", "same player to move"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected a problem mentioning %q, got:\n%v", want, err)
+		}
+	}
+
+	var verr *ValidationError[nimState]
+	if !errors.As(err, &verr) {
+		t.Error("Expected problems to be ValidationErrors")
+	}
+}
@@ -0,0 +1,117 @@
+package minimax
+
+import "sync"
+
+// Verification reports what a Table in verification mode found, counting
+// only the sampled states
+type Verification struct {
+	States     int // Distinct states probed or stored
+	Collisions int // States whose 64-bit hash an earlier, different state already had
+	Hits       int // Probes that found an entry, and used it if deep enough
+	FalseHits  int // Hits on an entry stored for a different state
+}
+
+// FalseHitRate returns the fraction of hits that found another state's entry
+func (v Verification) FalseHitRate() float64 {
+	if v.Hits == 0 {
+		return 0
+	}
+	return float64(v.FalseHits) / float64(v.Hits)
+}
+
+// verifier remembers the full states behind the sampled entries of a Table
+type verifier struct {
+	mu     sync.Mutex
+	rate   uint64
+	slots  map[uint64]any   // State whose entry each sampled slot holds
+	states map[uint64][]any // Distinct states seen with each sampled hash
+	stats  Verification
+}
+
+// Verify puts the table in verification mode, in which searches also keep
+// the full states of one in rate hashes (every one if rate ≤ 1), and check
+// that the entries they find were stored for the same state. It measures how
+// often the table mistakes a state for another, and how often the hash
+// function itself collides, before trusting hashes alone for a strong solve.
+//
+// States are sampled by the bits of their hash the table keeps, so a state
+// is sampled along with any other it could be mistaken for. Verification
+// costs memory and a lock per sampled probe; it must be enabled before the
+// table is searched with, and only entries stored by searches are checked.
+func (t *Table) Verify(rate int) {
+	t.verifier = &verifier{
+		rate:   uint64(max(rate, 1)),
+		slots:  make(map[uint64]any),
+		states: make(map[uint64][]any),
+	}
+}
+
+// Verification returns what verification mode found so far, or zero if the
+// table is not in verification mode
+func (t *Table) Verification() Verification {
+	if t.verifier == nil {
+		return Verification{}
+	}
+	t.verifier.mu.Lock()
+	defer t.verifier.mu.Unlock()
+	return t.verifier.stats
+}
+
+// sampled returns the verifier if state with the given hash is verified, else nil
+func (t *Table) sampled(hash uint64) *verifier {
+	if v := t.verifier; v != nil && hash>>(64-checkBits)%v.rate == 0 {
+		return v
+	}
+	return nil
+}
+
+// see notes a state with the given hash, counting collisions of full hashes.
+// v.mu must be held.
+func (v *verifier) see(hash uint64, state any) {
+	for _, s := range v.states[hash] {
+		if s == state {
+			return
+		}
+	}
+	if len(v.states[hash]) > 0 {
+		v.stats.Collisions++
+	}
+	v.states[hash] = append(v.states[hash], state)
+	v.stats.States++
+}
+
+// probeState is Probe, checking that the entry found belongs to state if sampled
+func (t *Table) probeState(hash uint64, state any) (Entry, bool) {
+	v := t.sampled(hash)
+	if v == nil {
+		return t.Probe(hash)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.see(hash, state)
+	e, ok := t.Probe(hash)
+	if ok {
+		v.stats.Hits++
+		if v.slots[hash&t.mask] != state {
+			v.stats.FalseHits++
+		}
+	}
+	return e, ok
+}
+
+// storeState is Store, remembering the state the entry was stored for if sampled
+func (t *Table) storeState(hash uint64, state any, e Entry) {
+	v := t.sampled(hash)
+	if v == nil {
+		t.store(hash, e)
+		return
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.see(hash, state)
+	if t.store(hash, e) {
+		v.slots[hash&t.mask] = state
+	}
+}
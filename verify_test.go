@@ -0,0 +1,56 @@
+package minimax
+
+import "testing"
+
+// TestVerifyGoodHash tests that a sound hash shows no collisions in verification mode.
+func TestVerifyGoodHash(t *testing.T) {
+	tt := NewTable(1 << 16)
+	tt.Verify(1)
+	for _, stones := range []int{21, 20, 18} {
+		state := nimState{stones: stones, aiTurn: true}
+		Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithTable(tt, nimHash))
+	}
+
+	v := tt.Verification()
+	if v.States != 43 || v.Hits == 0 {
+		t.Errorf("Expected 43 states and some hits, got %+v", v)
+	}
+	if v.Collisions != 0 || v.FalseHits != 0 || v.FalseHitRate() != 0 {
+		t.Errorf("Expected no collisions, got %+v", v)
+	}
+}
+
+// TestVerifyBadHash tests that verification mode catches a hash mapping several states to one value.
+func TestVerifyBadHash(t *testing.T) {
+	bad := func(s *nimState) uint64 {
+		return nimHash(&nimState{stones: s.stones % 4, aiTurn: s.aiTurn})
+	}
+	tt := NewTable(1 << 16)
+	tt.Verify(1)
+	state := nimState{stones: 15, aiTurn: true}
+	Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithTable(tt, bad))
+
+	v := tt.Verification()
+	if v.States != 30 || v.Collisions != 22 {
+		t.Errorf("Expected 30 states, 22 of them colliding, got %+v", v)
+	}
+	if v.FalseHits == 0 || v.FalseHitRate() <= 0 || v.FalseHitRate() > 1 {
+		t.Errorf("Expected false hits, got %+v", v)
+	}
+}
+
+// TestVerifySample tests that sampling keeps only some states, and nothing without verification.
+func TestVerifySample(t *testing.T) {
+	plain, sampled := NewTable(1<<16), NewTable(1<<16)
+	sampled.Verify(4)
+	state := nimState{stones: 40, aiTurn: true}
+	Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithTable(plain, nimHash))
+	Make(&state, nimTerminal, nimUtility, nimSuccessors, true, WithTable(sampled, nimHash))
+
+	if v := plain.Verification(); v != (Verification{}) {
+		t.Errorf("Expected nothing verified, got %+v", v)
+	}
+	if v := sampled.Verification(); v.States == 0 || v.States >= 82 || v.FalseHits != 0 {
+		t.Errorf("Expected some of the 82 states sampled, got %+v", v)
+	}
+}
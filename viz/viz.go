@@ -0,0 +1,191 @@
+// Package viz streams a running search to a browser. A Server serves a viewer
+// page and pushes the search events to it over a websocket, so expansions and
+// cutoffs can be watched as they happen:
+//
+//	srv := viz.NewServer(3)
+//	go http.ListenAndServe("localhost:8080", srv)
+//	mm := minimax.Make(&state, isTerminal, utility, successors, true,
+//		viz.Observe(srv, func(s *State) string { return s.String() }))
+//
+// Observing forces a sequential search, and the search never waits for slow
+// viewers: events they cannot keep up with are dropped.
+package viz
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+//go:embed viewer.html
+var viewer []byte
+
+// Message is a search event as sent to viewers
+type Message struct {
+	minimax.Event
+	Label string `json:"label,omitempty"` // Description of the node's state
+}
+
+// Server serves the viewer page at / and the event stream at /ws
+type Server struct {
+	maxDepth int           // Deepest ply streamed, 0 for all
+	delay    time.Duration // Pause after each streamed event
+
+	mu      sync.Mutex
+	origins []string // Origins of pages allowed to connect besides the server's own
+	clients map[*conn]chan []byte
+}
+
+// NewServer returns a server streaming the top maxDepth plies of the tree,
+// or all of it if maxDepth is 0
+func NewServer(maxDepth int) *Server {
+	return &Server{maxDepth: maxDepth, clients: make(map[*conn]chan []byte)}
+}
+
+// SetDelay slows the search down by pausing after every streamed event, so
+// that small searches can be followed by eye
+func (s *Server) SetDelay(d time.Duration) {
+	s.mu.Lock()
+	s.delay = d
+	s.mu.Unlock()
+}
+
+// AllowOrigins lets pages served from the given origins, such as
+// "http://localhost:3000", connect to the event stream. By default only the
+// viewer served by s itself, and clients sending no Origin such as command
+// line tools, may connect: otherwise any page open in the browser could
+// watch the search.
+func (s *Server) AllowOrigins(origins ...string) {
+	s.mu.Lock()
+	s.origins = append(s.origins, origins...)
+	s.mu.Unlock()
+}
+
+// allowed reports whether a websocket request may connect, given its Origin
+func (s *Server) allowed(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true // Not sent by a browser
+	}
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Contains(s.origins, origin)
+}
+
+// Observe returns an option that streams the search to s, labelling nodes with
+// label if it is not nil, or with the State of events given WithFormat
+func Observe[T comparable](s *Server, label func(*T) string) minimax.Option {
+	return minimax.WithObserver(func(ev minimax.Event, state *T) {
+		if s.maxDepth > 0 && len(ev.Path) > s.maxDepth && ev.Kind != minimax.EventDone {
+			return
+		}
+
+		msg := Message{Event: ev, Label: ev.State}
+		if label != nil {
+			msg.Label = label(state)
+		}
+		s.Publish(msg)
+	})
+}
+
+// Publish sends a message to every connected viewer
+func (s *Server) Publish(msg Message) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	for _, ch := range s.clients {
+		select {
+		case ch <- data:
+		default: // The viewer is falling behind, drop the event
+		}
+	}
+	delay := s.delay
+	s.mu.Unlock()
+
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+}
+
+// Clients returns the number of connected viewers
+func (s *Server) Clients() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.clients)
+}
+
+// ServeHTTP serves the viewer page and the websocket stream
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/", "/index.html":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(viewer)
+	case "/ws":
+		s.serveStream(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// serveStream upgrades the request and streams events until the viewer leaves
+func (s *Server) serveStream(w http.ResponseWriter, r *http.Request) {
+	if !s.allowed(r) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	}
+	c, err := upgrade(w, r)
+	if err != nil {
+		return
+	}
+
+	ch := make(chan []byte, 4096)
+	s.mu.Lock()
+	s.clients[c] = ch
+	s.mu.Unlock()
+
+	closed := make(chan struct{})
+	go func() {
+		// Read client frames only to answer pings and notice the close
+		defer close(closed)
+		for {
+			op, payload, err := c.readFrame()
+			if err != nil || op == opClose {
+				return
+			}
+			if op == opPing {
+				c.writeFrame(opPong, payload)
+			}
+		}
+	}()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, c)
+		s.mu.Unlock()
+		c.writeFrame(opClose, nil)
+		c.Close()
+	}()
+	for {
+		select {
+		case data := <-ch:
+			if c.writeFrame(opText, data) != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
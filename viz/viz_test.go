@@ -0,0 +1,204 @@
+package viz
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abtsousa/minimax-go"
+)
+
+// countdown is a game where players take 1 or 2 from a counter, and whoever takes the last one wins
+type countdown struct {
+	n      int
+	aiTurn bool
+}
+
+func countdownTerminal(s *countdown) bool { return s.n == 0 }
+
+func countdownUtility(s *countdown) int {
+	if s.aiTurn {
+		return -1
+	}
+	return 1
+}
+
+func countdownSuccessors(s *countdown) []*countdown {
+	var succ []*countdown
+	for take := 1; take <= 2 && take <= s.n; take++ {
+		succ = append(succ, &countdown{s.n - take, !s.aiTurn})
+	}
+	return succ
+}
+
+// dial opens a websocket to the server and returns a reader positioned after the handshake
+func dial(t *testing.T, srv *httptest.Server) (net.Conn, *bufio.Reader) {
+	c, err := net.Dial("tcp", strings.TrimPrefix(srv.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := "dGhlIHNhbXBsZSBub25jZQ=="
+	fmt.Fprintf(c, "GET /ws HTTP/1.1\r\nHost: x\r\nConnection: Upgrade\r\nUpgrade: websocket\r\n"+
+		"Sec-WebSocket-Version: 13\r\nSec-WebSocket-Key: %s\r\n\r\n", key)
+
+	r := bufio.NewReader(c)
+	resp, err := http.ReadResponse(r, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("Expected a protocol switch, got %s", resp.Status)
+	}
+	if got := resp.Header.Get("Sec-WebSocket-Accept"); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("Unexpected accept key %q", got)
+	}
+	return c, r
+}
+
+// readMessage reads a text frame sent by the server
+func readMessage(t *testing.T, r *bufio.Reader) Message {
+	var h [2]byte
+	if _, err := io.ReadFull(r, h[:]); err != nil {
+		t.Fatal(err)
+	}
+	if h[0] != 0x81 {
+		t.Fatalf("Expected a text frame, got header %x", h)
+	}
+	n := int(h[1])
+	if n == 126 {
+		var b [2]byte
+		io.ReadFull(r, b[:])
+		n = int(b[0])<<8 | int(b[1])
+	}
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		t.Fatal(err)
+	}
+
+	var msg Message
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+// TestStream tests that a search is streamed to a websocket viewer, up to the depth limit.
+func TestStream(t *testing.T) {
+	vs := NewServer(1)
+	srv := httptest.NewServer(vs)
+	defer srv.Close()
+
+	c, r := dial(t, srv)
+	defer c.Close()
+	for vs.Clients() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	state := countdown{5, true}
+	mm := minimax.Make(&state, countdownTerminal, countdownUtility, countdownSuccessors, true,
+		Observe(vs, func(s *countdown) string { return fmt.Sprint(s.n) }))
+
+	first := readMessage(t, r)
+	if first.Kind != minimax.EventEnter || len(first.Path) != 0 || first.Label != "5" {
+		t.Errorf("Expected the root to be entered first, got %+v", first)
+	}
+	for {
+		msg := readMessage(t, r)
+		if len(msg.Path) > 1 {
+			t.Errorf("Expected only the top ply, got %+v", msg)
+		}
+		if msg.Kind == minimax.EventDone {
+			if msg.Value != mm.Result().Value {
+				t.Errorf("Expected the done event to carry value %d, got %d", mm.Result().Value, msg.Value)
+			}
+			break
+		}
+	}
+
+	// A masked close frame from the client ends the stream
+	c.Write([]byte{0x88, 0x80, 1, 2, 3, 4})
+	for vs.Clients() != 0 {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestViewer tests that the viewer page is served and plain requests to the stream are refused.
+func TestViewer(t *testing.T) {
+	srv := httptest.NewServer(NewServer(0))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(body), "new WebSocket") {
+		t.Errorf("Expected the viewer page, got %.60q", body)
+	}
+
+	resp, err = http.Get(srv.URL + "/ws")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected a bad request for a non-websocket stream request, got %s", resp.Status)
+	}
+}
+
+// handshake requests a websocket from the server with the given Origin, none
+// if empty, and returns the status of the response
+func handshake(t *testing.T, srv *httptest.Server, origin string) int {
+	req, err := http.NewRequest("GET", srv.URL+"/ws", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+// TestOrigin tests that pages from other origins cannot connect unless allowed.
+func TestOrigin(t *testing.T) {
+	vs := NewServer(0)
+	srv := httptest.NewServer(vs)
+	defer srv.Close()
+
+	tests := []struct {
+		origin string
+		want   int
+	}{
+		{"", http.StatusSwitchingProtocols},
+		{srv.URL, http.StatusSwitchingProtocols},
+		{"http://evil.example", http.StatusForbidden},
+		{"null", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		if got := handshake(t, srv, tt.origin); got != tt.want {
+			t.Errorf("Origin %q: expected status %d, got %d", tt.origin, tt.want, got)
+		}
+	}
+
+	vs.AllowOrigins("http://evil.example")
+	if got := handshake(t, srv, "http://evil.example"); got != http.StatusSwitchingProtocols {
+		t.Errorf("Expected an allowed origin to connect, got status %d", got)
+	}
+}
@@ -0,0 +1,33 @@
+package minimax
+
+// WithWidening enables progressive widening, a gentler alternative to
+// WithBeam: a node keeps base children (at least 1) when it is one ply from
+// the depth limit, and step more for every further ply left to search below
+// it. Nodes close to the root, which decide the move, are searched wide and
+// the frontier narrow, and every iteration of SolveClock widens all nodes
+// since it searches one ply deeper, so early iterations are quick and later
+// ones catch the moves they missed.
+//
+// Children are ranked as for WithBeam, and if both are given a node keeps the
+// fewer children of the two. Widening needs a depth limit and is skipped without one.
+func WithWidening(base, step int) Option {
+	return func(o *options) {
+		o.widenBase = max(base, 1)
+		o.widenStep = max(step, 0)
+	}
+}
+
+// width returns how many children n keeps, 0 for all of them
+func (s *search[T]) width(n *node[T]) int {
+	if (s.beam > 0 || s.widenBase > 0) && s.exact(n) {
+		return 0
+	}
+	width := s.beam
+	if s.widenBase > 0 && s.limits.MaxDepth > 0 {
+		widened := s.widenBase + s.widenStep*max(s.limits.MaxDepth-n.depth-1, 0)
+		if width == 0 || widened < width {
+			width = widened
+		}
+	}
+	return width
+}
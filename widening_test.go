@@ -0,0 +1,37 @@
+package minimax
+
+import "testing"
+
+// TestWidening tests that progressive widening searches the frontier narrower than the root.
+func TestWidening(t *testing.T) {
+	state := pathState{depth: pathDepth}
+	h := WithHeuristic(func(s *pathState) int { return 1000 * (int(s.path%7) - 3) })
+	build := func(depth int, opts ...Option) Result {
+		opts = append([]Option{WithLimits(Limits{MaxDepth: depth}), h}, opts...)
+		mm := Make(&state, pathTerminal, pathUtility, pathSuccessors, true, opts...)
+		mm.Solve(pathState{})
+		return mm.Result()
+	}
+
+	plain := build(6)
+	if full := build(6, WithWidening(3, 0)); full.Nodes != plain.Nodes || full.Value != plain.Value {
+		t.Errorf("Expected a full width to change nothing, got %+v instead of %+v", full, plain)
+	}
+	widened := build(6, WithWidening(1, 1))
+	if widened.Nodes >= plain.Nodes || !widened.Unknown {
+		t.Errorf("Expected fewer than %d nodes, got %+v", plain.Nodes, widened)
+	}
+
+	// The root keeps 1 child one ply from the limit, 2 two plies from it
+	if r := build(1, WithWidening(1, 1)); r.Nodes != 2 {
+		t.Errorf("Expected 2 nodes, got %d", r.Nodes)
+	}
+	if r := build(2, WithWidening(1, 1)); r.Nodes != 5 {
+		t.Errorf("Expected 5 nodes, got %d", r.Nodes)
+	}
+
+	// The narrower of a beam and widening applies
+	if r := build(2, WithWidening(1, 1), WithBeam(1)); r.Nodes != 3 {
+		t.Errorf("Expected 3 nodes, got %d", r.Nodes)
+	}
+}
@@ -0,0 +1,146 @@
+// Package zobrist generates Zobrist hashes for game states. A Zobrist table
+// assigns a random 64-bit key to every feature a state can have, such as "a
+// white pawn on e4" or "black to move", and the hash of a state is the XOR of
+// the keys of its features. Since XOR is its own inverse, a move updates the
+// hash by toggling the features it changes, without rehashing the state.
+//
+// Hashes make cheap, well spread comparable keys, for instance as a field of
+// the state type or as the whole state of a lookup table:
+//
+//	table := zobrist.NewBoard(64, 12, 1, zobrist.Seed("chess"))
+//	h := table.Hash(table.Cell(12, pawn), table.Cell(52, pawn))
+//	h = table.Move(h, table.Cell(12, pawn), table.Cell(28, pawn))
+//	h = table.Toggle(h, table.Len()-1) // Side to move
+package zobrist
+
+import (
+	"hash/fnv"
+	"math/rand"
+)
+
+// Table holds a random key per feature
+type Table struct {
+	keys   []uint64
+	pieces int // Pieces per cell, for Cell, 0 if unknown
+}
+
+// New returns a table of the given number of features, filled from seed.
+// The same seed always gives the same keys, so hashes can be stored and
+// compared across runs.
+func New(features int, seed int64) *Table {
+	src := NewSource(uint64(seed))
+	return fill(features, src.Uint64)
+}
+
+// NewRand returns a table of the given number of features, filled from r
+func NewRand(features int, r *rand.Rand) *Table {
+	return fill(features, r.Uint64)
+}
+
+// NewBoard returns a table for a board of cells cells, each of which may hold
+// one of pieces kinds of pieces, followed by extra features such as the side
+// to move or castling rights. Board features are numbered by Cell, extra
+// features follow them.
+func NewBoard(cells, pieces, extra int, seed int64) *Table {
+	t := New(Features(cells, pieces)+extra, seed)
+	t.pieces = pieces
+	return t
+}
+
+// fill draws a key per feature, rejecting zero and repeated keys since they
+// would make features indistinguishable
+func fill(features int, next func() uint64) *Table {
+	t := &Table{keys: make([]uint64, features)}
+	seen := make(map[uint64]bool, features)
+	for i := range t.keys {
+		k := next()
+		for k == 0 || seen[k] {
+			k = next()
+		}
+		seen[k] = true
+		t.keys[i] = k
+	}
+	return t
+}
+
+// Features returns the number of features of a board of cells cells with
+// pieces kinds of pieces
+func Features(cells, pieces int) int {
+	return cells * pieces
+}
+
+// Cell returns the feature of a piece on a cell of a table made by NewBoard
+func (t *Table) Cell(cell, piece int) int {
+	if t.pieces == 0 {
+		panic("zobrist: Cell needs a table made by NewBoard")
+	}
+	return cell*t.pieces + piece
+}
+
+// Len returns the number of features
+func (t *Table) Len() int {
+	return len(t.keys)
+}
+
+// Key returns the key of a feature
+func (t *Table) Key(feature int) uint64 {
+	return t.keys[feature]
+}
+
+// Hash returns the hash of a state with the given features
+func (t *Table) Hash(features ...int) uint64 {
+	var h uint64
+	for _, f := range features {
+		h ^= t.keys[f]
+	}
+	return h
+}
+
+// Toggle adds a feature to the hash h, or removes it if it was there
+func (t *Table) Toggle(h uint64, feature int) uint64 {
+	return h ^ t.keys[feature]
+}
+
+// Move removes feature from and adds feature to, as when a piece moves
+func (t *Table) Move(h uint64, from, to int) uint64 {
+	return h ^ t.keys[from] ^ t.keys[to]
+}
+
+// Source is a SplitMix64 generator. It is fast, has a single 64-bit word of
+// state, and every seed, including 0, gives a good sequence.
+type Source struct {
+	state uint64
+}
+
+// NewSource returns a generator starting from seed
+func NewSource(seed uint64) *Source {
+	return &Source{state: seed}
+}
+
+// Uint64 returns the next number of the sequence
+func (s *Source) Uint64() uint64 {
+	s.state += 0x9e3779b97f4a7c15
+	z := s.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// Int63 returns the next number of the sequence without its sign bit, so a
+// Source can back a math/rand.Rand
+func (s *Source) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+// Seed sets the state of the generator
+func (s *Source) Seed(seed int64) {
+	s.state = uint64(seed)
+}
+
+// Seed derives a seed from a name, so that each game can have its own
+// reproducible table without picking numbers by hand
+func Seed(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return int64(h.Sum64())
+}
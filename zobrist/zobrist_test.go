@@ -0,0 +1,53 @@
+package zobrist
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestIncremental tests that toggling features gives the hash of the resulting state.
+func TestIncremental(t *testing.T) {
+	table := NewBoard(9, 2, 1, Seed("tictactoe"))
+	side := table.Len() - 1
+
+	h := table.Hash(table.Cell(4, 0))
+	h = table.Toggle(h, table.Cell(0, 1))
+	h = table.Toggle(h, side)
+	if want := table.Hash(side, table.Cell(0, 1), table.Cell(4, 0)); h != want {
+		t.Errorf("Expected %x, got %x", want, h)
+	}
+
+	h = table.Move(h, table.Cell(0, 1), table.Cell(8, 1))
+	if want := table.Hash(side, table.Cell(4, 0), table.Cell(8, 1)); h != want {
+		t.Errorf("Expected %x after a move, got %x", want, h)
+	}
+	if table.Toggle(h, side) == h {
+		t.Error("Expected toggling a feature to change the hash")
+	}
+}
+
+// TestDeterministic tests that a seed always gives the same distinct keys.
+func TestDeterministic(t *testing.T) {
+	a, b := New(1000, 42), New(1000, 42)
+	seen := make(map[uint64]bool)
+	for i := range a.Len() {
+		if a.Key(i) != b.Key(i) {
+			t.Fatalf("Expected equal keys for feature %d", i)
+		}
+		if a.Key(i) == 0 || seen[a.Key(i)] {
+			t.Fatalf("Expected a distinct non-zero key for feature %d", i)
+		}
+		seen[a.Key(i)] = true
+	}
+	if New(1, 43).Key(0) == a.Key(0) {
+		t.Error("Expected different seeds to give different keys")
+	}
+	if Seed("chess") == Seed("checkers") {
+		t.Error("Expected different names to give different seeds")
+	}
+
+	r := rand.New(NewSource(7))
+	if NewRand(10, r).Len() != 10 {
+		t.Error("Expected a table of 10 features")
+	}
+}